@@ -5,12 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"path/filepath"
+	"sync/atomic"
+	"time"
 
-	"github.com/hasura/ndc-http/connector/internal"
 	"github.com/hasura/ndc-http/exhttp"
 	"github.com/hasura/ndc-http/ndc-http-schema/configuration"
-	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
 	"github.com/hasura/ndc-sdk-go/v2/connector"
 	"github.com/hasura/ndc-sdk-go/v2/schema"
 	"go.opentelemetry.io/otel/attribute"
@@ -18,13 +17,11 @@ import (
 
 // HTTPConnector implements the SDK interface of NDC specification.
 type HTTPConnector struct {
-	config              *configuration.Configuration
-	metadata            internal.MetadataCollection
-	capabilities        *schema.RawCapabilitiesResponse
-	rawSchema           *schema.RawSchemaResponse
-	httpClient          *http.Client
-	upstreams           *internal.UpstreamManager
-	procSendHttpRequest *rest.OperationInfo
+	capabilities      *schema.RawCapabilitiesResponse
+	httpClient        *http.Client
+	fileWatchInterval time.Duration
+	configurationDir  string
+	snapshot          atomic.Pointer[connectorSnapshot]
 }
 
 // NewHTTPConnector creates a HTTP connector instance.
@@ -34,7 +31,8 @@ func NewHTTPConnector(opts ...Option) *HTTPConnector {
 	}
 
 	return &HTTPConnector{
-		httpClient: defaultOptions.client,
+		httpClient:        defaultOptions.client,
+		fileWatchInterval: defaultOptions.fileWatchInterval,
 	}
 }
 
@@ -65,36 +63,8 @@ func (c *HTTPConnector) ParseConfiguration(
 
 	c.capabilities = schema.NewRawCapabilitiesResponseUnsafe(rawCapabilities)
 
-	config, err := configuration.ReadConfigurationFile(configurationDir)
-	if err != nil {
-		return nil, err
-	}
-
 	logger := connector.GetLogger(ctx)
 
-	schemas, err := configuration.ReadSchemaOutputFile(configurationDir, config.Output, logger)
-	if err != nil {
-		return nil, err
-	}
-
-	var errs map[string][]string
-
-	if schemas == nil {
-		logger.Debug(
-			fmt.Sprintf(
-				"output file at %s does not exist. Parsing files...",
-				filepath.Join(configurationDir, config.Output),
-			),
-		)
-
-		schemas, errs = configuration.BuildSchemaFromConfig(config, configurationDir, logger)
-		if len(errs) > 0 {
-			printSchemaValidationError(logger, errs)
-
-			return nil, errBuildSchemaFailed
-		}
-	}
-
 	c.httpClient.Transport = exhttp.NewTelemetryTransport(
 		c.httpClient.Transport,
 		exhttp.TelemetryConfig{
@@ -103,18 +73,15 @@ func (c *HTTPConnector) ParseConfiguration(
 		},
 	)
 
-	c.config = config
-
-	c.upstreams, err = internal.NewUpstreamManager(c.httpClient, config)
+	snapshot, err := c.loadSnapshot(ctx, configurationDir, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := c.ApplyNDCHttpSchemas(ctx, schemas, logger); err != nil {
-		return nil, fmt.Errorf("failed to validate NDC HTTP schema: %w", err)
-	}
+	c.configurationDir = configurationDir
+	c.snapshot.Store(snapshot)
 
-	return config, nil
+	return snapshot.config, nil
 }
 
 // TryInitState initializes the connector's in-memory state.
@@ -129,8 +96,12 @@ func (c *HTTPConnector) TryInitState(
 	configuration *configuration.Configuration,
 	metrics *connector.TelemetryState,
 ) (*State, error) {
+	watchCtx, stopWatcher := context.WithCancel(ctx)
+	c.watch(watchCtx, metrics.Tracer)
+
 	return &State{
-		Tracer: metrics.Tracer,
+		Tracer:      metrics.Tracer,
+		stopWatcher: stopWatcher,
 	}, nil
 }
 
@@ -157,5 +128,9 @@ func (c *HTTPConnector) GetCapabilities(
 
 // Close handles the graceful shutdown that cleans up the connector's state.
 func (c *HTTPConnector) Close(state *State) error {
+	if state != nil && state.stopWatcher != nil {
+		state.stopWatcher()
+	}
+
 	return nil
 }
@@ -2,16 +2,22 @@ package connector
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto/md5" //nolint:gosec
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -88,8 +94,9 @@ func TestHTTPConnectorAuthentication(t *testing.T) {
 		assert.NilError(t, err)
 		assertHTTPResponse(t, res, http.StatusOK, schema.ExplainResponse{
 			Details: schema.ExplainResponseDetails{
-				"url":     state.Server.URL + "/pet",
-				"headers": `{"Accept":["application/json"],"Api_key":["ran*******(14)"],"Content-Type":["application/json"]}`,
+				"url":      state.Server.URL + "/pet",
+				"security": "api_key",
+				"headers":  `{"Accept":["application/json"],"Api_key":["ran*******(14)"],"Content-Type":["application/json"]}`,
 			},
 		})
 	})
@@ -104,8 +111,9 @@ func TestHTTPConnectorAuthentication(t *testing.T) {
 		assert.NilError(t, err)
 		assertHTTPResponse(t, res, http.StatusOK, schema.ExplainResponse{
 			Details: schema.ExplainResponseDetails{
-				"url":     state.Server.URL + "/pet",
-				"headers": `{"Accept":["application/json"],"Api_key":["una*******(12)"],"Content-Type":["application/json"],"Foo":["bar"]}`,
+				"url":      state.Server.URL + "/pet",
+				"security": "api_key",
+				"headers":  `{"Accept":["application/json"],"Api_key":["una*******(12)"],"Content-Type":["application/json"],"Foo":["bar"]}`,
 			},
 		})
 	})
@@ -225,9 +233,10 @@ func TestHTTPConnectorAuthentication(t *testing.T) {
 		assert.NilError(t, err)
 		assertHTTPResponse(t, res, http.StatusOK, schema.ExplainResponse{
 			Details: schema.ExplainResponseDetails{
-				"url":     state.Server.URL + "/pet",
-				"headers": `{"Accept":["application/json"],"Api_key":["ran*******(14)"],"Content-Type":["application/json"]}`,
-				"body":    "{\"name\":\"pet\"}",
+				"url":      state.Server.URL + "/pet",
+				"security": "api_key",
+				"headers":  `{"Accept":["application/json"],"Api_key":["ran*******(14)"],"Content-Type":["application/json"]}`,
+				"body":     "{\"name\":\"pet\"}",
 			},
 		})
 	})
@@ -241,9 +250,10 @@ func TestHTTPConnectorAuthentication(t *testing.T) {
 		assert.NilError(t, err)
 		assertHTTPResponse(t, res, http.StatusOK, schema.ExplainResponse{
 			Details: schema.ExplainResponseDetails{
-				"url":     state.Server.URL + "/pet",
-				"headers": `{"Accept":["application/json"],"Api_key":["una*******(12)"],"Content-Type":["application/json"],"Foo":["bar"]}`,
-				"body":    "{\"name\":\"pet\"}",
+				"url":      state.Server.URL + "/pet",
+				"security": "api_key",
+				"headers":  `{"Accept":["application/json"],"Api_key":["una*******(12)"],"Content-Type":["application/json"],"Foo":["bar"]}`,
+				"body":     "{\"name\":\"pet\"}",
 			},
 		})
 	})
@@ -321,8 +331,9 @@ func TestHTTPConnectorAuthentication(t *testing.T) {
 		assert.NilError(t, err)
 		assertHTTPResponse(t, res, http.StatusOK, schema.ExplainResponse{
 			Details: schema.ExplainResponseDetails{
-				"url":     state.Server.URL + "/pet/findByStatus?status=available",
-				"headers": `{"Accept":["application/json"],"Authorization":["Bearer ran*******(19)"],"Content-Type":["application/json"],"X-Custom-Header":["This is a test"]}`,
+				"url":      state.Server.URL + "/pet/findByStatus?status=available",
+				"security": "bearer",
+				"headers":  `{"Accept":["application/json"],"Authorization":["Bea*******(21)"],"Content-Type":["application/json"],"X-Custom-Header":["This is a test"]}`,
 			},
 		})
 	})
@@ -352,6 +363,61 @@ func TestHTTPConnectorAuthentication(t *testing.T) {
 		}
 	})
 
+	t.Run("auth_combined", func(t *testing.T) {
+		createBody := func(collection string) []byte {
+			return []byte(fmt.Sprintf(`{
+				"collection": "%s",
+				"query": {
+					"fields": {
+						"__value": {
+							"type": "column",
+							"column": "__value"
+						}
+					}
+				},
+				"arguments": {},
+				"collection_relationships": {}
+			}`, collection))
+		}
+
+		res, err := http.Post(
+			fmt.Sprintf("%s/query/explain", testServer.URL),
+			"application/json",
+			bytes.NewBuffer(createBody("findPetsCombinedAuth")),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.ExplainResponse{
+			Details: schema.ExplainResponseDetails{
+				"url":      state.Server.URL + "/pet/combined",
+				"security": "api_key,bearer",
+				"headers":  `{"Accept":["application/json"],"Api_key":["ran*******(14)"],"Authorization":["Bea*******(21)"],"Content-Type":["application/json"]}`,
+			},
+		})
+
+		for _, collection := range []string{"findPetsCombinedAuth", "findPetsAlternativeAuth"} {
+			res, err := http.Post(
+				fmt.Sprintf("%s/query", testServer.URL),
+				"application/json",
+				bytes.NewBuffer(createBody(collection)),
+			)
+			assert.NilError(t, err)
+			assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+				{
+					Rows: []map[string]any{
+						{
+							"__value": map[string]any{
+								"headers": map[string]any{
+									"Content-Type": string("application/json"),
+								},
+								"response": []any{map[string]any{}},
+							},
+						},
+					},
+				},
+			})
+		}
+	})
+
 	t.Run("auth_cookie", func(t *testing.T) {
 		requestBody := []byte(`{
 		"collection": "findPetsCookie",
@@ -804,11 +870,11 @@ func TestHTTPConnector_distribution(t *testing.T) {
 		testServer := connServer.BuildTestServer()
 		defer testServer.Close()
 
-		assert.Equal(t, uint(30), rc.metadata[0].Runtime.Timeout)
-		assert.Equal(t, uint(2), rc.metadata[0].Runtime.Retry.Times)
-		assert.Equal(t, uint(1000), rc.metadata[0].Runtime.Retry.Delay)
-		assert.Equal(t, uint(1000), rc.metadata[0].Runtime.Retry.Delay)
-		assert.DeepEqual(t, []int{429, 500}, rc.metadata[0].Runtime.Retry.HTTPStatus)
+		assert.Equal(t, uint(30), rc.snapshot.Load().metadata[0].Runtime.Timeout)
+		assert.Equal(t, uint(2), rc.snapshot.Load().metadata[0].Runtime.Retry.Times)
+		assert.Equal(t, uint(1000), rc.snapshot.Load().metadata[0].Runtime.Retry.Delay)
+		assert.Equal(t, uint(1000), rc.snapshot.Load().metadata[0].Runtime.Retry.Delay)
+		assert.DeepEqual(t, []int{429, 500}, rc.snapshot.Load().metadata[0].Runtime.Retry.HTTPStatus)
 
 		reqBody := []byte(`{
 			"collection": "findPetsDistributed",
@@ -1303,6 +1369,18 @@ func createMockServer(t *testing.T, apiKey string, bearerToken string) *mockServ
 		}
 	})
 
+	mux.HandleFunc("/pet/combined", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, apiKey, r.Header.Get("api_key"))
+		assert.Equal(t, "Bearer "+bearerToken, r.Header.Get("Authorization"))
+		writeResponse(w, "[{}]")
+	})
+
+	mux.HandleFunc("/pet/alternative", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "", r.Header.Get("api_key"))
+		assert.Equal(t, "Bearer "+bearerToken, r.Header.Get("Authorization"))
+		writeResponse(w, "[{}]")
+	})
+
 	mux.HandleFunc("/pet/cookie", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -1798,6 +1876,80 @@ func TestConnectorTLS(t *testing.T) {
 	assert.Equal(t, 1, mockServer1.Count())
 }
 
+func TestConnectorTLSPKCS12(t *testing.T) {
+	mockServer := &mockTLSServer{}
+	server := mockServer.createMockTLSServer(t, "testdata/tls/certs", false)
+	defer server.Close()
+
+	mockServer1 := &mockTLSServer{}
+	server1 := mockServer1.createMockTLSServer(t, "testdata/tls/certs_s1", false)
+	defer server1.Close()
+
+	t.Setenv("PET_STORE_URL", server.URL)
+	t.Setenv("PET_STORE_CA_FILE", filepath.Join("testdata/tls/certs", "ca.crt"))
+	t.Setenv("PET_STORE_PKCS12_FILE", filepath.Join("testdata/tls/certs", "client.p12"))
+	t.Setenv("PET_STORE_PKCS12_PASSWORD", "p12secret")
+
+	t.Setenv("PET_STORE_S1_URL", server1.URL)
+	caPem, err := os.ReadFile(filepath.Join("testdata/tls/certs_s1", "ca.crt"))
+	assert.NilError(t, err)
+	t.Setenv("PET_STORE_S1_CA_PEM", base64.StdEncoding.EncodeToString(caPem))
+
+	p12Data, err := os.ReadFile(filepath.Join("testdata/tls/certs_s1", "client-legacy.p12"))
+	assert.NilError(t, err)
+	t.Setenv("PET_STORE_S1_PKCS12_PEM", base64.StdEncoding.EncodeToString(p12Data))
+	t.Setenv("PET_STORE_S1_PKCS12_PASSWORD", "p12secret")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/tls-pkcs12",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	for _, serverID := range []string{"0", "1"} {
+		findPetsBody := []byte(fmt.Sprintf(`{
+			"collection": "findPets",
+			"query": {
+				"fields": {
+					"__value": {
+						"type": "column",
+						"column": "__value"
+					}
+				}
+			},
+			"arguments": {
+				"httpOptions": {
+					"type": "literal",
+					"value": {
+						"servers": [%q]
+					}
+				}
+			},
+			"collection_relationships": {}
+		}`, serverID))
+
+		res, err := http.Post(
+			fmt.Sprintf("%s/query", testServer.URL),
+			"application/json",
+			bytes.NewBuffer(findPetsBody),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": []any{},
+					},
+				},
+			},
+		})
+	}
+
+	assert.Equal(t, 1, mockServer.Count())
+	assert.Equal(t, 1, mockServer1.Count())
+}
+
 func TestConnectorTLSInsecure(t *testing.T) {
 	mockServer := &mockTLSServer{}
 	server := mockServer.createMockTLSServer(t, "testdata/tls/certs", true)
@@ -2020,3 +2172,2258 @@ func TestConnectorArgumentPresets(t *testing.T) {
 		})
 	})
 }
+
+type mockTokenEndpointServer struct {
+	lock       sync.Mutex
+	loginCount int
+	token      string
+}
+
+func (mts *mockTokenEndpointServer) login(prefix string) string {
+	mts.lock.Lock()
+	defer mts.lock.Unlock()
+
+	mts.loginCount++
+	mts.token = fmt.Sprintf("%s-%d", prefix, mts.loginCount)
+
+	return mts.token
+}
+
+func (mts *mockTokenEndpointServer) invalidate() {
+	mts.lock.Lock()
+	defer mts.lock.Unlock()
+
+	mts.token = ""
+}
+
+func (mts *mockTokenEndpointServer) isValid(token string) bool {
+	mts.lock.Lock()
+	defer mts.lock.Unlock()
+
+	return mts.token != "" && mts.token == token
+}
+
+func (mts *mockTokenEndpointServer) LoginCount() int {
+	mts.lock.Lock()
+	defer mts.lock.Unlock()
+
+	return mts.loginCount
+}
+
+func (mts *mockTokenEndpointServer) createServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	writeResponse := func(w http.ResponseWriter, body string) {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}
+
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		assert.Equal(t, "ndc-http", r.Header.Get("X-Client-Id"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]string
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		if body["username"] != "user" || body["password"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		token := mts.login("token")
+		writeResponse(w, fmt.Sprintf(`{"data": {"token": "%s", "expires_in": 3600}}`, token))
+	})
+
+	mux.HandleFunc("/login/form", func(w http.ResponseWriter, r *http.Request) {
+		assert.NilError(t, r.ParseForm())
+
+		if r.PostForm.Get("username") != "user" || r.PostForm.Get("password") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		token := mts.login("qtoken")
+		writeResponse(w, fmt.Sprintf(`{"token": "%s"}`, token))
+	})
+
+	mux.HandleFunc("/pet", func(w http.ResponseWriter, r *http.Request) {
+		if !mts.isValid(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeResponse(w, `[{"id": 1, "name": "Dog"}]`)
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			assert.NilError(t, err)
+			writeResponse(w, string(body))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/pet/query", func(w http.ResponseWriter, r *http.Request) {
+		if !mts.isValid(r.URL.Query().Get("access_token")) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		writeResponse(w, `[{"id": 2, "name": "Cat"}]`)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestConnectorTokenEndpoint(t *testing.T) {
+	mockServer := &mockTokenEndpointServer{}
+	server := mockServer.createServer(t)
+	defer server.Close()
+
+	t.Setenv("PET_STORE_URL", server.URL)
+	t.Setenv("PET_STORE_USERNAME", "user")
+	t.Setenv("PET_STORE_PASSWORD", "secret")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/token-endpoint",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	createQueryBody := func(collection string) []byte {
+		return []byte(fmt.Sprintf(`{
+			"collection": "%s",
+			"query": {
+				"fields": {
+					"__value": {
+						"type": "column",
+						"column": "__value"
+					}
+				}
+			},
+			"arguments": {},
+			"collection_relationships": {}
+		}`, collection))
+	}
+
+	sendQuery := func(t *testing.T, collection string, expected []any) {
+		t.Helper()
+
+		res, err := http.Post(
+			fmt.Sprintf("%s/query", testServer.URL),
+			"application/json",
+			bytes.NewBuffer(createQueryBody(collection)),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{"__value": expected},
+				},
+			},
+		})
+	}
+
+	findPetsResult := []any{
+		map[string]any{"id": float64(1), "name": "Dog"},
+	}
+
+	t.Run("explain", func(t *testing.T) {
+		res, err := http.Post(
+			fmt.Sprintf("%s/query/explain", testServer.URL),
+			"application/json",
+			bytes.NewBuffer(createQueryBody("findPets")),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.ExplainResponse{
+			Details: schema.ExplainResponseDetails{
+				"url":      server.URL + "/pet",
+				"security": "login",
+				"headers":  `{"Accept":["application/json"],"Authorization":["B*********"],"Content-Type":["application/json"],"X-Api-Secret":["sta*******(19)"]}`,
+			},
+		})
+		assert.Equal(t, 0, mockServer.LoginCount())
+	})
+
+	t.Run("explain_fallback", func(t *testing.T) {
+		res, err := http.Post(
+			fmt.Sprintf("%s/query/explain", testServer.URL),
+			"application/json",
+			bytes.NewBuffer(createQueryBody("findPetsOptionalAuth")),
+		)
+		assert.NilError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		var explainResp schema.ExplainResponse
+		assert.NilError(t, json.NewDecoder(res.Body).Decode(&explainResp))
+		assert.Assert(t, strings.HasPrefix(explainResp.Details["url"], server.URL+"/pet"))
+		assert.Assert(t, explainResp.Details["security"] != "")
+		assert.Equal(t, 0, mockServer.LoginCount())
+	})
+
+	t.Run("login_once", func(t *testing.T) {
+		sendQuery(t, "findPets", findPetsResult)
+		assert.Equal(t, 1, mockServer.LoginCount())
+
+		var wg sync.WaitGroup
+
+		for range 10 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				sendQuery(t, "findPets", findPetsResult)
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, 1, mockServer.LoginCount())
+	})
+
+	t.Run("relogin_on_401", func(t *testing.T) {
+		mockServer.invalidate()
+
+		var wg sync.WaitGroup
+
+		for range 10 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				sendQuery(t, "findPets", findPetsResult)
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, 2, mockServer.LoginCount())
+	})
+
+	t.Run("replay_request_body", func(t *testing.T) {
+		mockServer.invalidate()
+
+		res, err := http.Post(
+			fmt.Sprintf("%s/mutation", testServer.URL),
+			"application/json",
+			bytes.NewBufferString(`{
+				"operations": [
+					{
+						"type": "procedure",
+						"name": "addPet",
+						"arguments": {
+							"body": {
+								"id": 3,
+								"name": "Bird"
+							}
+						}
+					}
+				],
+				"collection_relationships": {}
+			}`),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.MutationResponse{
+			OperationResults: []schema.MutationOperationResults{
+				schema.NewProcedureResult(map[string]any{
+					"id":   float64(3),
+					"name": "Bird",
+				}).Encode(),
+			},
+		})
+		assert.Equal(t, 3, mockServer.LoginCount())
+	})
+
+	t.Run("query_token", func(t *testing.T) {
+		sendQuery(t, "findPetsQueryToken", []any{
+			map[string]any{"id": float64(2), "name": "Cat"},
+		})
+		assert.Equal(t, 4, mockServer.LoginCount())
+	})
+}
+
+func TestConnectorCookie(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pet", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		cookies := map[string]string{}
+		for _, cookie := range r.Cookies() {
+			cookies[cookie.Name] = cookie.Value
+		}
+
+		assert.DeepEqual(t, map[string]string{
+			"session_id": "secret-session",
+			"theme":      "dark",
+			"tags":       "dog,cat",
+			"status":     "available",
+			"category":   "pet%20food",
+		}, cookies)
+
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": 1, "name": "Dog"}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("PET_STORE_URL", server.URL)
+	t.Setenv("PET_STORE_SESSION_ID", "secret-session")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/cookie",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	t.Run("explain", func(t *testing.T) {
+		res, err := http.Post(
+			fmt.Sprintf("%s/query/explain", testServer.URL),
+			"application/json",
+			bytes.NewBufferString(`{
+				"collection": "findPets",
+				"query": {
+					"fields": {
+						"__value": {
+							"type": "column",
+							"column": "__value"
+						}
+					}
+				},
+				"arguments": {
+					"tags": {
+						"type": "literal",
+						"value": ["dog", "cat"]
+					},
+					"headers": {
+						"type": "literal",
+						"value": {
+							"Cookie": "theme=dark"
+						}
+					}
+				},
+				"collection_relationships": {}
+			}`),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.ExplainResponse{
+			Details: schema.ExplainResponseDetails{
+				"url":      server.URL + "/pet",
+				"security": "session",
+				"headers":  `{"Accept":["application/json"],"Content-Type":["application/json"],"Cookie":["theme=****; tags=d******; session_id=sec*******(14)"]}`,
+			},
+		})
+	})
+
+	t.Run("query", func(t *testing.T) {
+		res, err := http.Post(
+			fmt.Sprintf("%s/query", testServer.URL),
+			"application/json",
+			bytes.NewBufferString(`{
+				"collection": "findPets",
+				"query": {
+					"fields": {
+						"__value": {
+							"type": "column",
+							"column": "__value"
+						}
+					}
+				},
+				"arguments": {
+					"tags": {
+						"type": "literal",
+						"value": ["dog", "cat"]
+					},
+					"filter": {
+						"type": "literal",
+						"value": {
+							"status": "available",
+							"category": "pet food"
+						}
+					},
+					"headers": {
+						"type": "literal",
+						"value": {
+							"Cookie": "theme=dark; tags=ignored; session_id=ignored"
+						}
+					}
+				},
+				"collection_relationships": {}
+			}`),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": []any{
+							map[string]any{"id": float64(1), "name": "Dog"},
+						},
+					},
+				},
+			},
+		})
+	})
+}
+
+func TestConnectorOAuth2Scopes(t *testing.T) {
+	var lock sync.Mutex
+
+	tokenRequests := map[string]int{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NilError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+
+		scope := r.PostForm.Get("scope")
+
+		lock.Lock()
+		tokenRequests[scope]++
+		lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "token:" + scope,
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		})
+	})
+
+	mux.HandleFunc("/pet", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		name := r.Header.Get("Authorization")
+		if r.Method == http.MethodPost {
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "name": name})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode([]map[string]any{{"id": 1, "name": name}})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("PET_STORE_URL", server.URL)
+	t.Setenv("OAUTH2_CLIENT_ID", "test-client")
+	t.Setenv("OAUTH2_CLIENT_SECRET", "test-secret")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/oauth2-scopes",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	sendQuery := func(t *testing.T, collection string, expectedToken string) {
+		t.Helper()
+
+		res, err := http.Post(
+			fmt.Sprintf("%s/query", testServer.URL),
+			"application/json",
+			bytes.NewBufferString(fmt.Sprintf(`{
+				"collection": "%s",
+				"query": {
+					"fields": {
+						"__value": {
+							"type": "column",
+							"column": "__value"
+						}
+					}
+				},
+				"arguments": {},
+				"collection_relationships": {}
+			}`, collection)),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": []any{
+							map[string]any{"id": float64(1), "name": "Bearer " + expectedToken},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	for range 2 {
+		sendQuery(t, "findPets", "token:read:pets")
+		sendQuery(t, "findPetsWithoutScopes", "token:")
+		sendQuery(t, "findPetsAllScopes", "token:read:pets write:pets")
+	}
+
+	res, err := http.Post(
+		fmt.Sprintf("%s/mutation", testServer.URL),
+		"application/json",
+		bytes.NewBufferString(`{
+			"operations": [
+				{
+					"type": "procedure",
+					"name": "addPet",
+					"arguments": {
+						"body": {
+							"id": 1,
+							"name": "Dog"
+						}
+					}
+				}
+			],
+			"collection_relationships": {}
+		}`),
+	)
+	assert.NilError(t, err)
+	assertHTTPResponse(t, res, http.StatusOK, schema.MutationResponse{
+		OperationResults: []schema.MutationOperationResults{
+			schema.NewProcedureResult(map[string]any{
+				"id":   float64(1),
+				"name": "Bearer token:read:pets write:pets",
+			}).Encode(),
+		},
+	})
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// tokens are cached per security scheme and scope set.
+	// Both petstore_auth and petstore_all_scopes request the full scope set once.
+	assert.DeepEqual(t, map[string]int{
+		"read:pets":            1,
+		"":                     1,
+		"read:pets write:pets": 2,
+	}, tokenRequests)
+}
+
+type mockChallengeAuthServer struct {
+	lock           sync.Mutex
+	digestNonce    string
+	digestCount    int
+	ntlmHandshakes int
+	ntlmSessions   map[string]bool
+}
+
+func (mcs *mockChallengeAuthServer) createServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mcs.ntlmSessions = map[string]bool{}
+	mux := http.NewServeMux()
+	writeResponse := func(w http.ResponseWriter, body string) {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}
+
+	md5Hex := func(values ...string) string {
+		sum := md5.Sum([]byte(strings.Join(values, ":")))
+
+		return hex.EncodeToString(sum[:])
+	}
+
+	mux.HandleFunc("/pet", func(w http.ResponseWriter, r *http.Request) {
+		mcs.lock.Lock()
+		defer mcs.lock.Unlock()
+
+		params := map[string]string{}
+		rawParams, isDigest := strings.CutPrefix(r.Header.Get("Authorization"), "Digest ")
+
+		for param := range strings.SplitSeq(rawParams, ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			params[key] = strings.Trim(value, `"`)
+		}
+
+		expected := md5Hex(
+			md5Hex("user", "pets", "secret"),
+			mcs.digestNonce,
+			params["nc"],
+			params["cnonce"],
+			"auth",
+			md5Hex(r.Method, r.URL.RequestURI()),
+		)
+
+		if !isDigest || mcs.digestNonce == "" || params["nonce"] != mcs.digestNonce ||
+			params["response"] != expected {
+			mcs.digestCount++
+			mcs.digestNonce = fmt.Sprintf("nonce-%d", mcs.digestCount)
+			w.Header().Set(
+				"WWW-Authenticate",
+				fmt.Sprintf(`Digest realm="pets", qop="auth", nonce="%s"`, mcs.digestNonce),
+			)
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		writeResponse(w, `[{"id": 1, "name": "Dog"}]`)
+	})
+
+	mux.HandleFunc("/pet/ntlm", func(w http.ResponseWriter, r *http.Request) {
+		mcs.lock.Lock()
+		defer mcs.lock.Unlock()
+
+		if mcs.ntlmSessions[r.RemoteAddr] {
+			writeResponse(w, `[{"id": 2, "name": "Cat"}]`)
+
+			return
+		}
+
+		rawToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "NTLM ")
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "NTLM")
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		msg, err := base64.StdEncoding.DecodeString(rawToken)
+		assert.NilError(t, err)
+		assert.Assert(t, len(msg) >= 32)
+		assert.Equal(t, "NTLMSSP\x00", string(msg[:8]))
+
+		switch msg[8] {
+		case 1:
+			mcs.ntlmHandshakes++
+
+			challenge := make([]byte, 48)
+			copy(challenge, "NTLMSSP\x00")
+			challenge[8] = 2
+			copy(challenge[24:], "12345678")
+			challenge[44] = 48
+
+			w.Header().Set("WWW-Authenticate", "NTLM "+base64.StdEncoding.EncodeToString(challenge))
+			w.WriteHeader(http.StatusUnauthorized)
+		case 3:
+			// the user name is encoded in UTF-16LE at the offset of the user field.
+			userLength := int(msg[36])
+			userOffset := int(msg[40])
+			assert.Equal(t, "u\x00s\x00e\x00r\x00", string(msg[userOffset:userOffset+userLength]))
+
+			mcs.ntlmSessions[r.RemoteAddr] = true
+			writeResponse(w, `[{"id": 2, "name": "Cat"}]`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestConnectorDigestNTLM(t *testing.T) {
+	mockServer := &mockChallengeAuthServer{}
+	server := mockServer.createServer(t)
+	defer server.Close()
+
+	t.Setenv("PET_STORE_URL", server.URL)
+	t.Setenv("PET_STORE_USERNAME", "user")
+	t.Setenv("PET_STORE_PASSWORD", "secret")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/digest",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	createQueryBody := func(collection string) []byte {
+		return []byte(fmt.Sprintf(`{
+			"collection": "%s",
+			"query": {
+				"fields": {
+					"__value": {
+						"type": "column",
+						"column": "__value"
+					}
+				}
+			},
+			"arguments": {},
+			"collection_relationships": {}
+		}`, collection))
+	}
+
+	sendQuery := func(t *testing.T, collection string, expected []any) {
+		t.Helper()
+
+		res, err := http.Post(
+			fmt.Sprintf("%s/query", testServer.URL),
+			"application/json",
+			bytes.NewBuffer(createQueryBody(collection)),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{"__value": expected},
+				},
+			},
+		})
+	}
+
+	t.Run("explain", func(t *testing.T) {
+		res, err := http.Post(
+			fmt.Sprintf("%s/query/explain", testServer.URL),
+			"application/json",
+			bytes.NewBuffer(createQueryBody("findPetsNTLM")),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.ExplainResponse{
+			Details: schema.ExplainResponseDetails{
+				"url":      server.URL + "/pet/ntlm",
+				"security": "ntlm",
+				"headers":  `{"Accept":["application/json"],"Authorization":["N*******"],"Content-Type":["application/json"]}`,
+			},
+		})
+	})
+
+	t.Run("digest", func(t *testing.T) {
+		for range 3 {
+			sendQuery(t, "findPets", []any{
+				map[string]any{"id": float64(1), "name": "Dog"},
+			})
+		}
+
+		// the nonce is reused by subsequent requests.
+		assert.Equal(t, 1, mockServer.digestCount)
+	})
+
+	t.Run("ntlm", func(t *testing.T) {
+		for range 3 {
+			sendQuery(t, "findPetsNTLM", []any{
+				map[string]any{"id": float64(2), "name": "Cat"},
+			})
+		}
+
+		// the authenticated connection is reused by subsequent requests.
+		assert.Equal(t, 1, mockServer.ntlmHandshakes)
+	})
+}
+
+func TestConnectorSecretFileReload(t *testing.T) {
+	var apiKey atomic.Value
+
+	apiKey.Store("secret-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pet", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("api_key") != apiKey.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": 1, "name": "Dog"}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "api_key")
+	assert.NilError(t, os.WriteFile(secretFile, []byte("secret-1\n"), 0o600))
+
+	t.Setenv("PET_STORE_URL", server.URL)
+	t.Setenv("PET_STORE_API_KEY_FILE", secretFile)
+
+	connServer, err := connector.NewServer(
+		NewHTTPConnector(WithFileWatchInterval(50*time.Millisecond)),
+		&connector.ServerOptions{
+			Configuration: "testdata/secret-file",
+		},
+		connector.WithoutRecovery(),
+	)
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	sendQuery := func() int {
+		res, err := http.Post(
+			fmt.Sprintf("%s/query", testServer.URL),
+			"application/json",
+			bytes.NewBufferString(`{
+				"collection": "findPets",
+				"query": {
+					"fields": {
+						"__value": {
+							"type": "column",
+							"column": "__value"
+						}
+					}
+				},
+				"arguments": {},
+				"collection_relationships": {}
+			}`),
+		)
+		assert.NilError(t, err)
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+
+		return res.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, sendQuery())
+
+	// rotate the secret atomically like Kubernetes secret volumes.
+	apiKey.Store("secret-2")
+
+	tempFile := secretFile + ".tmp"
+	assert.NilError(t, os.WriteFile(tempFile, []byte("secret-2\n"), 0o600))
+	assert.NilError(t, os.Rename(tempFile, secretFile))
+
+	status := sendQuery()
+	for i := 0; i < 50 && status != http.StatusOK; i++ {
+		time.Sleep(50 * time.Millisecond)
+
+		status = sendQuery()
+	}
+
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestConnectorConfigurationReload(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pet", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": 1, "name": "Dog"}]`))
+	})
+	mux.HandleFunc("/pet/v2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": 2, "name": "Cat"}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("PET_STORE_URL", server.URL)
+	t.Setenv("PET_STORE_API_KEY_FILE", filepath.Join(t.TempDir(), "api_key"))
+
+	configDir := t.TempDir()
+	rawSchema, err := os.ReadFile("testdata/secret-file/schema.yaml")
+	assert.NilError(t, err)
+	assert.NilError(t, os.WriteFile(filepath.Join(configDir, "schema.yaml"), rawSchema, 0o600))
+	assert.NilError(t, os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(`strict: true
+runtime:
+  watchConfig:
+    value: true
+files:
+  - file: schema.yaml
+    spec: ndc
+`), 0o600))
+
+	rc := NewHTTPConnector(WithFileWatchInterval(50 * time.Millisecond))
+	connServer, err := connector.NewServer(
+		rc,
+		&connector.ServerOptions{
+			Configuration: configDir,
+		},
+		connector.WithoutRecovery(),
+	)
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	sendQuery := func(collection string) *http.Response {
+		res, err := http.Post(
+			fmt.Sprintf("%s/query", testServer.URL),
+			"application/json",
+			bytes.NewBufferString(fmt.Sprintf(`{
+				"collection": "%s",
+				"query": {
+					"fields": {
+						"__value": {
+							"type": "column",
+							"column": "__value"
+						}
+					}
+				},
+				"arguments": {},
+				"collection_relationships": {}
+			}`, collection)),
+		)
+		assert.NilError(t, err)
+
+		return res
+	}
+
+	waitForReload := func(previous *connectorSnapshot) {
+		t.Helper()
+
+		for i := 0; i < 50 && rc.snapshot.Load() == previous; i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		assert.Assert(t, rc.snapshot.Load() != previous, "the configuration isn't reloaded")
+	}
+
+	// the secret file doesn't exist, requests are sent without the API key.
+	assertHTTPResponse(t, sendQuery("findPets"), http.StatusOK, schema.QueryResponse{
+		{
+			Rows: []map[string]any{
+				{"__value": []any{map[string]any{"id": float64(1), "name": "Dog"}}},
+			},
+		},
+	})
+
+	snapshot := rc.snapshot.Load()
+	newSchema := strings.Replace(string(rawSchema), "findPets:", "findPetsV2:", 1)
+	newSchema = strings.Replace(newSchema, `url: "/pet"`, `url: "/pet/v2"`, 1)
+	assert.NilError(
+		t,
+		os.WriteFile(filepath.Join(configDir, "schema.yaml"), []byte(newSchema), 0o600),
+	)
+	waitForReload(snapshot)
+
+	assertHTTPResponse(t, sendQuery("findPetsV2"), http.StatusOK, schema.QueryResponse{
+		{
+			Rows: []map[string]any{
+				{"__value": []any{map[string]any{"id": float64(2), "name": "Cat"}}},
+			},
+		},
+	})
+
+	assertHTTPResponse(t, sendQuery("findPets"), http.StatusInternalServerError, schema.ErrorResponse{
+		Message: "unsupported query: findPets",
+		Details: map[string]any{},
+	})
+
+	// the invalid configuration is rejected and the previous configuration stays active.
+	snapshot = rc.snapshot.Load()
+	assert.NilError(
+		t,
+		os.WriteFile(filepath.Join(configDir, "schema.yaml"), []byte("functions: ["), 0o600),
+	)
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, snapshot, rc.snapshot.Load())
+
+	assertHTTPResponse(t, sendQuery("findPetsV2"), http.StatusOK, schema.QueryResponse{
+		{
+			Rows: []map[string]any{
+				{"__value": []any{map[string]any{"id": float64(2), "name": "Cat"}}},
+			},
+		},
+	})
+}
+
+func TestConnectorProxy(t *testing.T) {
+	var upstreamCount, proxyCount atomic.Int32
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamCount.Add(1)
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": 1, "name": "Dog"}]`))
+	}))
+	defer upstream.Close()
+
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("proxy-user:proxy-pass"))
+		if r.Header.Get("Proxy-Authorization") != expectedAuth {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+
+			return
+		}
+
+		proxyCount.Add(1)
+
+		outReq, err := http.NewRequestWithContext(r.Context(), r.Method, r.URL.String(), r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		resp, err := http.DefaultTransport.RoundTrip(outReq)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		defer resp.Body.Close()
+
+		w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, resp.Body)
+	}))
+	defer proxyServer.Close()
+
+	t.Setenv("PET_STORE_URL", upstream.URL)
+	t.Setenv("PET_STORE_PROXY_URL", proxyServer.URL)
+	t.Setenv("PET_STORE_PROXY_USERNAME", "proxy-user")
+	t.Setenv("PET_STORE_PROXY_PASSWORD", "proxy-pass")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/proxy",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	sendQuery := func(servers []string) {
+		t.Helper()
+
+		reqBody := map[string]any{
+			"collection": "findPets",
+			"query": map[string]any{
+				"fields": map[string]any{
+					"__value": map[string]any{
+						"type":   "column",
+						"column": "__value",
+					},
+				},
+			},
+			"arguments": map[string]any{
+				"httpOptions": map[string]any{
+					"type": "literal",
+					"value": map[string]any{
+						"servers": servers,
+					},
+				},
+			},
+			"collection_relationships": map[string]any{},
+		}
+
+		rawBody, err := json.Marshal(reqBody)
+		assert.NilError(t, err)
+
+		res, err := http.Post(
+			fmt.Sprintf("%s/query", testServer.URL),
+			"application/json",
+			bytes.NewBuffer(rawBody),
+		)
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": []any{
+							map[string]any{"id": float64(1), "name": "Dog"},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	// the first server uses the proxy of the upstream settings.
+	sendQuery([]string{"0"})
+	assert.Equal(t, int32(1), proxyCount.Load())
+	assert.Equal(t, int32(1), upstreamCount.Load())
+
+	// the second server disables the proxy.
+	sendQuery([]string{"1"})
+	assert.Equal(t, int32(1), proxyCount.Load())
+	assert.Equal(t, int32(2), upstreamCount.Load())
+}
+
+func TestConnectorTransport(t *testing.T) {
+	var requestHost atomic.Value
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestHost.Store(r.Host)
+		time.Sleep(1500 * time.Millisecond)
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": 1, "name": "Dog"}]`))
+	}))
+	defer upstream.Close()
+
+	upstreamURL, err := url.Parse(upstream.URL)
+	assert.NilError(t, err)
+
+	partnerHost := "pets.partner.test:" + upstreamURL.Port()
+
+	t.Setenv("PET_STORE_URL", upstream.URL)
+	t.Setenv("PET_STORE_PARTNER_URL", "http://"+partnerHost)
+
+	httpConnector := NewHTTPConnector()
+	connServer, err := connector.NewServer(httpConnector, &connector.ServerOptions{
+		Configuration: "testdata/transport",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	transport := httpConnector.snapshot.Load().metadata[0].Settings.Transport
+	assert.Assert(t, transport != nil)
+	assert.Equal(t, 4, *transport.MaxIdleConnsPerHost)
+
+	sendQuery := func(servers []string) *http.Response {
+		t.Helper()
+
+		reqBody := map[string]any{
+			"collection": "findPets",
+			"query": map[string]any{
+				"fields": map[string]any{
+					"__value": map[string]any{
+						"type":   "column",
+						"column": "__value",
+					},
+				},
+			},
+			"arguments": map[string]any{
+				"httpOptions": map[string]any{
+					"type": "literal",
+					"value": map[string]any{
+						"servers": servers,
+					},
+				},
+			},
+			"collection_relationships": map[string]any{},
+		}
+
+		rawBody, err := json.Marshal(reqBody)
+		assert.NilError(t, err)
+
+		res, err := http.Post(
+			fmt.Sprintf("%s/query", testServer.URL),
+			"application/json",
+			bytes.NewBuffer(rawBody),
+		)
+		assert.NilError(t, err)
+
+		return res
+	}
+
+	// the first server inherits the response header timeout of the config file.
+	res := sendQuery([]string{"0"})
+	defer res.Body.Close()
+
+	assert.Assert(t, res.StatusCode != http.StatusOK)
+
+	// the second server increases the response header timeout and resolves the partner host statically.
+	assertHTTPResponse(t, sendQuery([]string{"1"}), http.StatusOK, schema.QueryResponse{
+		{
+			Rows: []map[string]any{
+				{
+					"__value": []any{
+						map[string]any{"id": float64(1), "name": "Dog"},
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, partnerHost, requestHost.Load())
+}
+
+func TestConnectorUnixSocket(t *testing.T) {
+	var socketProto atomic.Value
+
+	socketPath := filepath.Join(t.TempDir(), "pets.sock")
+	listener, err := net.Listen("unix", socketPath)
+	assert.NilError(t, err)
+
+	protocols := &http.Protocols{}
+	protocols.SetUnencryptedHTTP2(true)
+
+	socketServer := &http.Server{
+		Protocols: protocols,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			socketProto.Store(r.Proto + " " + r.URL.Path)
+			w.Header().Add("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"id": 1, "name": "socket"}]`))
+		}),
+	}
+
+	go func() {
+		_ = socketServer.Serve(listener)
+	}()
+
+	defer socketServer.Close()
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": 2, "name": "http"}]`))
+	}))
+	defer upstream.Close()
+
+	t.Setenv("PET_STORE_SOCKET_URL", "unix://"+socketPath+":/v1")
+	t.Setenv("PET_STORE_URL", upstream.URL)
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/unix-socket",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	reqBody := []byte(`{
+		"collection": "findPetsDistributed",
+		"query": {
+			"fields": {
+				"__value": {
+					"type": "column",
+					"column": "__value"
+				}
+			}
+		},
+		"arguments": {},
+		"collection_relationships": {}
+	}`)
+
+	res, err := http.Post(
+		fmt.Sprintf("%s/query", testServer.URL),
+		"application/json",
+		bytes.NewBuffer(reqBody),
+	)
+	assert.NilError(t, err)
+
+	type petResult = internal.DistributedResult[[]map[string]any]
+
+	var body []struct {
+		Rows []struct {
+			Value struct {
+				Errors  []internal.DistributedError `json:"errors"`
+				Results []petResult                 `json:"results"`
+			} `json:"__value"`
+		} `json:"rows"`
+	}
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NilError(t, json.NewDecoder(res.Body).Decode(&body))
+	_ = res.Body.Close()
+
+	results := body[0].Rows[0].Value.Results
+	assert.Equal(t, 0, len(body[0].Rows[0].Value.Errors))
+	slices.SortFunc(results, func(a petResult, b petResult) int {
+		return strings.Compare(a.Server, b.Server)
+	})
+
+	assert.DeepEqual(t, []petResult{
+		{
+			Server: "http",
+			Data:   []map[string]any{{"id": float64(2), "name": "http"}},
+		},
+		{
+			Server: "socket",
+			Data:   []map[string]any{{"id": float64(1), "name": "socket"}},
+		},
+	}, results)
+	assert.Equal(t, "HTTP/2.0 /v1/pet", socketProto.Load())
+}
+
+func TestConnectorResponseLimits(t *testing.T) {
+	pets := make([]map[string]any, 200)
+	for i := range pets {
+		pets[i] = map[string]any{
+			"id":   float64(i),
+			"name": fmt.Sprintf("pet-%05d", i),
+		}
+	}
+
+	rawPets, err := json.Marshal(pets)
+	assert.NilError(t, err)
+
+	var compressedPets bytes.Buffer
+
+	gw := gzip.NewWriter(&compressedPets)
+	_, err = gw.Write([]byte("["))
+	assert.NilError(t, err)
+
+	for range 100_000 {
+		_, err = gw.Write([]byte(`{"id":1,"name":"pet"},`))
+		assert.NilError(t, err)
+	}
+
+	_, err = gw.Write([]byte(`{"id":1,"name":"pet"}]`))
+	assert.NilError(t, err)
+	assert.NilError(t, gw.Close())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pet", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		// flush chunks so the body is streamed without the content length.
+		for chunk := range slices.Chunk(rawPets, 1024) {
+			_, _ = w.Write(chunk)
+			w.(http.Flusher).Flush()
+		}
+	})
+	mux.HandleFunc("/pet/large", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(rawPets)
+	})
+	mux.HandleFunc("/pet/compressed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(compressedPets.Bytes())
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("PET_STORE_URL", server.URL)
+
+	newTestServer := func(t *testing.T, options ...connector.ServeOption) *httptest.Server {
+		t.Helper()
+
+		connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+			Configuration: "testdata/response-limits",
+		}, append(options, connector.WithoutRecovery())...)
+		assert.NilError(t, err)
+
+		testServer := connServer.BuildTestServer()
+		t.Cleanup(testServer.Close)
+
+		return testServer
+	}
+
+	testServer := newTestServer(t)
+
+	sendQueryTo := func(t *testing.T, serverURL string, collection string) *http.Response {
+		t.Helper()
+
+		res, err := http.Post(
+			serverURL+"/query",
+			"application/json",
+			strings.NewReader(fmt.Sprintf(`{
+				"collection": %q,
+				"query": {
+					"fields": {
+						"__value": {
+							"type": "column",
+							"column": "__value"
+						}
+					}
+				},
+				"arguments": {},
+				"collection_relationships": {}
+			}`, collection)),
+		)
+		assert.NilError(t, err)
+
+		return res
+	}
+
+	sendQuery := func(t *testing.T, collection string) *http.Response {
+		t.Helper()
+
+		return sendQueryTo(t, testServer.URL, collection)
+	}
+
+	t.Run("global_limit", func(t *testing.T) {
+		assertHTTPResponse(t, sendQuery(t, "findPets"), http.StatusBadGateway, schema.ErrorResponse{
+			Message: "response body exceeds the limit of 4096 bytes",
+			Details: map[string]any{
+				"limit": float64(4096),
+			},
+		})
+	})
+
+	t.Run("operation_limit", func(t *testing.T) {
+		var expected []any
+
+		assert.NilError(t, json.Unmarshal(rawPets, &expected))
+		assertHTTPResponse(t, sendQuery(t, "findPetsLarge"), http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": expected,
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("decompressed_limit", func(t *testing.T) {
+		assertHTTPResponse(t, sendQuery(t, "findPetsCompressed"), http.StatusBadGateway, schema.ErrorResponse{
+			Message: "decompressed response body exceeds the limit of 65536 bytes",
+			Details: map[string]any{
+				"limit": float64(65536),
+			},
+		})
+	})
+
+	t.Run("default_decompressed_limit", func(t *testing.T) {
+		t.Setenv("PET_STORE_MAX_DECOMPRESSED_BYTES", "0")
+
+		serverURL := newTestServer(t).URL
+
+		assertHTTPResponse(t, sendQueryTo(t, serverURL, "findPetsCompressed"), http.StatusBadGateway, schema.ErrorResponse{
+			Message: "decompressed response body exceeds the limit of 4096 bytes",
+			Details: map[string]any{
+				"limit": float64(4096),
+			},
+		})
+	})
+
+	t.Run("debug_log", func(t *testing.T) {
+		logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		serverURL := newTestServer(t, connector.WithLogger(logger)).URL
+
+		assertHTTPResponse(t, sendQueryTo(t, serverURL, "findPets"), http.StatusBadGateway, schema.ErrorResponse{
+			Message: "response body exceeds the limit of 4096 bytes",
+			Details: map[string]any{
+				"limit": float64(4096),
+			},
+		})
+
+		assertHTTPResponse(t, sendQueryTo(t, serverURL, "findPetsCompressed"), http.StatusBadGateway, schema.ErrorResponse{
+			Message: "decompressed response body exceeds the limit of 65536 bytes",
+			Details: map[string]any{
+				"limit": float64(65536),
+			},
+		})
+	})
+}
+
+func TestConnectorGraphQL(t *testing.T) {
+	var lastRequest map[string]any
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		rawBody, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.NilError(t, json.Unmarshal(rawBody, &lastRequest))
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case bytes.HasPrefix(rawBody, []byte(`{"query":"query`)):
+			_, _ = w.Write([]byte(`{"data": {"posts": [
+				{"id": "1", "title": "Hello", "author": {"name": "Alice"}},
+				{"id": "2", "title": "World", "author": null}
+			]}}`))
+		case bytes.Contains(rawBody, []byte("Invalid")):
+			_, _ = w.Write([]byte(`{"data": null, "errors": [{"message": "title is invalid", "path": ["createPost"]}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data": {"createPost": {"id": "3", "title": "New", "status": "DRAFT"}}}`))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("BLOG_SERVER_URL", server.URL+"/graphql")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/graphql",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	t.Run("query", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/query", "application/json", strings.NewReader(`{
+			"collection": "posts",
+			"query": {
+				"fields": {
+					"__value": {
+						"type": "column",
+						"column": "__value",
+						"fields": {
+							"type": "object",
+							"fields": {
+								"response": {
+									"type": "column",
+									"column": "response",
+									"fields": {
+										"type": "array",
+										"fields": {
+											"type": "object",
+											"fields": {
+												"postTitle": { "type": "column", "column": "title" },
+												"author": {
+													"type": "column",
+													"column": "author",
+													"fields": {
+														"type": "object",
+														"fields": {
+															"name": { "type": "column", "column": "name" }
+														}
+													}
+												}
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			},
+			"arguments": {
+				"limit": { "type": "literal", "value": 2 },
+				"status": { "type": "literal", "value": null }
+			},
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": map[string]any{
+							"response": []any{
+								map[string]any{
+									"postTitle": "Hello",
+									"author":    map[string]any{"name": "Alice"},
+								},
+								map[string]any{
+									"postTitle": "World",
+									"author":    nil,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		assert.DeepEqual(t, map[string]any{
+			"query": "query($limit: Int) { posts(limit: $limit) { author { name } title } }",
+			"variables": map[string]any{
+				"limit": float64(2),
+			},
+		}, lastRequest)
+	})
+
+	t.Run("mutation", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/mutation", "application/json", strings.NewReader(`{
+			"operations": [
+				{
+					"type": "procedure",
+					"name": "createPost",
+					"arguments": {
+						"input": { "title": "New" }
+					}
+				}
+			],
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.MutationResponse{
+			OperationResults: []schema.MutationOperationResults{
+				schema.NewProcedureResult(map[string]any{
+					"headers": map[string]any{
+						"Content-Type": "application/json",
+					},
+					"response": map[string]any{
+						"id":     "3",
+						"title":  "New",
+						"status": "DRAFT",
+					},
+				}).Encode(),
+			},
+		})
+
+		assert.DeepEqual(t, map[string]any{
+			"query": "mutation($input: CreatePostInput!) { createPost(input: $input) { id status title } }",
+			"variables": map[string]any{
+				"input": map[string]any{"title": "New"},
+			},
+		}, lastRequest)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/mutation", "application/json", strings.NewReader(`{
+			"operations": [
+				{
+					"type": "procedure",
+					"name": "createPost",
+					"arguments": {
+						"input": { "title": "Invalid" }
+					}
+				}
+			],
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusUnprocessableEntity, schema.ErrorResponse{
+			Message: "title is invalid",
+			Details: map[string]any{
+				"errors": []any{
+					map[string]any{
+						"message": "title is invalid",
+						"path":    []any{"createPost"},
+					},
+				},
+			},
+		})
+	})
+}
+
+func TestConnectorJSONRPC(t *testing.T) {
+	var lastRequest any
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rpc", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		rawBody, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.NilError(t, json.Unmarshal(rawBody, &lastRequest))
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case bytes.HasPrefix(rawBody, []byte("[")):
+			// results of the batch response can be in any order.
+			_, _ = w.Write([]byte(`[
+				{"jsonrpc": "2.0", "id": 2, "result": "0x2"},
+				{"jsonrpc": "2.0", "id": 1, "result": "0x1"}
+			]`))
+		case bytes.Contains(rawBody, []byte("Invalid")):
+			_, _ = w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "error": {"code": -32602, "message": "invalid name", "data": "Invalid"}}`))
+		case bytes.Contains(rawBody, []byte("user.create")):
+			_, _ = w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "result": {"id": 10, "name": "Alice"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "result": "0x1"}`))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("RPC_SERVER_URL", server.URL+"/rpc")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/jsonrpc",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	t.Run("query", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/query", "application/json", strings.NewReader(`{
+			"collection": "eth_getBalance",
+			"query": {
+				"fields": {
+					"__value": { "type": "column", "column": "__value" }
+				}
+			},
+			"arguments": {
+				"address": { "type": "literal", "value": "0xabc" },
+				"block": { "type": "literal", "value": null }
+			},
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{"__value": "0x1"},
+				},
+			},
+		})
+
+		assert.DeepEqual(t, map[string]any{
+			"jsonrpc": "2.0",
+			"method":  "eth_getBalance",
+			"params":  []any{"0xabc"},
+			"id":      float64(1),
+		}, lastRequest)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/query", "application/json", strings.NewReader(`{
+			"collection": "eth_getBalance",
+			"query": {
+				"fields": {
+					"__value": { "type": "column", "column": "__value" }
+				}
+			},
+			"arguments": {
+				"address": { "type": "variable", "name": "address" },
+				"block": { "type": "literal", "value": "latest" }
+			},
+			"variables": [
+				{ "address": "0x1111" },
+				{ "address": "0x2222" }
+			],
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{"__value": "0x1"},
+				},
+			},
+			{
+				Rows: []map[string]any{
+					{"__value": "0x2"},
+				},
+			},
+		})
+
+		assert.DeepEqual(t, []any{
+			map[string]any{
+				"jsonrpc": "2.0",
+				"method":  "eth_getBalance",
+				"params":  []any{"0x1111", "latest"},
+				"id":      float64(1),
+			},
+			map[string]any{
+				"jsonrpc": "2.0",
+				"method":  "eth_getBalance",
+				"params":  []any{"0x2222", "latest"},
+				"id":      float64(2),
+			},
+		}, lastRequest)
+	})
+
+	t.Run("mutation", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/mutation", "application/json", strings.NewReader(`{
+			"operations": [
+				{
+					"type": "procedure",
+					"name": "user_create",
+					"arguments": {
+						"name": "Alice"
+					}
+				}
+			],
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.MutationResponse{
+			OperationResults: []schema.MutationOperationResults{
+				schema.NewProcedureResult(map[string]any{
+					"id":   float64(10),
+					"name": "Alice",
+				}).Encode(),
+			},
+		})
+
+		assert.DeepEqual(t, map[string]any{
+			"jsonrpc": "2.0",
+			"method":  "user.create",
+			"params":  map[string]any{"name": "Alice"},
+			"id":      float64(1),
+		}, lastRequest)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/mutation", "application/json", strings.NewReader(`{
+			"operations": [
+				{
+					"type": "procedure",
+					"name": "user_create",
+					"arguments": {
+						"name": "Invalid"
+					}
+				}
+			],
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusUnprocessableEntity, schema.ErrorResponse{
+			Message: "invalid name",
+			Details: map[string]any{
+				"code": float64(-32602),
+				"data": "Invalid",
+			},
+		})
+	})
+}
+
+func TestConnectorSOAP(t *testing.T) {
+	var lastRequest string
+
+	var lastHeaders http.Header
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/weather.asmx", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		rawBody, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+
+		lastRequest = string(rawBody)
+		lastHeaders = r.Header
+
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+
+		switch r.Header.Get("SOAPAction") {
+		case `"http://example.com/weather/GetForecast"`:
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:w="http://example.com/weather">
+  <soap:Body>
+    <w:GetForecastResponse>
+      <w:GetForecastResult generatedAt="2024-01-01T00:00:00Z">
+        <w:city>Hanoi</w:city>
+        <w:days>
+          <w:date>2024-01-02</w:date>
+          <w:summary>Sunny</w:summary>
+          <w:temperature unit="Celsius">30.5</w:temperature>
+        </w:days>
+        <w:days>
+          <w:date>2024-01-03</w:date>
+          <w:temperature unit="Celsius">28</w:temperature>
+        </w:days>
+      </w:GetForecastResult>
+    </w:GetForecastResponse>
+  </soap:Body>
+</soap:Envelope>`))
+		case `"http://example.com/weather/ListCities"`:
+			_, _ = w.Write([]byte(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><ListCitiesResponse xmlns="http://example.com/weather"><ListCitiesResult><string>Hanoi</string><string>Tokyo</string></ListCitiesResult></ListCitiesResponse></soap:Body></soap:Envelope>`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <soap:Fault>
+      <faultcode>soap:Client</faultcode>
+      <faultstring>The city is required</faultstring>
+      <detail><field>city</field></detail>
+    </soap:Fault>
+  </soap:Body>
+</soap:Envelope>`))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("WEATHER_SERVER_URL", server.URL+"/weather.asmx")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/wsdl",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	t.Run("query", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/query", "application/json", strings.NewReader(`{
+			"collection": "getForecast",
+			"query": {
+				"fields": {
+					"__value": { "type": "column", "column": "__value" }
+				}
+			},
+			"arguments": {
+				"body": { "type": "literal", "value": { "city": "Hanoi", "unit": "Celsius" } }
+			},
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": map[string]any{
+							"GetForecastResult": map[string]any{
+								"generatedAt": "2024-01-01T00:00:00Z",
+								"city":        "Hanoi",
+								"days": []any{
+									map[string]any{
+										"date":        "2024-01-02",
+										"summary":     "Sunny",
+										"temperature": map[string]any{"unit": "Celsius", "value": "30.5"},
+									},
+									map[string]any{
+										"date":        "2024-01-03",
+										"temperature": map[string]any{"unit": "Celsius", "value": "28"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		assert.Equal(t, "text/xml; charset=utf-8", lastHeaders.Get("Content-Type"))
+		assert.Equal(t, xml.Header+`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>`+
+			`<GetForecast xmlns="http://example.com/weather"><city>Hanoi</city><unit>Celsius</unit></GetForecast>`+
+			`</soap:Body></soap:Envelope>`, lastRequest)
+	})
+
+	t.Run("empty_element", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/query", "application/json", strings.NewReader(`{
+			"collection": "listCities",
+			"query": {
+				"fields": {
+					"__value": { "type": "column", "column": "__value" }
+				}
+			},
+			"arguments": {},
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": map[string]any{
+							"ListCitiesResult": map[string]any{
+								"string": []any{"Hanoi", "Tokyo"},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		assert.Equal(t, xml.Header+`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>`+
+			`<ListCities xmlns="http://example.com/weather"></ListCities>`+
+			`</soap:Body></soap:Envelope>`, lastRequest)
+	})
+
+	t.Run("fault", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/mutation", "application/json", strings.NewReader(`{
+			"operations": [
+				{
+					"type": "procedure",
+					"name": "submitReport",
+					"arguments": {
+						"body": {
+							"report": { "city": "", "rain": 1.5 }
+						}
+					}
+				}
+			],
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusUnprocessableEntity, schema.ErrorResponse{
+			Message: "The city is required",
+			Details: map[string]any{
+				"code": "soap:Client",
+				"detail": map[string]any{
+					"field": "city",
+				},
+			},
+		})
+
+		assert.Equal(t, `"http://example.com/weather/SubmitReport"`, lastHeaders.Get("SOAPAction"))
+	})
+}
+
+func TestConnectorOData(t *testing.T) {
+	var lastRequest *http.Request
+
+	var lastBody string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/odata/", func(w http.ResponseWriter, r *http.Request) {
+		rawBody, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+
+		lastRequest = r
+		lastBody = string(rawBody)
+
+		w.Header().Set("Content-Type", "application/json;odata.metadata=minimal")
+
+		switch r.URL.Path {
+		case "/odata/Products":
+			_, _ = w.Write([]byte(`{
+				"@odata.context": "$metadata#Products(ID,Name,Price)",
+				"value": [
+					{ "ID": 1, "Name": "Bread", "Price": 2.5 },
+					{ "ID": 2, "Name": "Milk", "Price": 3.5 }
+				]
+			}`))
+		case "/odata/Company":
+			_, _ = w.Write([]byte(`{
+				"@odata.context": "$metadata#Company",
+				"Company": "ACME",
+				"Number": "1",
+				"Name": "ACME Corporation"
+			}`))
+		case "/odata/Orders(c5f51a1e-7c3c-4bb6-9a1a-0d8c1d1f0f00)/Example.ERP.Approve":
+			_, _ = w.Write([]byte(`{
+				"@odata.context": "$metadata#Orders/$entity",
+				"ID": "c5f51a1e-7c3c-4bb6-9a1a-0d8c1d1f0f00",
+				"CreatedAt": "2024-01-01T00:00:00Z",
+				"Status": "Approved",
+				"Lines": []
+			}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{
+				"error": {
+					"code": "InvalidColor",
+					"message": "The color is invalid.",
+					"target": "color"
+				}
+			}`))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("ERP_SERVER_URL", server.URL+"/odata")
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/odata",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	t.Run("entity_set", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/query", "application/json", strings.NewReader(`{
+			"collection": "products",
+			"query": {
+				"fields": {
+					"__value": { "type": "column", "column": "__value" }
+				}
+			},
+			"arguments": {
+				"filter": { "type": "literal", "value": "Price lt 10" },
+				"orderby": { "type": "literal", "value": ["Name desc", "Price"] },
+				"select": { "type": "literal", "value": ["ID", "Name", "Price"] },
+				"top": { "type": "literal", "value": 2 }
+			},
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": []any{
+							map[string]any{"ID": float64(1), "Name": "Bread", "Price": float64(2.5)},
+							map[string]any{"ID": float64(2), "Name": "Milk", "Price": float64(3.5)},
+						},
+					},
+				},
+			},
+		})
+
+		query := lastRequest.URL.Query()
+		assert.Equal(t, http.MethodGet, lastRequest.Method)
+		assert.Equal(t, "Price lt 10", query.Get("$filter"))
+		assert.Equal(t, "Name desc,Price", query.Get("$orderby"))
+		assert.Equal(t, "ID,Name,Price", query.Get("$select"))
+		assert.Equal(t, "2", query.Get("$top"))
+		assert.Assert(t, !query.Has("$skip"))
+	})
+
+	t.Run("singleton", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/query", "application/json", strings.NewReader(`{
+			"collection": "company",
+			"query": {
+				"fields": {
+					"__value": {
+						"type": "column",
+						"column": "__value",
+						"fields": {
+							"type": "object",
+							"fields": {
+								"Company": { "type": "column", "column": "Company" },
+								"Name": { "type": "column", "column": "Name" }
+							}
+						}
+					}
+				}
+			},
+			"arguments": {},
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": map[string]any{
+							"Company": "ACME",
+							"Name":    "ACME Corporation",
+						},
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("bound_action", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/mutation", "application/json", strings.NewReader(`{
+			"operations": [
+				{
+					"type": "procedure",
+					"name": "ordersApprove",
+					"arguments": {
+						"ID": "c5f51a1e-7c3c-4bb6-9a1a-0d8c1d1f0f00",
+						"body": { "comment": "LGTM" }
+					},
+					"fields": {
+						"type": "object",
+						"fields": {
+							"ID": { "type": "column", "column": "ID" },
+							"Status": { "type": "column", "column": "Status" }
+						}
+					}
+				}
+			],
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.MutationResponse{
+			OperationResults: []schema.MutationOperationResults{
+				schema.NewProcedureResult(map[string]any{
+					"ID":     "c5f51a1e-7c3c-4bb6-9a1a-0d8c1d1f0f00",
+					"Status": "Approved",
+				}).Encode(),
+			},
+		})
+
+		assert.Equal(t, http.MethodPost, lastRequest.Method)
+		assert.Equal(t, `{"comment":"LGTM"}`, strings.TrimSpace(lastBody))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/query", "application/json", strings.NewReader(`{
+			"collection": "productsByColor",
+			"query": {
+				"fields": {
+					"__value": { "type": "column", "column": "__value" }
+				}
+			},
+			"arguments": {
+				"color": { "type": "literal", "value": "Purple" },
+				"maxPrice": { "type": "literal", "value": "10" }
+			},
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusUnprocessableEntity, schema.ErrorResponse{
+			Message: "The color is invalid.",
+			Details: map[string]any{
+				"code":   "InvalidColor",
+				"target": "color",
+			},
+		})
+
+		assert.Equal(t, "/odata/ProductsByColor(color=Example.ERP.Color'Purple',maxPrice=10)", lastRequest.URL.Path)
+	})
+}
+
+func TestConnectorProto(t *testing.T) {
+	var lastRequest *http.Request
+
+	var lastBody string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/shelves/", func(w http.ResponseWriter, r *http.Request) {
+		rawBody, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+
+		lastRequest = r
+		lastBody = string(rawBody)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"name": "shelves/1/books/2",
+			"title": "The Go Programming Language",
+			"kind": "HARDCOVER",
+			"sizeBytes": "9007199254740993",
+			"publishTime": "2015-10-26T00:00:00Z"
+		}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("LIBRARY_SERVER_URL", server.URL)
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/proto",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	t.Run("get", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/query", "application/json", strings.NewReader(`{
+			"collection": "getBook",
+			"query": {
+				"fields": {
+					"__value": {
+						"type": "column",
+						"column": "__value",
+						"fields": {
+							"type": "object",
+							"fields": {
+								"kind": { "type": "column", "column": "kind" },
+								"sizeBytes": { "type": "column", "column": "sizeBytes" }
+							}
+						}
+					}
+				}
+			},
+			"arguments": {
+				"name": { "type": "literal", "value": "shelves/1/books/2" },
+				"includeDeleted": { "type": "literal", "value": true }
+			},
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.QueryResponse{
+			{
+				Rows: []map[string]any{
+					{
+						"__value": map[string]any{
+							"kind":      "HARDCOVER",
+							"sizeBytes": "9007199254740993",
+						},
+					},
+				},
+			},
+		})
+
+		assert.Equal(t, http.MethodGet, lastRequest.Method)
+		assert.Equal(t, "/v1/shelves/1/books/2", lastRequest.URL.Path)
+		assert.Equal(t, "true", lastRequest.URL.Query().Get("includeDeleted"))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		res, err := http.Post(testServer.URL+"/mutation", "application/json", strings.NewReader(`{
+			"operations": [
+				{
+					"type": "procedure",
+					"name": "createBook",
+					"arguments": {
+						"parent": "shelves/1",
+						"body": {
+							"title": "The Go Programming Language",
+							"kind": "HARDCOVER",
+							"sizeBytes": "1024"
+						}
+					},
+					"fields": {
+						"type": "object",
+						"fields": {
+							"name": { "type": "column", "column": "name" }
+						}
+					}
+				}
+			],
+			"collection_relationships": {}
+		}`))
+		assert.NilError(t, err)
+		assertHTTPResponse(t, res, http.StatusOK, schema.MutationResponse{
+			OperationResults: []schema.MutationOperationResults{
+				schema.NewProcedureResult(map[string]any{
+					"name": "shelves/1/books/2",
+				}).Encode(),
+			},
+		})
+
+		assert.Equal(t, http.MethodPost, lastRequest.Method)
+		assert.Equal(t, "/v1/shelves/1/books", lastRequest.URL.Path)
+		assert.Equal(t, `{"kind":"HARDCOVER","sizeBytes":"1024","title":"The Go Programming Language"}`, strings.TrimSpace(lastBody))
+	})
+}
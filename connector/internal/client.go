@@ -20,14 +20,22 @@ import (
 	"github.com/hasura/ndc-sdk-go/v2/connector"
 	"github.com/hasura/ndc-sdk-go/v2/schema"
 	"github.com/hasura/ndc-sdk-go/v2/utils"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
 var tracer = connector.NewTracer("HTTPClient")
 
+// responseTruncatedCounter counts upstream responses that exceed size limits.
+var responseTruncatedCounter, _ = otel.Meter("HTTPClient").Int64Counter(
+	"ndc_http.response.truncated_total",
+	metric.WithDescription("Total number of upstream responses that exceed size limits"),
+)
+
 // HTTPClient represents a http client wrapper with advanced methods.
 type HTTPClient struct {
 	manager          *UpstreamManager
@@ -203,6 +211,16 @@ func (client *HTTPClient) sendSingle(
 		span.SetStatus(codes.Error, "error happened when executing the request")
 		span.RecordError(err)
 
+		var egressErr *exhttp.EgressDeniedError
+		if errors.As(err, &egressErr) {
+			return nil, nil, schema.NewConnectorError(http.StatusForbidden, egressErr.Error(), nil)
+		}
+
+		var limitErr *exhttp.ResponseTooLargeError
+		if errors.As(err, &limitErr) {
+			return nil, nil, newResponseTooLargeError(ctx, namespace, limitErr)
+		}
+
 		if !errors.As(err, &httpError) {
 			return nil, nil, schema.InternalServerError(err.Error(), nil)
 		}
@@ -221,6 +239,14 @@ func (client *HTTPClient) sendSingle(
 	}
 
 	if httpError != nil {
+		if faultErr := client.evalSOAPFault(httpError.Body); faultErr != nil {
+			return nil, nil, faultErr
+		}
+
+		if odataErr := client.evalODataError(resp.StatusCode, httpError.Body); odataErr != nil {
+			return nil, nil, odataErr
+		}
+
 		details := make(map[string]any)
 
 		switch contentType {
@@ -249,6 +275,10 @@ func (client *HTTPClient) sendSingle(
 		return nil, nil, schema.NewConnectorError(statusCode, resp.Status, details)
 	}
 
+	// the body may be replaced while evaluating, e.g. it is buffered for debug logs,
+	// so the limited body is kept to check the cause of read errors.
+	limitedBody, _ := resp.Body.(*exhttp.LimitedReadCloser)
+
 	result, evalErr := client.evalHTTPResponse(ctx, span, resp, contentType, logger)
 	if evalErr != nil {
 		// return the null result if the status code is no content.
@@ -256,6 +286,14 @@ func (client *HTTPClient) sendSingle(
 			return nil, resp.Header, nil
 		}
 
+		// decoders wrap or stringify read errors, so the cause is taken from the limited body.
+		if limitedBody != nil && limitedBody.Err() != nil {
+			span.SetStatus(codes.Error, "the http response exceeds the size limit")
+			span.RecordError(limitedBody.Err())
+
+			return nil, nil, newResponseTooLargeError(ctx, namespace, limitedBody.Err())
+		}
+
 		span.SetStatus(codes.Error, "failed to decode the http response")
 		span.RecordError(evalErr)
 
@@ -273,6 +311,27 @@ func (client *HTTPClient) sendSingle(
 	return transformedResult, resp.Header, nil
 }
 
+// newResponseTooLargeError counts the truncated response and creates a bad gateway error.
+func newResponseTooLargeError(
+	ctx context.Context,
+	namespace string,
+	err *exhttp.ResponseTooLargeError,
+) *schema.ConnectorError {
+	limitType := "response"
+	if err.Decompressed {
+		limitType = "decompressed"
+	}
+
+	responseTruncatedCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("db.namespace", namespace),
+		attribute.String("limit_type", limitType),
+	))
+
+	return schema.NewConnectorError(http.StatusBadGateway, err.Error(), map[string]any{
+		"limit": err.Limit,
+	})
+}
+
 func (client *HTTPClient) evalHTTPResponse(
 	ctx context.Context,
 	span trace.Span,
@@ -281,9 +340,10 @@ func (client *HTTPClient) evalHTTPResponse(
 	logger *slog.Logger,
 ) (any, *schema.ConnectorError) {
 	if logger.Enabled(ctx, slog.LevelDebug) {
+		redactor := client.manager.RuntimeSettings.Redactor
 		logAttrs := []any{
 			slog.Int("http_status", resp.StatusCode),
-			slog.Any("response_headers", resp.Header),
+			slog.Any("response_headers", redactor.RedactHeaders(resp.Header)),
 		}
 
 		if resp.Body != nil {
@@ -304,7 +364,7 @@ func (client *HTTPClient) evalHTTPResponse(
 			}
 
 			resp.Body = io.NopCloser(bytes.NewBuffer(respBody))
-			logAttrs = append(logAttrs, slog.String("response_body", string(respBody)))
+			logAttrs = append(logAttrs, slog.String("response_body", redactor.RedactBody(respBody)))
 		}
 
 		logger.Debug("received response from remote server", logAttrs...)
@@ -316,6 +376,23 @@ func (client *HTTPClient) evalHTTPResponse(
 
 	resultType := client.requests.Operation.OriginalResultType
 
+	// SOAP responses are unwrapped regardless of the content type which is text/xml in SOAP 1.1.
+	if rawRequest := client.requests.Operation.Request; rawRequest != nil && rawRequest.SOAP != nil {
+		return client.evalSOAPResponse(resp.Body, resultType)
+	}
+
+	if rawRequest := client.requests.Operation.Request; rawRequest != nil &&
+		restUtils.IsContentTypeJSON(contentType) {
+		switch {
+		case rawRequest.GraphQL != nil:
+			return client.evalGraphQLResponse(resp.Body, rawRequest.GraphQL, resultType)
+		case rawRequest.JSONRPC != nil:
+			return client.evalJSONRPCResponse(resp.Body, resultType)
+		case rawRequest.OData != nil && rawRequest.OData.Value:
+			return client.evalODataValueResponse(resp.Body, resultType)
+		}
+	}
+
 	switch {
 	case restUtils.IsContentTypeText(contentType):
 		respBody, err := io.ReadAll(resp.Body)
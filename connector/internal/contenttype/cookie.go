@@ -0,0 +1,107 @@
+package contenttype
+
+import (
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// SetCookieParameters encodes parameters with the form style and merges them into the Cookie header.
+// Cookie values are percent-encoded so they don't contain delimiters of the Cookie header.
+//
+// | style | explode | primitive | array         | object                   |
+// | ----- | ------- | --------- | ------------- | ------------------------ |
+// | form  | false   | id=5      | id=3,4,5      | id=role,admin,name,Alex  |
+// | form  | true    | id=5      | id=3; id=4    | role=admin; name=Alex    |
+func SetCookieParameters(
+	header *http.Header,
+	name string,
+	queryParams ParameterItems,
+	encObject rest.EncodingObject,
+) {
+	pairs := encodeCookieParameterPairs(name, queryParams, encObject)
+	if len(pairs) == 0 {
+		return
+	}
+
+	header.Set(rest.CookieHeader, MergeCookieHeader(header.Get(rest.CookieHeader), strings.Join(pairs, "; ")))
+}
+
+func encodeCookieParameterPairs(
+	name string,
+	queryParams ParameterItems,
+	encObject rest.EncodingObject,
+) []string {
+	escapedParams := make(ParameterItems, len(queryParams))
+
+	for i, item := range queryParams {
+		values := make([]string, len(item.values))
+		for j, value := range item.values {
+			values[j] = url.PathEscape(value)
+		}
+
+		escapedParams[i] = ParameterItem{
+			keys:   item.keys,
+			values: values,
+		}
+	}
+
+	// cookie parameters are serialized in the same way as query parameters with the form style,
+	// except that exploded values are separated by semicolons.
+	q := url.Values{}
+	EvalQueryParameters(&q, name, escapedParams, encObject)
+
+	var pairs []string
+
+	for _, key := range slices.Sorted(maps.Keys(q)) {
+		for _, value := range q[key] {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+
+	return pairs
+}
+
+// MergeCookieHeader merges cookie pairs of the incoming Cookie header into the existing one.
+// Incoming cookies replace existing cookies with the same name.
+func MergeCookieHeader(existing string, incoming string) string {
+	existingPairs := splitCookiePairs(existing)
+	incomingPairs := splitCookiePairs(incoming)
+
+	if len(existingPairs) == 0 || len(incomingPairs) == 0 {
+		return strings.Join(append(existingPairs, incomingPairs...), "; ")
+	}
+
+	incomingNames := make([]string, len(incomingPairs))
+	for i, pair := range incomingPairs {
+		incomingNames[i], _, _ = strings.Cut(pair, "=")
+	}
+
+	results := make([]string, 0, len(existingPairs)+len(incomingPairs))
+
+	for _, pair := range existingPairs {
+		name, _, _ := strings.Cut(pair, "=")
+		if !slices.Contains(incomingNames, name) {
+			results = append(results, pair)
+		}
+	}
+
+	return strings.Join(append(results, incomingPairs...), "; ")
+}
+
+func splitCookiePairs(rawCookie string) []string {
+	var results []string
+
+	for pair := range strings.SplitSeq(rawCookie, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair != "" {
+			results = append(results, pair)
+		}
+	}
+
+	return results
+}
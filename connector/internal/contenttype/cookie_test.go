@@ -0,0 +1,140 @@
+package contenttype
+
+import (
+	"net/http"
+	"testing"
+
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-sdk-go/v2/utils"
+	"gotest.tools/v3/assert"
+)
+
+func TestEncodeCookieParameters(t *testing.T) {
+	colorInputs := ParameterItems{
+		{
+			keys:   []Key{NewKey("R")},
+			values: []string{"100"},
+		},
+		{
+			keys:   []Key{NewKey("G")},
+			values: []string{"200"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		encoding rest.EncodingObject
+		existing string
+		inputs   ParameterItems
+		expected string
+	}{
+		{
+			name: "empty",
+			inputs: ParameterItems{
+				{
+					keys:   []Key{},
+					values: []string{},
+				},
+			},
+			expected: "",
+		},
+		{
+			name: "single",
+			inputs: ParameterItems{
+				{
+					keys:   []Key{},
+					values: []string{"hello world;"},
+				},
+			},
+			expected: "id=hello%20world%3B",
+		},
+		{
+			name: "array",
+			encoding: rest.EncodingObject{
+				Explode: utils.ToPtr(false),
+			},
+			inputs: ParameterItems{
+				{
+					keys:   []Key{},
+					values: []string{"3", "4", "5"},
+				},
+			},
+			expected: "id=3,4,5",
+		},
+		{
+			name: "array_explode",
+			inputs: ParameterItems{
+				{
+					keys:   []Key{},
+					values: []string{"3", "4", "5"},
+				},
+			},
+			expected: "id=3; id=4; id=5",
+		},
+		{
+			name: "object",
+			encoding: rest.EncodingObject{
+				Explode: utils.ToPtr(false),
+			},
+			inputs:   colorInputs,
+			expected: "id=R,100,G,200",
+		},
+		{
+			name:     "object_explode",
+			inputs:   colorInputs,
+			expected: "G=200; R=100",
+		},
+		{
+			name:     "merge",
+			existing: "session=abc; id=1",
+			inputs: ParameterItems{
+				{
+					keys:   []Key{},
+					values: []string{"2"},
+				},
+			},
+			expected: "session=abc; id=2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			headers := http.Header{}
+			if tc.existing != "" {
+				headers.Set(rest.CookieHeader, tc.existing)
+			}
+
+			SetCookieParameters(&headers, "id", tc.inputs, tc.encoding)
+			assert.Equal(t, tc.expected, headers.Get(rest.CookieHeader))
+		})
+	}
+}
+
+func TestMergeCookieHeader(t *testing.T) {
+	testCases := []struct {
+		existing string
+		incoming string
+		expected string
+	}{
+		{},
+		{
+			existing: "a=1",
+			expected: "a=1",
+		},
+		{
+			incoming: "b=2; ",
+			expected: "b=2",
+		},
+		{
+			existing: "a=1; b=2",
+			incoming: "b=3;c=4",
+			expected: "a=1; b=3; c=4",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MergeCookieHeader(tc.existing, tc.incoming))
+		})
+	}
+}
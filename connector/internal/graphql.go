@@ -0,0 +1,307 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hasura/ndc-http/connector/internal/contenttype"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
+	"github.com/hasura/ndc-sdk-go/v2/utils"
+)
+
+// the maximum depth to look for the selection of the result type in wrapper objects
+// of the header forwarding and distributed execution.
+const graphQLSelectionLookupDepth = 3
+
+// graphQLRequestBody represents the payload of a GraphQL request.
+type graphQLRequestBody struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// graphQLResponseBody represents the payload of a GraphQL response.
+type graphQLResponseBody struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []map[string]any           `json:"errors,omitempty"`
+}
+
+// graphQLSelection represents the selection set of a GraphQL field. Leaf fields have nil selections.
+type graphQLSelection map[string]graphQLSelection
+
+// buildGraphQLRequestBody builds the GraphQL query of the root field.
+// Arguments are sent as variables and the selection set is derived from the requested fields.
+func (c *RequestBuilder) buildGraphQLRequestBody(
+	request *RetryableRequest,
+	operation *rest.GraphQLRequest,
+) error {
+	variables := map[string]any{}
+	definitions := []string{}
+	arguments := []string{}
+
+	for _, name := range utils.GetSortedKeys(operation.Variables) {
+		value, ok := c.Arguments[name]
+		// omit null arguments so the server can apply default values.
+		if !ok || value == nil {
+			continue
+		}
+
+		if argInfo, ok := c.Operation.Arguments[name]; ok && c.GlobalRuntime.StringifyJSON {
+			rawValue, err := contenttype.NewJSONEncoder(c.Schema).Encode(value, argInfo.Type)
+			if err != nil {
+				return schema.UnprocessableContentError(
+					"failed to encode the GraphQL variable "+name,
+					map[string]any{
+						"cause": err.Error(),
+					},
+				)
+			}
+
+			value = json.RawMessage(rawValue)
+		}
+
+		variables[name] = value
+		definitions = append(definitions, fmt.Sprintf("$%s: %s", name, operation.Variables[name]))
+		arguments = append(arguments, fmt.Sprintf("%s: $%s", name, name))
+	}
+
+	var query strings.Builder
+
+	query.WriteString(string(operation.OperationType))
+
+	if len(definitions) > 0 {
+		query.WriteString("(" + strings.Join(definitions, ", ") + ")")
+	}
+
+	query.WriteString(" { " + operation.Field)
+
+	if len(arguments) > 0 {
+		query.WriteString("(" + strings.Join(arguments, ", ") + ")")
+	}
+
+	resultType := c.Operation.OriginalResultType
+	if len(resultType) == 0 {
+		resultType = c.Operation.ResultType
+	}
+
+	if objectType, ok := c.getGraphQLObjectType(resultType); ok {
+		fields := c.findGraphQLSelectionFields(objectType, c.Fields, 0)
+		writeGraphQLSelection(&query, c.buildGraphQLSelection(objectType, fields))
+	}
+
+	query.WriteString(" }")
+
+	body, err := json.Marshal(graphQLRequestBody{
+		Query:     query.String(),
+		Variables: variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	request.ContentType = rest.ContentTypeJSON
+	request.Body = body
+
+	return nil
+}
+
+// findGraphQLSelectionFields finds the selected fields of the object type.
+// The result type can be wrapped in the header forwarding or distributed response objects,
+// so nested fields are looked up until all selected columns belong to the object type.
+func (c *RequestBuilder) findGraphQLSelectionFields(
+	objectType rest.ObjectType,
+	fields schema.NestedField,
+	depth int,
+) map[string]schema.Field {
+	objectFields := getNestedObjectFields(fields)
+	if len(objectFields) == 0 || depth > graphQLSelectionLookupDepth {
+		return nil
+	}
+
+	isObjectSelection := true
+
+	for _, field := range objectFields {
+		column, err := field.AsColumn()
+		if err != nil {
+			continue
+		}
+
+		if _, ok := objectType.Fields[column.Column]; !ok {
+			isObjectSelection = false
+
+			break
+		}
+	}
+
+	if isObjectSelection {
+		return objectFields
+	}
+
+	for _, key := range utils.GetSortedKeys(objectFields) {
+		column, err := objectFields[key].AsColumn()
+		if err != nil {
+			continue
+		}
+
+		if result := c.findGraphQLSelectionFields(objectType, column.Fields, depth+1); result != nil {
+			return result
+		}
+	}
+
+	return nil
+}
+
+// buildGraphQLSelection builds the selection set from requested columns.
+// Scalar fields are selected if there is no requested column.
+func (c *RequestBuilder) buildGraphQLSelection(
+	objectType rest.ObjectType,
+	fields map[string]schema.Field,
+) graphQLSelection {
+	selection := graphQLSelection{}
+
+	for _, field := range fields {
+		column, err := field.AsColumn()
+		if err != nil {
+			continue
+		}
+
+		fieldInfo, ok := objectType.Fields[column.Column]
+		if !ok {
+			continue
+		}
+
+		childType, isObject := c.getGraphQLObjectType(fieldInfo.Type)
+		if !isObject {
+			selection[column.Column] = nil
+
+			continue
+		}
+
+		// the same column can be requested many times with different aliases.
+		selection[column.Column] = mergeGraphQLSelection(
+			selection[column.Column],
+			c.buildGraphQLSelection(childType, getNestedObjectFields(column.Fields)),
+		)
+	}
+
+	if len(fields) > 0 && len(selection) > 0 {
+		return selection
+	}
+
+	for name, field := range objectType.Fields {
+		if _, isObject := c.getGraphQLObjectType(field.Type); !isObject {
+			selection[name] = nil
+		}
+	}
+
+	if len(selection) == 0 {
+		selection["__typename"] = nil
+	}
+
+	return selection
+}
+
+func (c *RequestBuilder) getGraphQLObjectType(fieldType schema.Type) (rest.ObjectType, bool) {
+	namedType := schema.GetUnderlyingNamedType(fieldType)
+	if namedType == nil {
+		return rest.ObjectType{}, false
+	}
+
+	objectType, ok := c.Schema.ObjectTypes[namedType.Name]
+
+	return objectType, ok
+}
+
+// evalGraphQLResponse unwraps the data of the root field from the GraphQL response.
+// GraphQL errors are returned as an unprocessable error even if partial data exists.
+func (client *HTTPClient) evalGraphQLResponse(
+	body io.Reader,
+	operation *rest.GraphQLRequest,
+	resultType schema.Type,
+) (any, *schema.ConnectorError) {
+	var payload graphQLResponseBody
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		return nil, schema.NewConnectorError(
+			http.StatusInternalServerError,
+			"failed to decode the GraphQL response",
+			map[string]any{
+				"cause": err.Error(),
+			},
+		)
+	}
+
+	if len(payload.Errors) > 0 {
+		message, ok := payload.Errors[0]["message"].(string)
+		if !ok || message == "" {
+			message = "the GraphQL request failed"
+		}
+
+		return nil, schema.NewConnectorError(
+			http.StatusUnprocessableEntity,
+			message,
+			map[string]any{
+				"errors": payload.Errors,
+			},
+		)
+	}
+
+	rawData, ok := payload.Data[operation.Field]
+	if !ok || len(rawData) == 0 || string(rawData) == "null" {
+		return nil, nil
+	}
+
+	result, err := contenttype.NewJSONDecoder(client.requests.Schema.NDCHttpSchema, contenttype.JSONDecodeOptions{
+		StringifyJSON: client.manager.RuntimeSettings.StringifyJSON,
+	}).Decode(bytes.NewReader(rawData), resultType)
+	if err != nil {
+		return nil, schema.NewConnectorError(http.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return result, nil
+}
+
+func getNestedObjectFields(fields schema.NestedField) map[string]schema.Field {
+	for len(fields) > 0 {
+		switch nestedField := fields.Interface().(type) {
+		case *schema.NestedObject:
+			return nestedField.Fields
+		case *schema.NestedArray:
+			fields = nestedField.Fields
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func mergeGraphQLSelection(dest graphQLSelection, src graphQLSelection) graphQLSelection {
+	if dest == nil {
+		return src
+	}
+
+	for key, value := range src {
+		dest[key] = mergeGraphQLSelection(dest[key], value)
+	}
+
+	return dest
+}
+
+func writeGraphQLSelection(sb *strings.Builder, selection graphQLSelection) {
+	if len(selection) == 0 {
+		return
+	}
+
+	sb.WriteString(" {")
+
+	for _, key := range utils.GetSortedKeys(selection) {
+		sb.WriteString(" " + key)
+		writeGraphQLSelection(sb, selection[key])
+	}
+
+	sb.WriteString(" }")
+}
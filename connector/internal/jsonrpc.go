@@ -0,0 +1,291 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/hasura/ndc-http/connector/internal/contenttype"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
+	"github.com/hasura/ndc-sdk-go/v2/utils"
+)
+
+const jsonRPCVersion = "2.0"
+
+// reserved JSON-RPC error codes which are caused by invalid requests of the connector
+// rather than the input of users.
+var jsonRPCInternalErrorCodes = map[int]bool{
+	-32700: true, // parse error
+	-32600: true, // invalid request
+	-32601: true, // method not found
+	-32603: true, // internal error
+}
+
+// jsonRPCRequestBody represents the request object of a JSON-RPC call.
+type jsonRPCRequestBody struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+	ID      int    `json:"id"`
+}
+
+// jsonRPCResponseBody represents the response object of a JSON-RPC call.
+type jsonRPCResponseBody struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *jsonRPCError   `json:"error"`
+}
+
+// jsonRPCError represents the error object of a JSON-RPC response.
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// jsonRPCBatchResult represents decoded results of a JSON-RPC batch response in order of requests.
+type jsonRPCBatchResult []any
+
+// buildJSONRPCRequestBody wraps arguments into params of the JSON-RPC request object.
+func (c *RequestBuilder) buildJSONRPCRequestBody(
+	request *RetryableRequest,
+	operation *rest.JSONRPCRequest,
+) error {
+	namedParams := map[string]any{}
+	positionalParams := make([]any, len(operation.Params))
+	// trailing null params are omitted so the server can apply default values.
+	positionalLength := 0
+
+	for i, name := range operation.Params {
+		value, ok := c.Arguments[name]
+		if !ok || value == nil {
+			continue
+		}
+
+		if argInfo, ok := c.Operation.Arguments[name]; ok && c.GlobalRuntime.StringifyJSON {
+			rawValue, err := contenttype.NewJSONEncoder(c.Schema).Encode(value, argInfo.Type)
+			if err != nil {
+				return schema.UnprocessableContentError(
+					"failed to encode the JSON-RPC param "+name,
+					map[string]any{
+						"cause": err.Error(),
+					},
+				)
+			}
+
+			value = json.RawMessage(rawValue)
+		}
+
+		namedParams[name] = value
+		positionalParams[i] = value
+		positionalLength = i + 1
+	}
+
+	body := jsonRPCRequestBody{
+		JSONRPC: jsonRPCVersion,
+		Method:  operation.Method,
+		ID:      1,
+	}
+
+	switch {
+	case operation.ParamStructure == rest.JSONRPCParamsByName && len(namedParams) > 0:
+		body.Params = namedParams
+	case operation.ParamStructure != rest.JSONRPCParamsByName && positionalLength > 0:
+		body.Params = positionalParams[:positionalLength]
+	}
+
+	rawBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	request.ContentType = rest.ContentTypeJSON
+	request.Body = rawBody
+
+	return nil
+}
+
+// SendJSONRPCBatch merges JSON-RPC requests into a batch request to the server of the first request.
+// Results are returned in order of requests after the selection is evaluated.
+func (client *HTTPClient) SendJSONRPCBatch(
+	ctx context.Context,
+	selection schema.NestedField,
+	requests []*RetryableRequest,
+) ([]any, error) {
+	if len(requests) == 0 {
+		return []any{}, nil
+	}
+
+	batchRequest, err := newJSONRPCBatchRequest(requests)
+	if err != nil {
+		return nil, schema.InternalServerError(err.Error(), nil)
+	}
+
+	result, headers, connectorErr := client.sendSingle(ctx, batchRequest, "batch")
+	if connectorErr != nil {
+		return nil, connectorErr
+	}
+
+	batchResult, ok := result.(jsonRPCBatchResult)
+	if !ok || len(batchResult) != len(requests) {
+		return nil, schema.NewConnectorError(
+			http.StatusBadGateway,
+			"the JSON-RPC batch response does not match requests",
+			nil,
+		)
+	}
+
+	results := make([]any, len(batchResult))
+
+	for i, item := range batchResult {
+		item = client.createHeaderForwardingResponse(item, headers)
+
+		if len(selection) > 0 {
+			item, err = utils.EvalNestedColumnFields(selection, item)
+			if err != nil {
+				return nil, schema.InternalServerError(err.Error(), nil)
+			}
+		}
+
+		results[i] = item
+	}
+
+	return results, nil
+}
+
+// newJSONRPCBatchRequest copies the first request with the body of an array of request objects.
+// Request ids are replaced with positions of requests, starting from 1.
+func newJSONRPCBatchRequest(requests []*RetryableRequest) (*RetryableRequest, error) {
+	batchBody := make([]map[string]json.RawMessage, len(requests))
+
+	for i, req := range requests {
+		var body map[string]json.RawMessage
+		if err := json.Unmarshal(req.Body, &body); err != nil {
+			return nil, fmt.Errorf("invalid JSON-RPC request object at %d: %w", i, err)
+		}
+
+		body["id"] = json.RawMessage(strconv.Itoa(i + 1))
+		batchBody[i] = body
+	}
+
+	rawBody, err := json.Marshal(batchBody)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *requests[0]
+	result.Body = rawBody
+
+	return &result, nil
+}
+
+// evalJSONRPCResponse unwraps the result from the JSON-RPC response object.
+// The response of a batch request is an array of response objects which are sorted by id.
+func (client *HTTPClient) evalJSONRPCResponse(
+	body io.Reader,
+	resultType schema.Type,
+) (any, *schema.ConnectorError) {
+	rawBody, err := io.ReadAll(body)
+	if err != nil {
+		return nil, schema.NewConnectorError(http.StatusInternalServerError, err.Error(), nil)
+	}
+
+	rawBody = bytes.TrimSpace(rawBody)
+	if !bytes.HasPrefix(rawBody, []byte("[")) {
+		var payload jsonRPCResponseBody
+		if err := json.Unmarshal(rawBody, &payload); err != nil {
+			return nil, newJSONRPCDecodeError(err)
+		}
+
+		return client.evalJSONRPCResult(payload, resultType)
+	}
+
+	var payloads []jsonRPCResponseBody
+	if err := json.Unmarshal(rawBody, &payloads); err != nil {
+		return nil, newJSONRPCDecodeError(err)
+	}
+
+	results := make(jsonRPCBatchResult, len(payloads))
+	evaluated := make([]bool, len(payloads))
+
+	for _, payload := range payloads {
+		id, err := strconv.Atoi(string(payload.ID))
+		if err != nil || id < 1 || id > len(payloads) || evaluated[id-1] {
+			// a response object without a valid id is returned if the batch request itself is invalid.
+			if payload.Error != nil {
+				return nil, payload.Error.toConnectorError()
+			}
+
+			return nil, newJSONRPCDecodeError(fmt.Errorf("invalid id %s in the batch response", payload.ID))
+		}
+
+		result, connectorErr := client.evalJSONRPCResult(payload, resultType)
+		if connectorErr != nil {
+			return nil, connectorErr
+		}
+
+		results[id-1] = result
+		evaluated[id-1] = true
+	}
+
+	return results, nil
+}
+
+func (client *HTTPClient) evalJSONRPCResult(
+	payload jsonRPCResponseBody,
+	resultType schema.Type,
+) (any, *schema.ConnectorError) {
+	if payload.Error != nil {
+		return nil, payload.Error.toConnectorError()
+	}
+
+	if len(payload.Result) == 0 || string(payload.Result) == "null" {
+		return nil, nil
+	}
+
+	result, err := contenttype.NewJSONDecoder(client.requests.Schema.NDCHttpSchema, contenttype.JSONDecodeOptions{
+		StringifyJSON: client.manager.RuntimeSettings.StringifyJSON,
+	}).Decode(bytes.NewReader(payload.Result), resultType)
+	if err != nil {
+		return nil, schema.NewConnectorError(http.StatusInternalServerError, err.Error(), nil)
+	}
+
+	return result, nil
+}
+
+func (je jsonRPCError) toConnectorError() *schema.ConnectorError {
+	statusCode := http.StatusUnprocessableEntity
+	if jsonRPCInternalErrorCodes[je.Code] {
+		statusCode = http.StatusInternalServerError
+	}
+
+	message := je.Message
+	if message == "" {
+		message = "the JSON-RPC request failed"
+	}
+
+	details := map[string]any{
+		"code": je.Code,
+	}
+
+	if je.Data != nil {
+		details["data"] = je.Data
+	}
+
+	return schema.NewConnectorError(statusCode, message, details)
+}
+
+func newJSONRPCDecodeError(err error) *schema.ConnectorError {
+	return schema.NewConnectorError(
+		http.StatusInternalServerError,
+		"failed to decode the JSON-RPC response",
+		map[string]any{
+			"cause": err.Error(),
+		},
+	)
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/hasura/ndc-http/connector/internal/contenttype"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
+)
+
+// odataValueResponse represents the response of collections and primitive values
+// which are wrapped in the value property with control information, e.g. @odata.context.
+type odataValueResponse struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// odataErrorResponse represents the error response of OData v4 services.
+type odataErrorResponse struct {
+	Error *odataError `json:"error"`
+}
+
+type odataError struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Target     string `json:"target,omitempty"`
+	Details    []any  `json:"details,omitempty"`
+	InnerError any    `json:"innererror,omitempty"`
+}
+
+// evalODataValueResponse unwraps the value property of the response and decodes it with the result type.
+func (client *HTTPClient) evalODataValueResponse(
+	body io.Reader,
+	resultType schema.Type,
+) (any, *schema.ConnectorError) {
+	var payload odataValueResponse
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		return nil, newODataDecodeError(err)
+	}
+
+	if len(payload.Value) == 0 || string(payload.Value) == "null" {
+		return nil, nil
+	}
+
+	result, err := contenttype.NewJSONDecoder(client.requests.Schema.NDCHttpSchema, contenttype.JSONDecodeOptions{
+		StringifyJSON: client.manager.RuntimeSettings.StringifyJSON,
+	}).Decode(bytes.NewReader(payload.Value), resultType)
+	if err != nil {
+		return nil, newODataDecodeError(err)
+	}
+
+	return result, nil
+}
+
+// evalODataError returns the connector error of the OData error response.
+func (client *HTTPClient) evalODataError(statusCode int, rawBody []byte) *schema.ConnectorError {
+	rawRequest := client.requests.Operation.Request
+	if rawRequest == nil || rawRequest.OData == nil {
+		return nil
+	}
+
+	var payload odataErrorResponse
+	if err := json.Unmarshal(rawBody, &payload); err != nil || payload.Error == nil ||
+		payload.Error.Message == "" {
+		return nil
+	}
+
+	return payload.Error.toConnectorError(statusCode)
+}
+
+func (oe odataError) toConnectorError(statusCode int) *schema.ConnectorError {
+	if statusCode < http.StatusInternalServerError {
+		statusCode = http.StatusUnprocessableEntity
+	}
+
+	details := map[string]any{
+		"code": oe.Code,
+	}
+
+	if oe.Target != "" {
+		details["target"] = oe.Target
+	}
+
+	if len(oe.Details) > 0 {
+		details["details"] = oe.Details
+	}
+
+	if oe.InnerError != nil {
+		details["innererror"] = oe.InnerError
+	}
+
+	return schema.NewConnectorError(statusCode, oe.Message, details)
+}
+
+func newODataDecodeError(err error) *schema.ConnectorError {
+	return schema.NewConnectorError(
+		http.StatusInternalServerError,
+		"failed to decode the OData response",
+		map[string]any{
+			"cause": err.Error(),
+		},
+	)
+}
@@ -22,6 +22,7 @@ type RequestBuilder struct {
 	Schema        *rest.NDCHttpSchema
 	Operation     *rest.OperationInfo
 	Arguments     map[string]any
+	Fields        schema.NestedField
 	Runtime       rest.RuntimeSettings
 	GlobalRuntime configuration.RuntimeSettings
 }
@@ -31,6 +32,7 @@ func NewRequestBuilder(
 	restSchema *rest.NDCHttpSchema,
 	operation *rest.OperationInfo,
 	arguments map[string]any,
+	fields schema.NestedField,
 	runtime rest.RuntimeSettings,
 	globalRuntime configuration.RuntimeSettings,
 ) *RequestBuilder {
@@ -38,6 +40,7 @@ func NewRequestBuilder(
 		Schema:        restSchema,
 		Operation:     operation,
 		Arguments:     arguments,
+		Fields:        fields,
 		Runtime:       runtime,
 		GlobalRuntime: globalRuntime,
 	}
@@ -64,8 +67,23 @@ func (c *RequestBuilder) Build() (*RetryableRequest, error) {
 		Runtime:    c.Runtime,
 	}
 
-	if err := c.buildRequestBody(request, rawRequest); err != nil {
-		return nil, err
+	switch {
+	case rawRequest.GraphQL != nil:
+		if err := c.buildGraphQLRequestBody(request, rawRequest.GraphQL); err != nil {
+			return nil, err
+		}
+	case rawRequest.JSONRPC != nil:
+		if err := c.buildJSONRPCRequestBody(request, rawRequest.JSONRPC); err != nil {
+			return nil, err
+		}
+	case rawRequest.SOAP != nil:
+		if err := c.buildSOAPRequestBody(request, rawRequest.SOAP); err != nil {
+			return nil, err
+		}
+	default:
+		if err := c.buildRequestBody(request, rawRequest); err != nil {
+			return nil, err
+		}
 	}
 
 	if rawRequest.Response.ContentType != "" && request.Headers.Get(acceptHeader) == "" {
@@ -104,6 +122,14 @@ func (c *RequestBuilder) Build() (*RetryableRequest, error) {
 		if rawRequest.Retry.MaxElapsedTimeSeconds > 0 {
 			request.Runtime.Retry.MaxElapsedTimeSeconds = rawRequest.Retry.MaxElapsedTimeSeconds
 		}
+
+		if rawRequest.MaxResponseBytes > 0 {
+			request.Runtime.MaxResponseBytes = rawRequest.MaxResponseBytes
+		}
+
+		if rawRequest.MaxDecompressedBytes > 0 {
+			request.Runtime.MaxDecompressedBytes = rawRequest.MaxDecompressedBytes
+		}
 	}
 
 	if request.Runtime.Retry.MaxElapsedTimeSeconds <= 0 && request.Runtime.Timeout > 0 {
@@ -288,7 +314,9 @@ func (c *RequestBuilder) evalURLAndHeaderParameters() (*url.URL, http.Header, er
 		}
 	}
 
-	for argumentKey, argumentInfo := range c.Operation.Arguments {
+	// evaluate arguments in order so the serialized cookie and header values are stable.
+	for _, argumentKey := range utils.GetSortedKeys(c.Operation.Arguments) {
+		argumentInfo := c.Operation.Arguments[argumentKey]
 		if argumentInfo.HTTP == nil ||
 			!slices.Contains(urlAndHeaderLocations, argumentInfo.HTTP.In) {
 			continue
@@ -346,6 +374,13 @@ func (c *RequestBuilder) evalURLAndHeaderParameterBySchema(
 	switch argumentInfo.HTTP.In {
 	case rest.InHeader:
 		contenttype.SetHeaderParameters(header, argumentInfo.HTTP, queryParams)
+	case rest.InCookie:
+		contenttype.SetCookieParameters(
+			header,
+			argumentKey,
+			queryParams,
+			argumentInfo.HTTP.EncodingObject,
+		)
 	case rest.InQuery:
 		q := endpoint.Query()
 		contenttype.EvalQueryParameters(
@@ -2,11 +2,15 @@ package internal
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"net/http"
 	"net/url"
 	"os"
 	"testing"
 
+	"github.com/hasura/ndc-http/ndc-http-schema/configuration"
 	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
 	"gotest.tools/v3/assert"
 )
 
@@ -221,3 +225,160 @@ func createMockSchemaOAS2(t *testing.T) *rest.NDCHttpSchema {
 
 	return &ndcSchema
 }
+
+func TestBuildGraphQLRequestBody(t *testing.T) {
+	rawSchema, err := os.ReadFile("../../ndc-http-schema/graphql/testdata/blog/expected.json")
+	assert.NilError(t, err)
+
+	var ndcSchema rest.NDCHttpSchema
+	assert.NilError(t, json.Unmarshal(rawSchema, &ndcSchema))
+
+	testCases := []struct {
+		name      string
+		arguments string
+		fields    string
+		expected  string
+	}{
+		{
+			name:      "post",
+			arguments: `{"id": "1"}`,
+			expected:  `{"query":"query($id: ID!) { post(id: $id) { body createdAt id rating status tags title views } }","variables":{"id":"1"}}`,
+		},
+		{
+			name:      "posts",
+			arguments: `{"limit": null}`,
+			fields: `{
+				"type": "object",
+				"fields": {
+					"results": {
+						"type": "column",
+						"column": "results",
+						"fields": {
+							"type": "array",
+							"fields": {
+								"type": "object",
+								"fields": {
+									"server": { "type": "column", "column": "server" },
+									"data": {
+										"type": "column",
+										"column": "data",
+										"fields": {
+											"type": "array",
+											"fields": {
+												"type": "object",
+												"fields": {
+													"id": { "type": "column", "column": "id" },
+													"writer": {
+														"type": "column",
+														"column": "author",
+														"fields": { "type": "object", "fields": { "name": { "type": "column", "column": "name" } } }
+													},
+													"author": {
+														"type": "column",
+														"column": "author",
+														"fields": { "type": "object", "fields": { "id": { "type": "column", "column": "id" } } }
+													}
+												}
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}`,
+			expected: `{"query":"query { posts { author { id name } id } }"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var arguments map[string]any
+			assert.NilError(t, json.Unmarshal([]byte(tc.arguments), &arguments))
+
+			var fields schema.NestedField
+			if tc.fields != "" {
+				assert.NilError(t, json.Unmarshal([]byte(tc.fields), &fields))
+			}
+
+			operation := ndcSchema.GetFunction(tc.name)
+			builder := NewRequestBuilder(&ndcSchema, operation, arguments, fields, rest.RuntimeSettings{}, configuration.RuntimeSettings{})
+
+			request, err := builder.Build()
+			assert.NilError(t, err)
+			assert.Equal(t, rest.ContentTypeJSON, request.ContentType)
+			assert.Equal(t, tc.expected, string(request.Body))
+		})
+	}
+}
+
+func TestBuildSOAPRequestBody(t *testing.T) {
+	rawSchema, err := os.ReadFile("../../ndc-http-schema/wsdl/testdata/calculator/expected.json")
+	assert.NilError(t, err)
+
+	var ndcSchema rest.NDCHttpSchema
+	assert.NilError(t, json.Unmarshal(rawSchema, &ndcSchema))
+
+	testCases := []struct {
+		name        string
+		arguments   string
+		contentType string
+		expected    string
+	}{
+		{
+			name:        "calcAdd",
+			arguments:   `{"body": {"a": 1, "b": 2}}`,
+			contentType: `application/soap+xml; charset=utf-8; action="urn:example:calculator#Add"`,
+			expected:    `<ns:Add xmlns:ns="urn:example:calculator:rpc"><a>1</a><b>2</b></ns:Add>`,
+		},
+		{
+			name:        "calcReset",
+			arguments:   `{}`,
+			contentType: `application/soap+xml; charset=utf-8`,
+			expected:    `<ns:Reset xmlns:ns="urn:example:calculator"></ns:Reset>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var arguments map[string]any
+			assert.NilError(t, json.Unmarshal([]byte(tc.arguments), &arguments))
+
+			operation := ndcSchema.GetProcedure(tc.name)
+			builder := NewRequestBuilder(&ndcSchema, operation, arguments, nil, rest.RuntimeSettings{}, configuration.RuntimeSettings{})
+
+			request, err := builder.Build()
+			assert.NilError(t, err)
+			assert.Equal(t, tc.contentType, request.ContentType)
+			assert.Equal(t, "", request.Headers.Get(soapActionHeader))
+			assert.Equal(
+				t,
+				xml.Header+`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body>`+tc.expected+`</soap:Body></soap:Envelope>`,
+				string(request.Body),
+			)
+		})
+	}
+}
+
+func TestParseSOAPFault(t *testing.T) {
+	content, err := extractSOAPBody([]byte(`<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope">
+		<env:Body>
+			<env:Fault>
+				<env:Code><env:Value>env:VersionMismatch</env:Value></env:Code>
+				<env:Reason><env:Text xml:lang="en">Version mismatch</env:Text></env:Reason>
+			</env:Fault>
+		</env:Body>
+	</env:Envelope>`))
+	assert.NilError(t, err)
+
+	fault := parseSOAPFault(content)
+	assert.Assert(t, fault != nil)
+	connectorErr := fault.toConnectorError()
+	assert.Equal(t, http.StatusInternalServerError, connectorErr.StatusCode())
+	assert.Equal(t, "Version mismatch", connectorErr.Message)
+	assert.DeepEqual(t, map[string]any{"code": "env:VersionMismatch"}, connectorErr.Details)
+
+	_, err = extractSOAPBody([]byte(`<html><body>Not found</body></html>`))
+	assert.ErrorContains(t, err, "not a SOAP envelope")
+}
@@ -20,16 +20,19 @@ import (
 type RawRequestBuilder struct {
 	operation      schema.MutationOperation
 	forwardHeaders configuration.ForwardHeadersSettings
+	redactor       *exhttp.Redactor
 }
 
 // NewRawRequestBuilder create a new RawRequestBuilder instance.
 func NewRawRequestBuilder(
 	operation schema.MutationOperation,
 	forwardHeaders configuration.ForwardHeadersSettings,
+	redactor *exhttp.Redactor,
 ) *RawRequestBuilder {
 	return &RawRequestBuilder{
 		operation:      operation,
 		forwardHeaders: forwardHeaders,
+		redactor:       redactor,
 	}
 }
 
@@ -44,20 +47,20 @@ func (rqe *RawRequestBuilder) Explain() (*schema.ExplainResponse, error) {
 	}
 
 	if httpRequest.Body != nil {
-		explainResp.Details["body"] = string(httpRequest.Body)
+		explainResp.Details["body"] = rqe.redactor.RedactBody(httpRequest.Body)
 		httpRequest.Body = nil
 	}
 
 	// mask sensitive forwarded headers if exists
-	for key, value := range httpRequest.Headers {
-		if IsSensitiveHeader(key) {
-			httpRequest.Headers.Set(key, restUtils.MaskString(value[0]))
-		}
+	headers := rqe.redactor.RedactHeaders(httpRequest.Headers)
+
+	if cookie := headers.Get(rest.CookieHeader); cookie != "" {
+		headers.Set(rest.CookieHeader, MaskCookieHeader(cookie))
 	}
 
-	explainResp.Details["url"] = httpRequest.URL.String()
+	explainResp.Details["url"] = rqe.redactor.RedactURL(&httpRequest.URL)
 
-	rawHeaders, err := json.Marshal(httpRequest.Headers)
+	rawHeaders, err := json.Marshal(headers)
 	if err != nil {
 		return nil, schema.InternalServerError("failed to encode headers", map[string]any{
 			"cause": err.Error(),
@@ -21,6 +21,18 @@ func (client *HTTPClient) transformResponse(body any) (any, error) {
 
 	var err error
 
+	// results of the JSON-RPC batch response are transformed separately.
+	if batchResult, ok := body.(jsonRPCBatchResult); ok {
+		for i, item := range batchResult {
+			batchResult[i], err = client.transformResponse(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return batchResult, nil
+	}
+
 	for _, setting := range client.requests.Schema.Settings.ResponseTransforms {
 		if len(setting.Targets) > 0 &&
 			!slices.Contains(setting.Targets, client.requests.OperationName) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hasura/ndc-http/connector/internal/contenttype"
 	"github.com/hasura/ndc-http/ndc-http-schema/schema"
 	"github.com/hasura/ndc-http/ndc-http-schema/utils"
 )
@@ -73,5 +74,10 @@ func (akc ApiKeyCredential) inject(req *http.Request, value string) {
 		q.Add(akc.Name, value)
 		endpoint.RawQuery = q.Encode()
 		req.URL = endpoint
+	case schema.APIKeyInCookie:
+		req.Header.Set(
+			schema.CookieHeader,
+			contenttype.MergeCookieHeader(req.Header.Get(schema.CookieHeader), akc.Name+"="+value),
+		)
 	}
 }
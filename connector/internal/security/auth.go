@@ -3,10 +3,13 @@ package security
 import (
 	"context"
 	"errors"
+	"maps"
 	"net/http"
 	"net/url"
+	"path"
 
 	"github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-http/ndc-http-schema/utils"
 )
 
 // Credential abstracts an authentication credential interface.
@@ -60,12 +63,65 @@ func NewCredential(
 
 		return cred, true, err
 	case *schema.MutualTLSAuthConfig:
-		return NewNoopCredential(httpClient), false, nil
+		return NewMutualTLSCredential(httpClient), false, nil
+	case *schema.TokenEndpointAuthConfig:
+		cred, err := NewTokenEndpointCredential(httpClient, baseServerURL, ss)
+
+		return cred, false, err
+	case *schema.DigestAuthConfig:
+		cred, err := NewDigestCredential(httpClient, ss)
+
+		return cred, err != nil, err
+	case *schema.NTLMAuthConfig:
+		cred, err := NewNTLMCredential(httpClient, ss)
+
+		return cred, err != nil, err
 	}
 
 	return NewNoopCredential(httpClient), true, nil
 }
 
+// GetSecretFilePaths returns paths of secret files that the security scheme reads credentials from.
+func GetSecretFilePaths(security schema.SecurityScheme) []string {
+	var secrets []*schema.SecretString
+
+	switch ss := security.SecuritySchemer.(type) {
+	case *schema.APIKeyAuthConfig:
+		secrets = append(secrets, &ss.Value)
+	case *schema.HTTPAuthConfig:
+		secrets = append(secrets, &ss.Value)
+	case *schema.BasicAuthConfig:
+		secrets = append(secrets, &ss.Username, &ss.Password)
+	case *schema.DigestAuthConfig:
+		secrets = append(secrets, &ss.Username, &ss.Password)
+	case *schema.NTLMAuthConfig:
+		secrets = append(secrets, &ss.Username, &ss.Password)
+	case *schema.OAuth2Config:
+		for _, flow := range ss.Flows {
+			secrets = append(secrets, flow.ClientID, flow.ClientSecret)
+		}
+	case *schema.TokenEndpointAuthConfig:
+		for _, field := range ss.Request.Body {
+			secrets = append(secrets, &field)
+		}
+	}
+
+	var results []string
+
+	for _, secret := range secrets {
+		if secret == nil {
+			continue
+		}
+
+		filePath, err := secret.GetFilePath()
+		if err == nil && filePath != "" {
+			results = append(results, filePath)
+		}
+	}
+
+	return results
+}
+
 // NoopCredential implements a no-op credential.
 type NoopCredential struct {
 	client *http.Client
@@ -94,3 +150,39 @@ func (cc NoopCredential) Inject(req *http.Request) (bool, error) {
 func (cc NoopCredential) InjectMock(req *http.Request) bool {
 	return false
 }
+
+func getClientTransport(client *http.Client) http.RoundTripper {
+	if client != nil && client.Transport != nil {
+		return client.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+// resolveTokenURL parses the token URL.
+// If the token URL is a relative path it will be joined with the base server URL.
+func resolveTokenURL(baseServerURL *url.URL, rawTokenURL string) (*url.URL, error) {
+	tokenURL, err := schema.ParseRelativeOrHttpURL(rawTokenURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenURL.Host != "" {
+		return tokenURL, nil
+	}
+
+	if baseServerURL == nil {
+		return nil, errors.New("the base server URL is required to resolve the relative token URL")
+	}
+
+	tu := utils.CloneURL(baseServerURL)
+	tu.Path = path.Join(tu.Path, tokenURL.Path)
+
+	q := tu.Query()
+	maps.Copy(q, tokenURL.Query())
+
+	tu.RawQuery = q.Encode()
+	tu.RawFragment = tokenURL.RawFragment
+
+	return tu, nil
+}
@@ -0,0 +1,64 @@
+package security
+
+import (
+	"net/http"
+	"sync"
+)
+
+// TransportCredential abstracts a credential that authenticates requests in the transport of its HTTP client,
+// e.g. challenge-response schemes that replay requests.
+type TransportCredential interface {
+	Credential
+	// WrapTransport wraps the transport with the authentication of the credential.
+	WrapTransport(transport http.RoundTripper) http.RoundTripper
+}
+
+// CompositeClients creates and caches HTTP clients which chain transports of credentials
+// that are required together by a security requirement.
+// Clients are reused so that stateful transports, e.g. NTLM connections, aren't reset per request.
+type CompositeClients struct {
+	lock    sync.Mutex
+	clients map[string]*http.Client
+}
+
+// NewCompositeClients creates a new CompositeClients instance.
+func NewCompositeClients() *CompositeClients {
+	return &CompositeClients{
+		clients: make(map[string]*http.Client),
+	}
+}
+
+// Get gets or creates the client of the key which chains transports of credentials over the base client.
+// The first credential handles the response last.
+func (cc *CompositeClients) Get(
+	key string,
+	baseClient *http.Client,
+	creds []TransportCredential,
+) *http.Client {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	if client, ok := cc.clients[key]; ok {
+		return client
+	}
+
+	transport := getClientTransport(baseClient)
+
+	for i := len(creds) - 1; i >= 0; i-- {
+		transport = creds[i].WrapTransport(transport)
+	}
+
+	client := &http.Client{
+		Transport: transport,
+	}
+
+	if baseClient != nil {
+		client.CheckRedirect = baseClient.CheckRedirect
+		client.Jar = baseClient.Jar
+		client.Timeout = baseClient.Timeout
+	}
+
+	cc.clients[key] = client
+
+	return client
+}
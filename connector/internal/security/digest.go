@@ -0,0 +1,82 @@
+package security
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hasura/ndc-http/exhttp"
+	"github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// DigestCredential represents the HTTP Digest authentication credential.
+// The credential is handled by the transport of the HTTP client that answers the 401 challenge of the server.
+type DigestCredential struct {
+	client   *http.Client
+	user     string
+	password string
+	// the username and password are configured.
+	configured bool
+}
+
+var (
+	_ Credential          = &DigestCredential{}
+	_ TransportCredential = &DigestCredential{}
+)
+
+// NewDigestCredential creates a new DigestCredential instance.
+func NewDigestCredential(
+	httpClient *http.Client,
+	config *schema.DigestAuthConfig,
+) (*DigestCredential, error) {
+	user, err := config.Username.Get()
+	if err != nil {
+		return nil, fmt.Errorf("DigestAuthConfig.Username: %w", err)
+	}
+
+	password, err := config.Password.Get()
+	if err != nil {
+		return nil, fmt.Errorf("DigestAuthConfig.Password: %w", err)
+	}
+
+	result := &DigestCredential{
+		user:       user,
+		password:   password,
+		configured: user != "" && password != "",
+	}
+
+	result.client = &http.Client{
+		Transport:     result.WrapTransport(getClientTransport(httpClient)),
+		CheckRedirect: httpClient.CheckRedirect,
+		Jar:           httpClient.Jar,
+		Timeout:       httpClient.Timeout,
+	}
+
+	return result, nil
+}
+
+// GetClient gets the HTTP client that is compatible with the current credential.
+func (dc DigestCredential) GetClient() *http.Client {
+	return dc.client
+}
+
+// WrapTransport wraps the transport with the digest authentication.
+func (dc DigestCredential) WrapTransport(transport http.RoundTripper) http.RoundTripper {
+	return exhttp.NewDigestTransport(transport, dc.user, dc.password)
+}
+
+// Inject the credential into the incoming request.
+// The Authorization header is computed from the challenge of the server when the request is sent.
+func (dc DigestCredential) Inject(req *http.Request) (bool, error) {
+	return dc.configured, nil
+}
+
+// InjectMock injects the mock credential into the incoming request for explain APIs.
+func (dc DigestCredential) InjectMock(req *http.Request) bool {
+	if !dc.configured {
+		return false
+	}
+
+	req.Header.Set(schema.AuthorizationHeader, "Digest xxx")
+
+	return true
+}
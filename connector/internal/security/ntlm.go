@@ -0,0 +1,95 @@
+package security
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hasura/ndc-http/exhttp"
+	"github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// NTLMCredential represents the NTLM authentication credential.
+// The handshake is handled by the transport of the HTTP client that authenticates keep-alive connections.
+type NTLMCredential struct {
+	client   *http.Client
+	domain   string
+	user     string
+	password string
+	// the username and password are configured.
+	configured bool
+}
+
+var (
+	_ Credential          = &NTLMCredential{}
+	_ TransportCredential = &NTLMCredential{}
+)
+
+// NewNTLMCredential creates a new NTLMCredential instance.
+func NewNTLMCredential(
+	httpClient *http.Client,
+	config *schema.NTLMAuthConfig,
+) (*NTLMCredential, error) {
+	var domain string
+
+	if config.Domain != nil {
+		var err error
+
+		domain, err = config.Domain.GetOrDefault("")
+		if err != nil {
+			return nil, fmt.Errorf("NTLMAuthConfig.Domain: %w", err)
+		}
+	}
+
+	user, err := config.Username.Get()
+	if err != nil {
+		return nil, fmt.Errorf("NTLMAuthConfig.Username: %w", err)
+	}
+
+	password, err := config.Password.Get()
+	if err != nil {
+		return nil, fmt.Errorf("NTLMAuthConfig.Password: %w", err)
+	}
+
+	result := &NTLMCredential{
+		domain:     domain,
+		user:       user,
+		password:   password,
+		configured: user != "" && password != "",
+	}
+
+	result.client = &http.Client{
+		Transport:     result.WrapTransport(getClientTransport(httpClient)),
+		CheckRedirect: httpClient.CheckRedirect,
+		Jar:           httpClient.Jar,
+		Timeout:       httpClient.Timeout,
+	}
+
+	return result, nil
+}
+
+// GetClient gets the HTTP client that is compatible with the current credential.
+func (nc NTLMCredential) GetClient() *http.Client {
+	return nc.client
+}
+
+// WrapTransport wraps the transport with the NTLM handshake.
+func (nc NTLMCredential) WrapTransport(transport http.RoundTripper) http.RoundTripper {
+	return exhttp.NewNTLMTransport(transport, nc.domain, nc.user, nc.password)
+}
+
+// Inject the credential into the incoming request.
+// The handshake messages are sent by the transport when the server challenges the request.
+func (nc NTLMCredential) Inject(req *http.Request) (bool, error) {
+	return nc.configured, nil
+}
+
+// InjectMock injects the mock credential into the incoming request for explain APIs.
+func (nc NTLMCredential) InjectMock(req *http.Request) bool {
+	if !nc.configured {
+		return false
+	}
+
+	req.Header.Set(schema.AuthorizationHeader, "NTLM xxx")
+
+	return true
+}
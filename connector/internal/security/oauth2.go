@@ -3,24 +3,44 @@ package security
 import (
 	"context"
 	"fmt"
-	"maps"
 	"net/http"
 	"net/url"
-	"path"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/hasura/ndc-http/ndc-http-schema/schema"
-	"github.com/hasura/ndc-http/ndc-http-schema/utils"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
+// ScopedCredential abstracts a credential that injects tokens with scopes required by the operation.
+type ScopedCredential interface {
+	Credential
+	// InjectWithScopes injects the credential with required scopes into the incoming request.
+	InjectWithScopes(request *http.Request, scopes []string) (bool, error)
+}
+
 // OAuth2Client represent the client of the OAuth2 client credentials.
 type OAuth2Client struct {
 	client  *http.Client
 	isEmpty bool
+
+	// the context contains the HTTP client to request tokens.
+	ctx              context.Context
+	config           *clientcredentials.Config
+	allScopes        []string
+	requestAllScopes bool
+
+	// token sources are cached by the set of requested scopes.
+	lock         sync.Mutex
+	tokenSources map[string]oauth2.TokenSource
 }
 
-var _ Credential = &OAuth2Client{}
+var (
+	_ Credential       = &OAuth2Client{}
+	_ ScopedCredential = &OAuth2Client{}
+)
 
 // NewOAuth2Client creates an OAuth2 client from the security scheme.
 func NewOAuth2Client(
@@ -44,30 +64,18 @@ func NewOAuth2Client(
 		return nil, fmt.Errorf("tokenUrl: %w", err)
 	}
 
-	tokenURL, err := schema.ParseRelativeOrHttpURL(rawTokenURL)
+	tokenURL, err := resolveTokenURL(baseServerURL, rawTokenURL)
 	if err != nil {
 		return nil, fmt.Errorf("tokenUrl: %w", err)
 	}
 
-	// if the token URL is a relative path it will be joined with the base server URL
-	if tokenURL.Host == "" {
-		tu := utils.CloneURL(baseServerURL)
-		tu.Path = path.Join(tu.Path, tokenURL.Path)
-
-		q := tu.Query()
-		maps.Copy(q, tokenURL.Query())
-
-		tu.RawQuery = q.Encode()
-		tu.RawFragment = tokenURL.RawFragment
-
-		tokenURL = tu
-	}
-
 	scopes := make([]string, 0, len(config.Scopes))
 	for scope := range config.Scopes {
 		scopes = append(scopes, scope)
 	}
 
+	slices.Sort(scopes)
+
 	clientID, err := config.ClientID.Get()
 	if err != nil {
 		return nil, fmt.Errorf("clientId: %w", err)
@@ -78,7 +86,7 @@ func NewOAuth2Client(
 		return nil, fmt.Errorf("clientSecret: %w", err)
 	}
 
-	var endpointParams url.Values
+	endpointParams := url.Values{}
 
 	for key, envValue := range config.EndpointParams {
 		value, err := envValue.GetOrDefault("")
@@ -91,34 +99,55 @@ func NewOAuth2Client(
 		}
 	}
 
-	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
-	conf := &clientcredentials.Config{
-		ClientID:       clientID,
-		ClientSecret:   clientSecret,
-		Scopes:         scopes,
-		TokenURL:       tokenURL.String(),
-		EndpointParams: endpointParams,
-	}
-
-	client := conf.Client(ctx)
-
 	return &OAuth2Client{
-		client: client,
+		client: httpClient,
+		ctx:    context.WithValue(ctx, oauth2.HTTPClient, httpClient),
+		config: &clientcredentials.Config{
+			ClientID:       clientID,
+			ClientSecret:   clientSecret,
+			TokenURL:       tokenURL.String(),
+			EndpointParams: endpointParams,
+		},
+		allScopes:        scopes,
+		requestAllScopes: config.RequestAllScopes,
+		tokenSources:     make(map[string]oauth2.TokenSource),
 	}, nil
 }
 
 // GetClient gets the HTTP client that is compatible with the current credential.
-func (oc OAuth2Client) GetClient() *http.Client {
+func (oc *OAuth2Client) GetClient() *http.Client {
 	return oc.client
 }
 
 // Inject the credential into the incoming request.
-func (oc OAuth2Client) Inject(req *http.Request) (bool, error) {
-	return !oc.isEmpty, nil
+func (oc *OAuth2Client) Inject(req *http.Request) (bool, error) {
+	return oc.InjectWithScopes(req, nil)
+}
+
+// InjectWithScopes injects the access token with required scopes into the incoming request.
+// If the operation doesn't require any scope the token is requested with all scopes of the flow
+// when requestAllScopes is enabled, otherwise without scopes.
+func (oc *OAuth2Client) InjectWithScopes(req *http.Request, scopes []string) (bool, error) {
+	if oc.isEmpty {
+		return false, nil
+	}
+
+	if len(scopes) == 0 && oc.requestAllScopes {
+		scopes = oc.allScopes
+	}
+
+	token, err := oc.getTokenSource(scopes).Token()
+	if err != nil {
+		return false, err
+	}
+
+	token.SetAuthHeader(req)
+
+	return true, nil
 }
 
 // InjectMock injects the mock credential into the incoming request for explain APIs.
-func (oc OAuth2Client) InjectMock(req *http.Request) bool {
+func (oc *OAuth2Client) InjectMock(req *http.Request) bool {
 	if oc.isEmpty {
 		return false
 	}
@@ -127,3 +156,24 @@ func (oc OAuth2Client) InjectMock(req *http.Request) bool {
 
 	return true
 }
+
+func (oc *OAuth2Client) getTokenSource(scopes []string) oauth2.TokenSource {
+	sortedScopes := slices.Clone(scopes)
+	slices.Sort(sortedScopes)
+	sortedScopes = slices.Compact(sortedScopes)
+	key := strings.Join(sortedScopes, " ")
+
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+
+	if ts, ok := oc.tokenSources[key]; ok {
+		return ts
+	}
+
+	conf := *oc.config
+	conf.Scopes = sortedScopes
+	ts := oauth2.ReuseTokenSource(nil, conf.TokenSource(oc.ctx))
+	oc.tokenSources[key] = ts
+
+	return ts
+}
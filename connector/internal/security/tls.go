@@ -8,6 +8,20 @@ import (
 	"github.com/hasura/ndc-http/exhttp"
 )
 
+// NewHTTPClientTransport creates a new HTTP Client with the connection pool and timeout settings of the transport.
+// The transport is the base of TLS and proxy settings that are applied later.
+func NewHTTPClientTransport(
+	baseClient *http.Client,
+	transportConfig *exhttp.HTTPTransportConfig,
+) *http.Client {
+	return &http.Client{
+		Transport:     transportConfig.ToTransport(),
+		CheckRedirect: baseClient.CheckRedirect,
+		Jar:           baseClient.Jar,
+		Timeout:       baseClient.Timeout,
+	}
+}
+
 // NewHTTPClientTLS creates a new HTTP Client with TLS configuration.
 func NewHTTPClientTLS(
 	baseClient *http.Client,
@@ -26,3 +40,58 @@ func NewHTTPClientTLS(
 		Timeout:       baseClient.Timeout,
 	}, nil
 }
+
+// NewHTTPClientProxy creates a new HTTP Client with the outbound proxy configuration.
+func NewHTTPClientProxy(
+	baseClient *http.Client,
+	proxyConfig *exhttp.ProxyConfig,
+) (*http.Client, error) {
+	transport, err := exhttp.NewProxyTransport(baseClient.Transport, proxyConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport:     transport,
+		CheckRedirect: baseClient.CheckRedirect,
+		Jar:           baseClient.Jar,
+		Timeout:       baseClient.Timeout,
+	}, nil
+}
+
+// NewHTTPClientUnixSocket creates a new HTTP Client that sends requests through the Unix domain socket.
+func NewHTTPClientUnixSocket(baseClient *http.Client, socketPath string) *http.Client {
+	return &http.Client{
+		Transport:     exhttp.NewUnixSocketTransport(baseClient.Transport, socketPath),
+		CheckRedirect: baseClient.CheckRedirect,
+		Jar:           baseClient.Jar,
+		Timeout:       baseClient.Timeout,
+	}
+}
+
+// NewHTTPClientEgress creates a new HTTP Client that enforces the egress policy.
+func NewHTTPClientEgress(baseClient *http.Client, policy *exhttp.EgressPolicy) *http.Client {
+	return &http.Client{
+		Transport:     exhttp.NewEgressTransport(baseClient.Transport, policy),
+		CheckRedirect: policy.CheckRedirect,
+		Jar:           baseClient.Jar,
+		Timeout:       baseClient.Timeout,
+	}
+}
+
+// MutualTLSCredential represents the mutual TLS security scheme.
+// The client certificate is loaded into the TLS settings of the HTTP client so nothing is injected into requests.
+type MutualTLSCredential struct {
+	NoopCredential
+}
+
+var _ Credential = &MutualTLSCredential{}
+
+// NewMutualTLSCredential creates a new MutualTLSCredential instance.
+func NewMutualTLSCredential(client *http.Client) *MutualTLSCredential {
+	return &MutualTLSCredential{
+		NoopCredential: NoopCredential{
+			client: client,
+		},
+	}
+}
@@ -0,0 +1,419 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/theory/jsonpath"
+)
+
+// refresh the token a bit earlier than the expiry time to avoid using an expired token in flight.
+const tokenExpiryDelta = 10 * time.Second
+
+// TokenEndpointCredential represents a credential that logs in to a custom token endpoint
+// and injects the returned token into requests.
+type TokenEndpointCredential struct {
+	In     schema.APIKeyLocation
+	Name   string
+	Scheme string
+
+	tokenURL      *url.URL
+	method        string
+	contentType   string
+	headers       map[string]string
+	body          map[string]string
+	tokenPath     *jsonpath.Path
+	expiresInPath *jsonpath.Path
+
+	// the base client sends login requests.
+	baseClient *http.Client
+	// the client re-logins and replays the request once if the server responds 401.
+	client *http.Client
+
+	lock   sync.RWMutex
+	token  string
+	expiry time.Time
+}
+
+var (
+	_ Credential          = &TokenEndpointCredential{}
+	_ TransportCredential = &TokenEndpointCredential{}
+)
+
+// NewTokenEndpointCredential creates a new TokenEndpointCredential instance.
+func NewTokenEndpointCredential(
+	httpClient *http.Client,
+	baseServerURL *url.URL,
+	config *schema.TokenEndpointAuthConfig,
+) (*TokenEndpointCredential, error) {
+	rawTokenURL, err := config.Request.URL.Get()
+	if err != nil {
+		return nil, fmt.Errorf("request.url: %w", err)
+	}
+
+	tokenURL, err := resolveTokenURL(baseServerURL, rawTokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("request.url: %w", err)
+	}
+
+	tokenPath, err := config.GetTokenPath()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresInPath, err := config.GetExpiresInPath()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string)
+
+	for key, envValue := range config.Request.Headers {
+		value, err := envValue.GetOrDefault("")
+		if err != nil {
+			return nil, fmt.Errorf("request.headers[%s]: %w", key, err)
+		}
+
+		if value != "" {
+			headers[key] = value
+		}
+	}
+
+	body := make(map[string]string)
+
+	for key, envValue := range config.Request.Body {
+		value, err := envValue.Get()
+		if err != nil {
+			return nil, fmt.Errorf("request.body[%s]: %w", key, err)
+		}
+
+		body[key] = value
+	}
+
+	result := &TokenEndpointCredential{
+		In:            config.In,
+		Name:          config.Name,
+		Scheme:        config.Scheme,
+		tokenURL:      tokenURL,
+		method:        config.Request.GetMethod(),
+		contentType:   config.Request.GetContentType(),
+		headers:       headers,
+		body:          body,
+		tokenPath:     tokenPath,
+		expiresInPath: expiresInPath,
+		baseClient:    httpClient,
+	}
+
+	result.client = &http.Client{
+		Transport:     result.WrapTransport(getClientTransport(httpClient)),
+		CheckRedirect: httpClient.CheckRedirect,
+		Jar:           httpClient.Jar,
+		Timeout:       httpClient.Timeout,
+	}
+
+	return result, nil
+}
+
+// GetClient gets the HTTP client that is compatible with the current credential.
+func (tec *TokenEndpointCredential) GetClient() *http.Client {
+	return tec.client
+}
+
+// WrapTransport wraps the transport which re-logins and replays the request once if the server responds 401.
+func (tec *TokenEndpointCredential) WrapTransport(transport http.RoundTripper) http.RoundTripper {
+	return &tokenEndpointTransport{
+		credential: tec,
+		transport:  transport,
+	}
+}
+
+// Inject the credential into the incoming request.
+func (tec *TokenEndpointCredential) Inject(req *http.Request) (bool, error) {
+	token, err := tec.getToken(req.Context())
+	if err != nil {
+		return false, err
+	}
+
+	tec.inject(req, token)
+
+	return true, nil
+}
+
+// InjectMock injects the mock credential into the incoming request for explain APIs.
+func (tec *TokenEndpointCredential) InjectMock(req *http.Request) bool {
+	tec.inject(req, "xxx")
+
+	return true
+}
+
+func (tec *TokenEndpointCredential) inject(req *http.Request, token string) {
+	value := token
+	if tec.Scheme != "" {
+		value = tec.Scheme + " " + token
+	}
+
+	switch tec.In {
+	case schema.APIKeyInHeader:
+		req.Header.Set(tec.Name, value)
+	case schema.APIKeyInQuery:
+		q := req.URL.Query()
+		q.Set(tec.Name, value)
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+// extractToken gets the token that was injected into the request.
+func (tec *TokenEndpointCredential) extractToken(req *http.Request) string {
+	var value string
+
+	switch tec.In {
+	case schema.APIKeyInHeader:
+		value = req.Header.Get(tec.Name)
+	case schema.APIKeyInQuery:
+		value = req.URL.Query().Get(tec.Name)
+	}
+
+	if tec.Scheme != "" {
+		value = strings.TrimPrefix(value, tec.Scheme+" ")
+	}
+
+	return value
+}
+
+func (tec *TokenEndpointCredential) getToken(ctx context.Context) (string, error) {
+	tec.lock.RLock()
+	token, ok := tec.getValidToken()
+	tec.lock.RUnlock()
+
+	if ok {
+		return token, nil
+	}
+
+	tec.lock.Lock()
+	defer tec.lock.Unlock()
+
+	// another request may have logged in while waiting for the lock.
+	if token, ok := tec.getValidToken(); ok {
+		return token, nil
+	}
+
+	return tec.login(ctx)
+}
+
+// refreshToken logs in again if the stale token is still the current one.
+// Concurrent requests that failed with the same stale token share a single login.
+func (tec *TokenEndpointCredential) refreshToken(
+	ctx context.Context,
+	staleToken string,
+) (string, error) {
+	tec.lock.Lock()
+	defer tec.lock.Unlock()
+
+	if token, ok := tec.getValidToken(); ok && token != staleToken {
+		return token, nil
+	}
+
+	return tec.login(ctx)
+}
+
+// getValidToken returns the cached token if it isn't expired. The caller must hold the lock.
+func (tec *TokenEndpointCredential) getValidToken() (string, bool) {
+	if tec.token == "" {
+		return "", false
+	}
+
+	if !tec.expiry.IsZero() && time.Now().Add(tokenExpiryDelta).After(tec.expiry) {
+		return "", false
+	}
+
+	return tec.token, true
+}
+
+// login sends the login request and caches the token. The caller must hold the write lock.
+func (tec *TokenEndpointCredential) login(ctx context.Context) (string, error) {
+	req, err := tec.createLoginRequest(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := tec.baseClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request the token endpoint: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the token endpoint response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("cannot fetch token: %s, %s", resp.Status, string(respBody))
+	}
+
+	var result any
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to decode the token endpoint response: %w", err)
+	}
+
+	token, err := tec.evalToken(result)
+	if err != nil {
+		return "", err
+	}
+
+	expiry, err := tec.evalExpiry(result)
+	if err != nil {
+		return "", err
+	}
+
+	tec.token = token
+	tec.expiry = expiry
+
+	return token, nil
+}
+
+func (tec *TokenEndpointCredential) createLoginRequest(ctx context.Context) (*http.Request, error) {
+	var body io.Reader
+
+	if len(tec.body) > 0 {
+		switch tec.contentType {
+		case schema.ContentTypeFormURLEncoded:
+			values := url.Values{}
+			for key, value := range tec.body {
+				values.Set(key, value)
+			}
+
+			body = strings.NewReader(values.Encode())
+		default:
+			rawBody, err := json.Marshal(tec.body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode the login request body: %w", err)
+			}
+
+			body = bytes.NewReader(rawBody)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, tec.method, tec.tokenURL.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the login request: %w", err)
+	}
+
+	for key, value := range tec.headers {
+		req.Header.Set(key, value)
+	}
+
+	if body != nil {
+		req.Header.Set(schema.ContentTypeHeader, tec.contentType)
+	}
+
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", schema.ContentTypeJSON)
+	}
+
+	return req, nil
+}
+
+func (tec *TokenEndpointCredential) evalToken(body any) (string, error) {
+	nodes := tec.tokenPath.Select(body)
+	if len(nodes) == 0 {
+		return "", fmt.Errorf("token does not exist in the response body at %s", tec.tokenPath)
+	}
+
+	token, ok := nodes[0].(string)
+	if !ok || token == "" {
+		return "", fmt.Errorf("expected the token at %s to be a non-empty string, got: %v", tec.tokenPath, nodes[0])
+	}
+
+	return token, nil
+}
+
+func (tec *TokenEndpointCredential) evalExpiry(body any) (time.Time, error) {
+	if tec.expiresInPath == nil {
+		return time.Time{}, nil
+	}
+
+	nodes := tec.expiresInPath.Select(body)
+	if len(nodes) == 0 || nodes[0] == nil {
+		return time.Time{}, nil
+	}
+
+	switch value := nodes[0].(type) {
+	case float64:
+		return time.Now().Add(time.Duration(value * float64(time.Second))), nil
+	case string:
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+			return time.Now().Add(time.Duration(seconds * float64(time.Second))), nil
+		}
+
+		expiry, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse the token expiry at %s: %w", tec.expiresInPath, err)
+		}
+
+		return expiry, nil
+	default:
+		return time.Time{}, fmt.Errorf(
+			"invalid token expiry at %s; expected a number or an RFC 3339 string, got: %v",
+			tec.expiresInPath,
+			value,
+		)
+	}
+}
+
+// tokenEndpointTransport re-logins and replays the request once if the server responds 401 Unauthorized.
+type tokenEndpointTransport struct {
+	credential *TokenEndpointCredential
+	transport  http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (tt *tokenEndpointTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := tt.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// the request body can't be replayed.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	staleToken := tt.credential.extractToken(req)
+
+	token, err := tt.credential.refreshToken(req.Context(), staleToken)
+	if err != nil {
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("failed to refresh the token after 401 Unauthorized: %w", err)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	newReq := req.Clone(req.Context())
+
+	if req.GetBody != nil {
+		newReq.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tt.credential.inject(newReq, token)
+
+	return tt.transport.RoundTrip(newReq)
+}
@@ -0,0 +1,319 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hasura/ndc-http/connector/internal/contenttype"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
+)
+
+const (
+	soap11EnvelopeNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12EnvelopeNamespace = "http://www.w3.org/2003/05/soap-envelope"
+	soapActionHeader        = "SOAPAction"
+)
+
+// fault codes which are caused by invalid envelopes of the connector rather than the input of users.
+var soapInternalFaultCodes = map[string]bool{
+	"DataEncodingUnknown": true,
+	"MustUnderstand":      true,
+	"VersionMismatch":     true,
+}
+
+// soapFault represents the fault element of SOAP 1.1 and 1.2 responses.
+type soapFault struct {
+	// SOAP 1.1
+	FaultCode   string           `xml:"faultcode"`
+	FaultString string           `xml:"faultstring"`
+	FaultActor  string           `xml:"faultactor"`
+	Detail11    *soapFaultDetail `xml:"detail"`
+	// SOAP 1.2
+	Code struct {
+		Value string `xml:"Value"`
+	} `xml:"Code"`
+	Reason struct {
+		Text []string `xml:"Text"`
+	} `xml:"Reason"`
+	Detail12 *soapFaultDetail `xml:"Detail"`
+}
+
+type soapFaultDetail struct {
+	Content []byte `xml:",innerxml"`
+}
+
+// buildSOAPRequestBody encodes the body argument to XML and wraps it into the SOAP envelope.
+// The action is sent in the SOAPAction header of SOAP 1.1 or the action parameter of the SOAP 1.2 content type.
+func (c *RequestBuilder) buildSOAPRequestBody(
+	request *RetryableRequest,
+	operation *rest.SOAPRequest,
+) error {
+	var content []byte
+
+	bodyInfo, infoOk := c.Operation.Arguments[rest.BodyKey]
+	bodyData := c.Arguments[rest.BodyKey]
+
+	switch {
+	case infoOk && bodyInfo.HTTP != nil && bodyData != nil:
+		xmlBody, err := contenttype.NewXMLEncoder(c.Schema).Encode(&bodyInfo, bodyData)
+		if err != nil {
+			return schema.UnprocessableContentError(
+				"failed to encode the SOAP body",
+				map[string]any{
+					"cause": err.Error(),
+				},
+			)
+		}
+
+		content = bytes.TrimPrefix(xmlBody, []byte(xml.Header))
+	case operation.Element != nil:
+		xmlBody, err := encodeEmptyXMLElement(operation.Element)
+		if err != nil {
+			return err
+		}
+
+		content = xmlBody
+	}
+
+	envelopeNamespace := soap11EnvelopeNamespace
+	contentType := rest.ContentTypeTextXML + "; charset=utf-8"
+
+	if operation.Version == rest.SOAPVersion12 {
+		envelopeNamespace = soap12EnvelopeNamespace
+		contentType = rest.ContentTypeSOAPXML + "; charset=utf-8"
+
+		if operation.Action != "" {
+			contentType += `; action="` + operation.Action + `"`
+		}
+	} else {
+		// the SOAPAction header is required by SOAP 1.1 even if the action is empty.
+		request.Headers.Set(soapActionHeader, `"`+operation.Action+`"`)
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<soap:Envelope xmlns:soap="` + envelopeNamespace + `"><soap:Body>`)
+	buf.Write(content)
+	buf.WriteString(`</soap:Body></soap:Envelope>`)
+
+	request.ContentType = contentType
+	request.Body = buf.Bytes()
+
+	return nil
+}
+
+func encodeEmptyXMLElement(xmlSchema *rest.XMLSchema) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := xml.NewEncoder(&buf)
+	start := xml.StartElement{
+		Name: xml.Name{Local: xmlSchema.GetFullName()},
+	}
+
+	if xmlSchema.Namespace != "" {
+		start.Attr = append(start.Attr, xmlSchema.GetNamespaceAttribute())
+	}
+
+	if err := enc.EncodeToken(start); err != nil {
+		return nil, err
+	}
+
+	if err := enc.EncodeToken(start.End()); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// evalSOAPResponse unwraps the content of the SOAP body and decodes it with the result type.
+func (client *HTTPClient) evalSOAPResponse(
+	body io.Reader,
+	resultType schema.Type,
+) (any, *schema.ConnectorError) {
+	rawBody, err := io.ReadAll(body)
+	if err != nil {
+		return nil, schema.NewConnectorError(http.StatusInternalServerError, err.Error(), nil)
+	}
+
+	content, err := extractSOAPBody(rawBody)
+	if err != nil {
+		return nil, newSOAPDecodeError(err)
+	}
+
+	if fault := parseSOAPFault(content); fault != nil {
+		return nil, fault.toConnectorError()
+	}
+
+	// one-way operations and operations without output return an empty body.
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, nil
+	}
+
+	result, err := contenttype.NewXMLDecoder(client.requests.Schema.NDCHttpSchema).
+		Decode(bytes.NewReader(content), resultType)
+	if err != nil {
+		return nil, newSOAPDecodeError(err)
+	}
+
+	return result, nil
+}
+
+// evalSOAPFault returns the connector error of the SOAP fault in the error response.
+// Servers usually respond faults with 4xx or 5xx status codes.
+func (client *HTTPClient) evalSOAPFault(rawBody []byte) *schema.ConnectorError {
+	rawRequest := client.requests.Operation.Request
+	if rawRequest == nil || rawRequest.SOAP == nil {
+		return nil
+	}
+
+	content, err := extractSOAPBody(rawBody)
+	if err != nil {
+		return nil
+	}
+
+	fault := parseSOAPFault(content)
+	if fault == nil {
+		return nil
+	}
+
+	return fault.toConnectorError()
+}
+
+// extractSOAPBody returns the inner XML of the body element in the SOAP envelope.
+func extractSOAPBody(rawBody []byte) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(rawBody))
+	depth := 0
+	start := int64(-1)
+
+	for {
+		offset := decoder.InputOffset()
+
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("the SOAP body does not exist")
+			}
+
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+
+			if depth == 1 && t.Name.Local != "Envelope" {
+				return nil, errors.New("the response is not a SOAP envelope")
+			}
+
+			if depth == 2 && t.Name.Local == "Body" {
+				start = decoder.InputOffset()
+			}
+		case xml.EndElement:
+			if depth == 2 && start >= 0 {
+				return rawBody[start:offset], nil
+			}
+
+			depth--
+		}
+	}
+}
+
+// parseSOAPFault decodes the fault if it is the first element of the body content.
+func parseSOAPFault(content []byte) *soapFault {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if start.Name.Local != "Fault" {
+			return nil
+		}
+
+		var fault soapFault
+		if err := decoder.DecodeElement(&fault, &start); err != nil {
+			return nil
+		}
+
+		return &fault
+	}
+}
+
+func (sf soapFault) toConnectorError() *schema.ConnectorError {
+	code := sf.FaultCode
+	if code == "" {
+		code = sf.Code.Value
+	}
+
+	message := sf.FaultString
+	if message == "" && len(sf.Reason.Text) > 0 {
+		message = sf.Reason.Text[0]
+	}
+
+	message = strings.TrimSpace(message)
+	if message == "" {
+		message = "the SOAP request failed"
+	}
+
+	// the code is a qualified name, e.g. soap:Client or soap:Client.Authentication.
+	_, localCode, ok := strings.Cut(code, ":")
+	if !ok {
+		localCode = code
+	}
+
+	localCode, _, _ = strings.Cut(localCode, ".")
+
+	statusCode := http.StatusUnprocessableEntity
+	if soapInternalFaultCodes[localCode] {
+		statusCode = http.StatusInternalServerError
+	}
+
+	details := map[string]any{
+		"code": code,
+	}
+
+	if sf.FaultActor != "" {
+		details["actor"] = sf.FaultActor
+	}
+
+	detail := sf.Detail11
+	if detail == nil {
+		detail = sf.Detail12
+	}
+
+	if detail != nil && len(bytes.TrimSpace(detail.Content)) > 0 {
+		detailXML := append(append([]byte("<detail>"), detail.Content...), []byte("</detail>")...)
+		if data, err := contenttype.DecodeArbitraryXML(bytes.NewReader(detailXML)); err == nil {
+			details["detail"] = data
+		}
+	}
+
+	return schema.NewConnectorError(statusCode, message, details)
+}
+
+func newSOAPDecodeError(err error) *schema.ConnectorError {
+	return schema.NewConnectorError(
+		http.StatusInternalServerError,
+		"failed to decode the SOAP response",
+		map[string]any{
+			"cause": err.Error(),
+		},
+	)
+}
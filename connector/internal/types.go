@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"regexp"
 
 	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
 	"github.com/hasura/ndc-sdk-go/v2/schema"
@@ -20,8 +19,12 @@ const (
 var errRequestBodyRequired = errors.New("request body is required")
 
 var (
-	sensitiveHeaderRegex  = regexp.MustCompile(`auth|key|secret|token`)
-	urlAndHeaderLocations = []rest.ParameterLocation{rest.InPath, rest.InQuery, rest.InHeader}
+	urlAndHeaderLocations = []rest.ParameterLocation{
+		rest.InPath,
+		rest.InQuery,
+		rest.InHeader,
+		rest.InCookie,
+	}
 )
 
 // HTTPOptions represent execution options for HTTP requests.
@@ -2,12 +2,17 @@ package internal
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/hasura/goenvconf"
 	"github.com/hasura/ndc-http/connector/internal/argument"
@@ -27,26 +32,47 @@ import (
 type UpstreamManager struct {
 	config          *configuration.Configuration
 	defaultClient   *http.Client
-	upstreams       map[string]UpstreamSetting
 	RuntimeSettings configuration.RuntimeSettings
+	// the client of sendHttpRequest requests that don't belong to any upstream.
+	rawClient *http.Client
+
+	// upstream settings are replaced as a whole when secret or TLS files change,
+	// so in-flight requests keep using credentials and clients of the old settings.
+	lock      sync.RWMutex
+	upstreams map[string]UpstreamSetting
+	sources   map[string]upstreamSource
 }
 
 // NewUpstreamManager creates a new UpstreamManager instance.
 func NewUpstreamManager(
 	httpClient *http.Client,
 	config *configuration.Configuration,
+	logger *slog.Logger,
 ) (*UpstreamManager, error) {
 	runtimeSettings, err := config.Runtime.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	return &UpstreamManager{
+	um := &UpstreamManager{
 		config:          config,
 		defaultClient:   httpClient,
+		rawClient:       httpClient,
 		upstreams:       make(map[string]UpstreamSetting),
+		sources:         make(map[string]upstreamSource),
 		RuntimeSettings: *runtimeSettings,
-	}, nil
+	}
+
+	if runtimeSettings.EgressPolicy != nil {
+		um.rawClient, err = um.newHTTPClient(httpClientConfig{
+			egressPolicy: runtimeSettings.EgressPolicy,
+		}, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return um, nil
 }
 
 // Register evaluates and registers an upstream from config.
@@ -55,28 +81,48 @@ func (um *UpstreamManager) Register(
 	runtimeSchema *configuration.NDCHttpRuntimeSchema,
 	ndcSchema *rest.NDCHttpSchema,
 ) error {
-	logger := connector.GetLogger(ctx)
-	namespace := runtimeSchema.Name
-	httpClient := um.defaultClient
+	settings, err := um.buildUpstreamSetting(ctx, runtimeSchema, ndcSchema)
+	if err != nil {
+		return err
+	}
 
-	if runtimeSchema.Settings.TLS != nil {
-		tlsClient, err := security.NewHTTPClientTLS(httpClient, runtimeSchema.Settings.TLS, logger)
-		if err != nil {
-			return fmt.Errorf("%s: %w", namespace, err)
-		}
+	um.lock.Lock()
+	defer um.lock.Unlock()
 
-		if tlsClient != nil {
-			transport := tlsClient.Transport
-			tlsClient.Transport = exhttp.NewTelemetryTransport(
-				transport,
-				exhttp.TelemetryConfig{
-					Logger:     logger,
-					Attributes: []attribute.KeyValue{attribute.String("db.system", "http")},
-				},
-			)
+	um.upstreams[runtimeSchema.Name] = *settings
+	um.sources[runtimeSchema.Name] = upstreamSource{
+		runtimeSchema: *runtimeSchema,
+		ndcSchema:     ndcSchema,
+		files:         getUpstreamWatchedFiles(runtimeSchema),
+	}
 
-			httpClient = tlsClient
-		}
+	return nil
+}
+
+// getUpstream gets the current settings of the upstream.
+func (um *UpstreamManager) getUpstream(namespace string) (UpstreamSetting, bool) {
+	um.lock.RLock()
+	defer um.lock.RUnlock()
+
+	settings, ok := um.upstreams[namespace]
+
+	return settings, ok
+}
+
+func (um *UpstreamManager) buildUpstreamSetting(
+	ctx context.Context,
+	runtimeSchema *configuration.NDCHttpRuntimeSchema,
+	ndcSchema *rest.NDCHttpSchema,
+) (*UpstreamSetting, error) {
+	logger := connector.GetLogger(ctx)
+	namespace := runtimeSchema.Name
+	httpClient, err := um.newHTTPClient(httpClientConfig{
+		transport: runtimeSchema.Settings.Transport,
+		tls:       runtimeSchema.Settings.TLS,
+		proxy:     runtimeSchema.Settings.Proxy,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", namespace, err)
 	}
 
 	var defaultServerURL *url.URL
@@ -96,7 +142,7 @@ func (um *UpstreamManager) Register(
 			true,
 		)
 		if err != nil {
-			return fmt.Errorf("%s: %w", namespace, err)
+			return nil, fmt.Errorf("%s: %w", namespace, err)
 		}
 
 		settings.argumentPresets = argumentPresets
@@ -119,20 +165,36 @@ func (um *UpstreamManager) Register(
 			continue
 		}
 
-		if defaultServerURL == nil {
-			defaultServerURL = serverURL
-		}
-
 		serverClient := httpClient
 
-		if server.TLS != nil {
-			tlsClient, err := security.NewHTTPClientTLS(um.defaultClient, server.TLS, logger)
+		var socketPath string
+
+		// requests of Unix socket servers are sent to the virtual localhost via the socket.
+		if serverURL.Scheme == exhttp.UnixSocketScheme {
+			socketPath, serverURL, err = exhttp.ParseUnixSocketURL(serverURL)
 			if err != nil {
-				return fmt.Errorf("%s.server[%s]: %w", namespace, serverID, err)
+				logger.Error(
+					fmt.Sprintf("failed to register server %s:%s, %s", namespace, serverID, err),
+				)
+
+				continue
 			}
+		}
+
+		if defaultServerURL == nil {
+			defaultServerURL = serverURL
+		}
 
-			if tlsClient != nil {
-				serverClient = tlsClient
+		// the server inherits the transport, TLS and proxy settings of the upstream if they are empty.
+		if socketPath != "" || server.Transport != nil || server.TLS != nil || server.Proxy != nil {
+			serverClient, err = um.newHTTPClient(httpClientConfig{
+				transport:  cmp.Or(server.Transport, runtimeSchema.Settings.Transport),
+				tls:        cmp.Or(server.TLS, runtimeSchema.Settings.TLS),
+				proxy:      cmp.Or(server.Proxy, runtimeSchema.Settings.Proxy),
+				unixSocket: socketPath,
+			}, logger)
+			if err != nil {
+				return nil, fmt.Errorf("%s.server[%s]: %w", namespace, serverID, err)
 			}
 		}
 
@@ -150,7 +212,8 @@ func (um *UpstreamManager) Register(
 					slog.String("server_id", serverID),
 				),
 			),
-			HTTPClient: serverClient,
+			CompositeClients: security.NewCompositeClients(),
+			HTTPClient:       serverClient,
 		}
 
 		if len(server.ArgumentPresets) > 0 {
@@ -160,7 +223,7 @@ func (um *UpstreamManager) Register(
 				false,
 			)
 			if err != nil {
-				return fmt.Errorf("%s.server[%s]: %w", namespace, serverID, err)
+				return nil, fmt.Errorf("%s.server[%s]: %w", namespace, serverID, err)
 			}
 
 			newServer.ArgumentPresets = argumentPresets
@@ -176,10 +239,83 @@ func (um *UpstreamManager) Register(
 		runtimeSchema.Settings.SecuritySchemes,
 		logger.With(slog.String("namespace", namespace)),
 	)
+	settings.compositeClients = security.NewCompositeClients()
 
-	um.upstreams[namespace] = settings
+	return &settings, nil
+}
 
-	return nil
+// httpClientConfig holds settings of an HTTP client of the upstream or a server.
+type httpClientConfig struct {
+	transport *exhttp.HTTPTransportConfig
+	tls       *exhttp.TLSConfig
+	proxy     *exhttp.ProxyConfig
+	// the path of the Unix domain socket that the client dials.
+	unixSocket string
+	// restricts destinations of requests. The proxy is disabled if the policy is set.
+	egressPolicy *exhttp.EgressPolicy
+}
+
+// newHTTPClient creates an HTTP client with transport, TLS, proxy and Unix socket settings.
+// Returns the default client if all settings are empty.
+func (um *UpstreamManager) newHTTPClient(
+	config httpClientConfig,
+	logger *slog.Logger,
+) (*http.Client, error) {
+	if config.transport == nil && config.tls == nil && config.proxy == nil && config.unixSocket == "" &&
+		config.egressPolicy == nil {
+		return um.defaultClient, nil
+	}
+
+	httpClient := um.defaultClient
+
+	// TLS and proxy transports clone the tuned transport so connection pool and timeout settings are kept.
+	if config.transport != nil {
+		httpClient = security.NewHTTPClientTransport(httpClient, config.transport)
+	}
+
+	if config.tls != nil {
+		tlsClient, err := security.NewHTTPClientTLS(httpClient, config.tls, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		httpClient = tlsClient
+	}
+
+	if config.proxy != nil {
+		proxyClient, err := security.NewHTTPClientProxy(httpClient, config.proxy)
+		if err != nil {
+			return nil, err
+		}
+
+		httpClient = proxyClient
+	}
+
+	if config.egressPolicy != nil {
+		httpClient = security.NewHTTPClientEgress(httpClient, config.egressPolicy)
+	}
+
+	attributes := []attribute.KeyValue{attribute.String("db.system", "http")}
+
+	// the socket transport replaces the proxy because connections never leave the host.
+	if config.unixSocket != "" {
+		httpClient = security.NewHTTPClientUnixSocket(httpClient, config.unixSocket)
+		attributes = append(
+			attributes,
+			attribute.String("network.transport", "unix"),
+			attribute.String("network.peer.address", config.unixSocket),
+		)
+	}
+
+	httpClient.Transport = exhttp.NewTelemetryTransport(
+		httpClient.Transport,
+		exhttp.TelemetryConfig{
+			Logger:     logger,
+			Attributes: attributes,
+		},
+	)
+
+	return httpClient, nil
 }
 
 // CreateHTTPClient create an HTTP client with requests.
@@ -221,6 +357,9 @@ func (um *UpstreamManager) ExecuteRequest(
 		request.Body = buf.Bytes()
 	}
 
+	// the telemetry transport masks sensitive data with redaction rules in the context.
+	ctx = exhttp.ContextWithRedactor(ctx, um.RuntimeSettings.Redactor)
+
 	req, cancel, err := request.CreateRequest(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -236,11 +375,31 @@ func (um *UpstreamManager) ExecuteRequest(
 	req.Header.Set("User-Agent", "ndc-http/"+version.BuildVersion)
 	// merge headers from the request-level arguments with the highest priority
 	for key, value := range requestArguments.Headers {
+		if strings.EqualFold(key, rest.CookieHeader) {
+			MergeCookieHeader(req.Header, value)
+
+			continue
+		}
+
 		req.Header.Set(key, value)
 	}
 
 	middlewares := []exhttp.Middleware{}
 
+	// limits of the operation and file settings take precedence over global limits.
+	// The decompressed limit defaults to the response limit if it isn't set.
+	maxResponseBytes := cmp.Or(request.Runtime.MaxResponseBytes, um.RuntimeSettings.MaxResponseBytes)
+	maxDecompressedBytes := cmp.Or(
+		request.Runtime.MaxDecompressedBytes,
+		um.RuntimeSettings.MaxDecompressedBytes,
+		maxResponseBytes,
+	)
+
+	// the size limit is applied before retries so error bodies of retried responses are also limited.
+	if maxResponseBytes > 0 {
+		middlewares = append(middlewares, exhttp.NewResponseLimitMiddleware(maxResponseBytes))
+	}
+
 	if request.Runtime.Retry.Times > 0 {
 		middlewares = append(middlewares, exhttp.NewRetryMiddleware(request.Runtime.Retry))
 	}
@@ -249,6 +408,12 @@ func (um *UpstreamManager) ExecuteRequest(
 
 	resp, err := clientWrapper.Do(req)
 
+	// the body is decompressed while streaming, so the limit is checked on the decompressed reader.
+	// The wrapper also records limit errors of the compressed body.
+	if resp != nil && resp.Body != nil && maxDecompressedBytes > 0 {
+		resp.Body = exhttp.NewLimitedReadCloser(resp.Body, maxDecompressedBytes, true)
+	}
+
 	return resp, cancel, err
 }
 
@@ -260,9 +425,9 @@ func (um *UpstreamManager) evalRequestSettings(
 ) (*http.Client, error) {
 	httpClient := um.defaultClient
 
-	settings, ok := um.upstreams[namespace]
+	settings, ok := um.getUpstream(namespace)
 	if !ok {
-		return um.defaultClient, nil
+		return um.rawClient, nil
 	}
 
 	if settings.httpClient != nil {
@@ -294,7 +459,13 @@ func (um *UpstreamManager) evalRequestSettings(
 		}
 
 		if len(server.Credentials) > 0 {
-			hc, securityName, err := um.evalSecuritySchemes(req, securities, server.Credentials)
+			hc, securityName, err := um.evalSecuritySchemes(
+				req,
+				securities,
+				server.Credentials,
+				server.CompositeClients,
+				server.HTTPClient,
+			)
 			if err != nil {
 				logger.Error(
 					fmt.Sprintf("failed to evaluate the authentication: %s", err),
@@ -303,16 +474,26 @@ func (um *UpstreamManager) evalRequestSettings(
 				)
 			}
 
-			if hc != nil {
+			if securityName != "" {
 				span.SetAttributes(attribute.String("security.key", securityName))
 
+				if hc == nil {
+					hc = httpClient
+				}
+
 				return hc, nil
 			}
 		}
 	}
 
 	if len(settings.credentials) > 0 {
-		hc, securityName, err := um.evalSecuritySchemes(req, securities, settings.credentials)
+		hc, securityName, err := um.evalSecuritySchemes(
+			req,
+			securities,
+			settings.credentials,
+			settings.compositeClients,
+			settings.httpClient,
+		)
 		if err != nil {
 			logger.Error(
 				fmt.Sprintf("failed to evaluate the authentication: %s", err),
@@ -322,9 +503,13 @@ func (um *UpstreamManager) evalRequestSettings(
 			return nil, err
 		}
 
-		if hc != nil {
+		if securityName != "" {
 			span.SetAttributes(attribute.String("security.key", securityName))
 
+			if hc == nil {
+				hc = httpClient
+			}
+
 			return hc, nil
 		}
 	}
@@ -332,32 +517,36 @@ func (um *UpstreamManager) evalRequestSettings(
 	return httpClient, nil
 }
 
+// evalSecuritySchemes injects credentials of the first security requirement that is satisfied.
+// Following the OpenAPI specification, schemes within a security requirement are ANDed
+// and alternative security requirements are ORed.
+// Returns the HTTP client and the comma-separated names of applied security schemes.
+// The client is nil if the requirement is satisfied by transport-level credentials only, e.g. mutual TLS.
 func (um *UpstreamManager) evalSecuritySchemes(
 	req *http.Request,
 	securities rest.AuthSecurities,
 	credentials map[string]security.Credential,
+	compositeClients *security.CompositeClients,
+	baseClient *http.Client,
 ) (*http.Client, string, error) {
 	// find the security that is required in the operation.
-	for _, security := range securities {
-		securityName := security.Name()
-
-		sc, ok := credentials[securityName]
-		if !ok {
-			continue
-		}
-
-		hasAuth, err := sc.Inject(req)
+	for _, requirement := range securities {
+		creds, ok, err := injectSecurityRequirement(req, requirement, credentials, false)
 		if err != nil {
-			return nil, securityName, err
+			return nil, "", err
 		}
 
-		if hasAuth {
-			return sc.GetClient(), securityName, nil
+		if ok {
+			names := strings.Join(requirement.Names(), ",")
+
+			return selectSecurityClient(creds, names, compositeClients, baseClient), names, nil
 		}
 	}
 
-	// fallback to the first working credential.
-	for name, cred := range credentials {
+	// fallback to the first working credential in the order of names.
+	for _, name := range slices.Sorted(maps.Keys(credentials)) {
+		cred := credentials[name]
+
 		hasAuth, err := cred.Inject(req)
 		if err != nil {
 			continue
@@ -371,15 +560,116 @@ func (um *UpstreamManager) evalSecuritySchemes(
 	return nil, "", nil
 }
 
+// injectSecurityRequirement injects all credentials of the security requirement into the request.
+// The request isn't modified unless every security scheme of the requirement is satisfied.
+func injectSecurityRequirement(
+	req *http.Request,
+	requirement rest.AuthSecurity,
+	credentials map[string]security.Credential,
+	mock bool,
+) ([]security.Credential, bool, error) {
+	if requirement.IsOptional() {
+		return nil, false, nil
+	}
+
+	names := requirement.Names()
+	creds := make([]security.Credential, len(names))
+
+	for i, name := range names {
+		cred, ok := credentials[name]
+		if !ok {
+			return nil, false, nil
+		}
+
+		creds[i] = cred
+	}
+
+	target := req.Clone(req.Context())
+
+	for i, cred := range creds {
+		// the client certificate is applied by the TLS transport.
+		if _, ok := cred.(*security.MutualTLSCredential); ok {
+			continue
+		}
+
+		if mock {
+			if !cred.InjectMock(target) {
+				return nil, false, nil
+			}
+
+			continue
+		}
+
+		var hasAuth bool
+
+		var err error
+
+		if sc, ok := cred.(security.ScopedCredential); ok {
+			hasAuth, err = sc.InjectWithScopes(target, requirement[names[i]])
+		} else {
+			hasAuth, err = cred.Inject(target)
+		}
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !hasAuth {
+			return nil, false, nil
+		}
+	}
+
+	req.Header = target.Header
+	req.URL = target.URL
+
+	return creds, true, nil
+}
+
+// selectSecurityClient selects the HTTP client of combined credentials.
+// Credentials that authenticate in the transport, e.g. digest or token endpoint, take precedence over the base client.
+// If many of them are combined their transports are chained into a composite client of the requirement.
+func selectSecurityClient(
+	creds []security.Credential,
+	key string,
+	compositeClients *security.CompositeClients,
+	baseClient *http.Client,
+) *http.Client {
+	var result *http.Client
+
+	var transportCreds []security.TransportCredential
+
+	for _, cred := range creds {
+		if _, ok := cred.(*security.MutualTLSCredential); ok {
+			continue
+		}
+
+		if tc, ok := cred.(security.TransportCredential); ok {
+			transportCreds = append(transportCreds, tc)
+		} else if result == nil {
+			result = cred.GetClient()
+		}
+	}
+
+	switch {
+	case len(transportCreds) == 1:
+		return transportCreds[0].GetClient()
+	case len(transportCreds) > 1:
+		return compositeClients.Get(key, baseClient, transportCreds)
+	default:
+		return result
+	}
+}
+
 // InjectMockRequestSettings injects mock credential into the request for explain APIs.
+// Returns the comma-separated names of applied security schemes.
 func (um *UpstreamManager) InjectMockRequestSettings(
 	req *http.Request,
 	namespace string,
 	securities rest.AuthSecurities,
-) {
-	settings, ok := um.upstreams[namespace]
+) string {
+	settings, ok := um.getUpstream(namespace)
 	if !ok {
-		return
+		return ""
 	}
 
 	for key, header := range settings.headers {
@@ -391,33 +681,25 @@ func (um *UpstreamManager) InjectMockRequestSettings(
 	}
 
 	if len(settings.credentials) == 0 {
-		return
+		return ""
 	}
 
 	// find the security that is required in the operation.
-	for _, security := range securities {
-		sc, ok := settings.credentials[security.Name()]
-		if !ok {
-			continue
-		}
-
-		hasAuth := sc.InjectMock(req)
-		if hasAuth {
-			return
+	for _, requirement := range securities {
+		_, ok, _ := injectSecurityRequirement(req, requirement, settings.credentials, true)
+		if ok {
+			return strings.Join(requirement.Names(), ",")
 		}
 	}
 
-	// fallback to the first working credential.
-	for _, cred := range settings.credentials {
-		hasAuth, err := cred.Inject(req)
-		if err != nil {
-			continue
-		}
-
-		if hasAuth {
-			return
+	// fallback to the first working credential in the order of names.
+	for _, name := range slices.Sorted(maps.Keys(settings.credentials)) {
+		if settings.credentials[name].InjectMock(req) {
+			return name
 		}
 	}
+
+	return ""
 }
 
 func (um *UpstreamManager) getHeadersFromEnv(
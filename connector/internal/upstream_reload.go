@@ -0,0 +1,238 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/hasura/ndc-http/connector/internal/security"
+	"github.com/hasura/ndc-http/exhttp"
+	"github.com/hasura/ndc-http/ndc-http-schema/configuration"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-sdk-go/v2/connector"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// upstreamSource holds the schema of a registered upstream to rebuild settings when watched files change.
+type upstreamSource struct {
+	runtimeSchema configuration.NDCHttpRuntimeSchema
+	ndcSchema     *rest.NDCHttpSchema
+	// paths of secret and TLS files that the upstream settings are built from.
+	files []string
+}
+
+// WatchFiles polls secret and TLS files of registered upstreams in the background
+// and rebuilds settings of the upstream whose files change.
+// The watcher stops when the context is canceled.
+func (um *UpstreamManager) WatchFiles(ctx context.Context, tracer trace.Tracer, interval time.Duration) {
+	um.lock.RLock()
+	fingerprints := make(map[string]map[string]string)
+
+	for namespace, source := range um.sources {
+		if len(source.files) > 0 {
+			fingerprints[namespace] = GetFileFingerprints(source.files)
+		}
+	}
+	um.lock.RUnlock()
+
+	if len(fingerprints) == 0 || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for namespace, previous := range fingerprints {
+					if current, ok := um.reloadChangedFiles(ctx, tracer, namespace, previous); ok {
+						fingerprints[namespace] = current
+					}
+				}
+			}
+		}
+	}()
+}
+
+// reloadChangedFiles rebuilds the upstream if any watched file changes.
+// Returns new fingerprints if the upstream is reloaded or the files are unchanged.
+func (um *UpstreamManager) reloadChangedFiles(
+	ctx context.Context,
+	tracer trace.Tracer,
+	namespace string,
+	previous map[string]string,
+) (map[string]string, bool) {
+	um.lock.RLock()
+	source, ok := um.sources[namespace]
+	um.lock.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	current := GetFileFingerprints(source.files)
+
+	var changedFiles []string
+
+	for _, filePath := range source.files {
+		if current[filePath] == previous[filePath] {
+			continue
+		}
+
+		// the file may be missing in the middle of rotation. Wait for the next tick.
+		if current[filePath] == "" {
+			return nil, false
+		}
+
+		changedFiles = append(changedFiles, filePath)
+	}
+
+	if len(changedFiles) == 0 {
+		return nil, false
+	}
+
+	if err := um.reload(ctx, tracer, namespace, source, changedFiles); err != nil {
+		return nil, false
+	}
+
+	return current, true
+}
+
+// reload rebuilds settings of the upstream and replaces the current settings atomically.
+func (um *UpstreamManager) reload(
+	ctx context.Context,
+	tracer trace.Tracer,
+	namespace string,
+	source upstreamSource,
+	changedFiles []string,
+) error {
+	ctx, span := tracer.Start(ctx, "Reload upstream")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("namespace", namespace),
+		attribute.StringSlice("files", changedFiles),
+	)
+
+	logger := connector.GetLogger(ctx).With(
+		slog.String("namespace", namespace),
+		slog.Any("files", changedFiles),
+	)
+
+	settings, err := um.buildUpstreamSetting(ctx, &source.runtimeSchema, source.ndcSchema)
+	if err != nil {
+		logger.Error("failed to reload upstream settings: " + err.Error())
+		span.SetStatus(codes.Error, "failed to reload upstream settings")
+		span.RecordError(err)
+
+		return err
+	}
+
+	um.lock.Lock()
+	oldSettings := um.upstreams[namespace]
+	um.upstreams[namespace] = *settings
+	um.lock.Unlock()
+
+	um.closeIdleConnections(oldSettings)
+	logger.Info("reloaded upstream settings")
+
+	return nil
+}
+
+// CloseIdleConnections closes idle connections of TLS clients of all upstreams.
+// It is called when the upstream manager is replaced by the configuration reload.
+func (um *UpstreamManager) CloseIdleConnections() {
+	um.lock.RLock()
+	defer um.lock.RUnlock()
+
+	for _, settings := range um.upstreams {
+		um.closeIdleConnections(settings)
+	}
+
+	if um.rawClient != um.defaultClient {
+		um.rawClient.CloseIdleConnections()
+	}
+}
+
+// closeIdleConnections closes idle connections of replaced TLS clients.
+// Connections in use by in-flight requests aren't interrupted.
+func (um *UpstreamManager) closeIdleConnections(settings UpstreamSetting) {
+	clients := []*http.Client{settings.httpClient}
+	for _, server := range settings.servers {
+		clients = append(clients, server.HTTPClient)
+	}
+
+	for _, client := range clients {
+		if client != nil && client != um.defaultClient {
+			client.CloseIdleConnections()
+		}
+	}
+}
+
+// getUpstreamWatchedFiles collects paths of secret and TLS files of the upstream.
+func getUpstreamWatchedFiles(runtimeSchema *configuration.NDCHttpRuntimeSchema) []string {
+	if runtimeSchema.NDCHttpSchema == nil || runtimeSchema.Settings == nil {
+		return nil
+	}
+
+	files := map[string]bool{}
+	addTLSFiles := func(tlsConfig *exhttp.TLSConfig) {
+		if tlsConfig == nil {
+			return
+		}
+
+		filePaths, _ := tlsConfig.GetFilePaths()
+		for _, filePath := range filePaths {
+			files[filePath] = true
+		}
+	}
+
+	addSecretFiles := func(securitySchemes map[string]rest.SecurityScheme) {
+		for _, ss := range securitySchemes {
+			for _, filePath := range security.GetSecretFilePaths(ss) {
+				files[filePath] = true
+			}
+		}
+	}
+
+	addTLSFiles(runtimeSchema.Settings.TLS)
+	addSecretFiles(runtimeSchema.Settings.SecuritySchemes)
+
+	for _, server := range runtimeSchema.Settings.Servers {
+		addTLSFiles(server.TLS)
+		addSecretFiles(server.SecuritySchemes)
+	}
+
+	return slices.Sorted(maps.Keys(files))
+}
+
+// GetFileFingerprints computes checksums of files. The checksum is empty if the file can't be read.
+func GetFileFingerprints(filePaths []string) map[string]string {
+	results := make(map[string]string)
+
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			results[filePath] = ""
+
+			continue
+		}
+
+		checksum := sha256.Sum256(content)
+		results[filePath] = hex.EncodeToString(checksum[:])
+	}
+
+	return results
+}
@@ -26,6 +26,7 @@ func (um *UpstreamManager) BuildRequests(
 	operationName string,
 	operation *rest.OperationInfo,
 	rawArgs map[string]any,
+	fields schema.NestedField,
 ) (*RequestBuilderResults, error) {
 	// 1. parse http options from arguments
 	httpOptions, err := um.parseHTTPOptionsFromArguments(operation.Arguments, rawArgs)
@@ -35,7 +36,7 @@ func (um *UpstreamManager) BuildRequests(
 		})
 	}
 
-	upstream, ok := um.upstreams[runtimeSchema.Name]
+	upstream, ok := um.getUpstream(runtimeSchema.Name)
 	if !ok {
 		return nil, schema.InternalServerError(
 			fmt.Sprintf("upstream with namespace %s does not exist", runtimeSchema.Name),
@@ -79,6 +80,7 @@ func (um *UpstreamManager) BuildRequests(
 			runtimeSchema.NDCHttpSchema,
 			operation,
 			rawArgs,
+			fields,
 			runtimeSchema.Runtime,
 			um.RuntimeSettings,
 		).Build()
@@ -99,6 +101,7 @@ func (um *UpstreamManager) BuildRequests(
 			operationName,
 			operation,
 			rawArgs,
+			fields,
 			headers,
 			httpOptions.Servers,
 		)
@@ -122,6 +125,7 @@ func (um *UpstreamManager) BuildRequests(
 			operationName,
 			operation,
 			rawArgs,
+			fields,
 			headers,
 			[]string{serverID},
 		)
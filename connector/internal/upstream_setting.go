@@ -12,27 +12,32 @@ import (
 	"github.com/hasura/ndc-http/connector/internal/security"
 	"github.com/hasura/ndc-http/ndc-http-schema/configuration"
 	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
 )
 
 // Server contains server settings.
 type Server struct {
-	URL             *url.URL
-	Headers         map[string]string
-	Credentials     map[string]security.Credential
-	ArgumentPresets *argument.ArgumentPresets
-	Security        rest.AuthSecurities
-	HTTPClient      *http.Client
+	URL         *url.URL
+	Headers     map[string]string
+	Credentials map[string]security.Credential
+	// clients which chain transports of credentials that are required together.
+	CompositeClients *security.CompositeClients
+	ArgumentPresets  *argument.ArgumentPresets
+	Security         rest.AuthSecurities
+	HTTPClient       *http.Client
 }
 
 // UpstreamSetting represents a setting for upstream servers.
 type UpstreamSetting struct {
-	httpClient      *http.Client
-	servers         map[string]Server
-	headers         map[string]string
-	security        rest.AuthSecurities
-	credentials     map[string]security.Credential
-	argumentPresets *argument.ArgumentPresets
-	runtime         configuration.RuntimeSettings
+	httpClient  *http.Client
+	servers     map[string]Server
+	headers     map[string]string
+	security    rest.AuthSecurities
+	credentials map[string]security.Credential
+	// clients which chain transports of credentials that are required together.
+	compositeClients *security.CompositeClients
+	argumentPresets  *argument.ArgumentPresets
+	runtime          configuration.RuntimeSettings
 }
 
 func (us *UpstreamSetting) buildRequest(
@@ -40,6 +45,7 @@ func (us *UpstreamSetting) buildRequest(
 	operationName string,
 	operation *rest.OperationInfo,
 	arguments map[string]any,
+	fields schema.NestedField,
 	headers map[string]string,
 	servers []string,
 ) (*RetryableRequest, error) {
@@ -60,6 +66,7 @@ func (us *UpstreamSetting) buildRequest(
 		runtimeSchema.NDCHttpSchema,
 		operation,
 		arguments,
+		fields,
 		runtimeSchema.Runtime,
 		us.runtime,
 	).Build()
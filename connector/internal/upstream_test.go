@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+	"github.com/hasura/ndc-http/connector/internal/security"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"gotest.tools/v3/assert"
+)
+
+func TestEvalSecuritySchemesFallback(t *testing.T) {
+	emptyDigest, err := security.NewDigestCredential(
+		http.DefaultClient,
+		rest.NewDigestAuthConfig(goenvconf.NewEnvStringValue(""), goenvconf.NewEnvStringValue("")),
+	)
+	assert.NilError(t, err)
+
+	apiKey, err := security.NewApiKeyCredential(
+		http.DefaultClient,
+		rest.NewAPIKeyAuthConfig("api_key", rest.APIKeyInHeader, goenvconf.NewEnvStringValue("secret")),
+	)
+	assert.NilError(t, err)
+
+	ntlm, err := security.NewNTLMCredential(
+		&http.Client{},
+		rest.NewNTLMAuthConfig(goenvconf.NewEnvStringValue("user"), goenvconf.NewEnvStringValue("pass")),
+	)
+	assert.NilError(t, err)
+
+	credentials := map[string]security.Credential{
+		"a_digest":  emptyDigest,
+		"b_api_key": apiKey,
+		"c_ntlm":    ntlm,
+	}
+
+	um := &UpstreamManager{
+		upstreams: map[string]UpstreamSetting{"": {credentials: credentials}},
+	}
+
+	for range 10 {
+		req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		assert.NilError(t, err)
+
+		client, name, err := um.evalSecuritySchemes(
+			req,
+			nil,
+			credentials,
+			security.NewCompositeClients(),
+			http.DefaultClient,
+		)
+		assert.NilError(t, err)
+		assert.Equal(t, "b_api_key", name)
+		assert.Equal(t, http.DefaultClient, client)
+		assert.Equal(t, "secret", req.Header.Get("api_key"))
+
+		mockReq, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		assert.NilError(t, err)
+
+		assert.Equal(t, "b_api_key", um.InjectMockRequestSettings(mockReq, "", nil))
+	}
+}
+
+type testLayerCredential struct {
+	security.NoopCredential
+
+	name string
+}
+
+func (tlc testLayerCredential) Inject(req *http.Request) (bool, error) {
+	return true, nil
+}
+
+func (tlc testLayerCredential) WrapTransport(transport http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Add("X-Layers", tlc.name)
+
+		return transport.RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestEvalSecuritySchemesComposite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Join(r.Header.Values("X-Layers"), ",")))
+	}))
+	defer server.Close()
+
+	credentials := map[string]security.Credential{
+		"api_key": testLayerCredential{name: "api_key"},
+		"digest":  testLayerCredential{name: "digest"},
+		"token":   testLayerCredential{name: "token"},
+	}
+	compositeClients := security.NewCompositeClients()
+	um := &UpstreamManager{}
+	requirement := rest.AuthSecurities{
+		{"api_key": []string{}, "digest": []string{}, "token": []string{}},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NilError(t, err)
+
+	client, name, err := um.evalSecuritySchemes(req, requirement, credentials, compositeClients, http.DefaultClient)
+	assert.NilError(t, err)
+	assert.Equal(t, "api_key,digest,token", name)
+
+	resp, err := client.Do(req)
+	assert.NilError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NilError(t, err)
+	assert.Equal(t, "api_key,digest,token", string(body))
+
+	req, err = http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NilError(t, err)
+
+	nextClient, _, err := um.evalSecuritySchemes(req, requirement, credentials, compositeClients, http.DefaultClient)
+	assert.NilError(t, err)
+	assert.Equal(t, client, nextClient)
+}
@@ -5,13 +5,35 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/hasura/ndc-http/connector/internal/contenttype"
 	"github.com/hasura/ndc-http/exhttp"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	restUtils "github.com/hasura/ndc-http/ndc-http-schema/utils"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// IsSensitiveHeader checks if the header name is sensitive.
-func IsSensitiveHeader(name string) bool {
-	return sensitiveHeaderRegex.MatchString(strings.ToLower(name))
+// MaskCookieHeader masks values of all cookie pairs in the Cookie header.
+func MaskCookieHeader(rawCookie string) string {
+	pairs := strings.Split(rawCookie, ";")
+
+	for i, pair := range pairs {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			pairs[i] = name
+
+			continue
+		}
+
+		pairs[i] = name + "=" + restUtils.MaskString(value)
+	}
+
+	return strings.Join(pairs, "; ")
+}
+
+// MergeCookieHeader merges the incoming Cookie header into the request.
+// Incoming cookies replace existing cookies with the same name.
+func MergeCookieHeader(header http.Header, rawCookie string) {
+	header.Set(rest.CookieHeader, contenttype.MergeCookieHeader(header.Get(rest.CookieHeader), rawCookie))
 }
 
 func WrapTelemetryTransport(baseTransport *http.Transport, logger *slog.Logger) http.RoundTripper {
@@ -35,6 +57,13 @@ func evalAcceptContentType(contentType string) string {
 
 func evalForwardedHeaders(req *RetryableRequest, headers map[string]string) {
 	for key, value := range headers {
+		// cookies of parameters take precedence over forwarded cookies with the same name.
+		if strings.EqualFold(key, rest.CookieHeader) {
+			req.Headers.Set(rest.CookieHeader, contenttype.MergeCookieHeader(value, req.Headers.Get(rest.CookieHeader)))
+
+			continue
+		}
+
 		if req.Headers.Get(key) != "" {
 			continue
 		}
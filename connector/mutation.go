@@ -19,6 +19,8 @@ func (c *HTTPConnector) Mutation(
 	state *State,
 	request *schema.MutationRequest,
 ) (*schema.MutationResponse, error) {
+	snapshot := c.snapshot.Load()
+
 	var requestArguments internal.HTTPRequestArguments
 
 	if len(request.RequestArguments) > 0 {
@@ -33,11 +35,11 @@ func (c *HTTPConnector) Mutation(
 		}
 	}
 
-	if len(request.Operations) == 1 || c.config.Concurrency.Mutation <= 1 {
-		return c.execMutationSync(ctx, state, request, requestArguments)
+	if len(request.Operations) == 1 || snapshot.config.Concurrency.Mutation <= 1 {
+		return snapshot.execMutationSync(ctx, state, request, requestArguments)
 	}
 
-	return c.execMutationAsync(ctx, state, request, requestArguments)
+	return snapshot.execMutationAsync(ctx, state, request, requestArguments)
 }
 
 // MutationExplain explains a mutation by creating an execution plan.
@@ -47,6 +49,8 @@ func (c *HTTPConnector) MutationExplain(
 	state *State,
 	request *schema.MutationRequest,
 ) (*schema.ExplainResponse, error) {
+	snapshot := c.snapshot.Load()
+
 	if len(request.Operations) == 0 {
 		return nil, schema.BadRequestError("mutation operations must not be empty", nil)
 	}
@@ -55,10 +59,14 @@ func (c *HTTPConnector) MutationExplain(
 	switch operation.Type {
 	case schema.MutationOperationProcedure:
 		if operation.Name == internal.ProcedureSendHTTPRequest {
-			return internal.NewRawRequestBuilder(operation, configuration.ForwardHeaders).Explain()
+			return internal.NewRawRequestBuilder(
+				operation,
+				snapshot.config.ForwardHeaders,
+				snapshot.upstreams.RuntimeSettings.Redactor,
+			).Explain()
 		}
 
-		requests, err := c.explainProcedure(&operation)
+		requests, err := snapshot.explainProcedure(&operation)
 		if err != nil {
 			return nil, err
 		}
@@ -77,7 +85,7 @@ func (c *HTTPConnector) MutationExplain(
 			}
 		}
 
-		return c.serializeExplainResponse(ctx, requests, requestArguments)
+		return snapshot.serializeExplainResponse(ctx, requests, requestArguments)
 	default:
 		return nil, schema.BadRequestError(
 			fmt.Sprintf("invalid operation type: %s", operation.Type),
@@ -86,10 +94,10 @@ func (c *HTTPConnector) MutationExplain(
 	}
 }
 
-func (c *HTTPConnector) explainProcedure(
+func (cs *connectorSnapshot) explainProcedure(
 	operation *schema.MutationOperation,
 ) (*internal.RequestBuilderResults, error) {
-	procedure, metadata, err := c.metadata.GetProcedure(operation.Name)
+	procedure, metadata, err := cs.metadata.GetProcedure(operation.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -101,10 +109,10 @@ func (c *HTTPConnector) explainProcedure(
 		})
 	}
 
-	return c.upstreams.BuildRequests(metadata, operation.Name, procedure, rawArgs)
+	return cs.upstreams.BuildRequests(metadata, operation.Name, procedure, rawArgs, operation.Fields)
 }
 
-func (c *HTTPConnector) execMutationSync(
+func (cs *connectorSnapshot) execMutationSync(
 	ctx context.Context,
 	state *State,
 	request *schema.MutationRequest,
@@ -113,7 +121,7 @@ func (c *HTTPConnector) execMutationSync(
 	operationResults := make([]schema.MutationOperationResults, len(request.Operations))
 
 	for i, operation := range request.Operations {
-		result, err := c.execMutationOperation(ctx, state, operation, i, requestArguments)
+		result, err := cs.execMutationOperation(ctx, state, operation, i, requestArguments)
 		if err != nil {
 			return nil, err
 		}
@@ -126,7 +134,7 @@ func (c *HTTPConnector) execMutationSync(
 	}, nil
 }
 
-func (c *HTTPConnector) execMutationAsync(
+func (cs *connectorSnapshot) execMutationAsync(
 	ctx context.Context,
 	state *State,
 	request *schema.MutationRequest,
@@ -135,12 +143,12 @@ func (c *HTTPConnector) execMutationAsync(
 	operationResults := make([]schema.MutationOperationResults, len(request.Operations))
 
 	eg, ctx := errgroup.WithContext(ctx)
-	eg.SetLimit(int(c.config.Concurrency.Mutation))
+	eg.SetLimit(int(cs.config.Concurrency.Mutation))
 
 	for i, operation := range request.Operations {
 		func(index int, op schema.MutationOperation) {
 			eg.Go(func() error {
-				result, err := c.execMutationOperation(ctx, state, op, index, requestArguments)
+				result, err := cs.execMutationOperation(ctx, state, op, index, requestArguments)
 				if err != nil {
 					return err
 				}
@@ -161,7 +169,7 @@ func (c *HTTPConnector) execMutationAsync(
 	}, nil
 }
 
-func (c *HTTPConnector) execMutationOperation(
+func (cs *connectorSnapshot) execMutationOperation(
 	parentCtx context.Context,
 	state *State,
 	operation schema.MutationOperation,
@@ -176,7 +184,7 @@ func (c *HTTPConnector) execMutationOperation(
 	var err error
 
 	if operation.Name == internal.ProcedureSendHTTPRequest {
-		if c.procSendHttpRequest == nil {
+		if cs.procSendHttpRequest == nil {
 			span.SetStatus(codes.Error, internal.ProcedureSendHTTPRequest+" mutation is disabled")
 
 			return nil, schema.InternalServerError(
@@ -185,12 +193,16 @@ func (c *HTTPConnector) execMutationOperation(
 			)
 		}
 
-		requests, err = internal.NewRawRequestBuilder(operation, c.config.ForwardHeaders).Build()
+		requests, err = internal.NewRawRequestBuilder(
+			operation,
+			cs.config.ForwardHeaders,
+			cs.upstreams.RuntimeSettings.Redactor,
+		).Build()
 		if err == nil {
-			requests.Operation = c.procSendHttpRequest
+			requests.Operation = cs.procSendHttpRequest
 		}
 	} else {
-		requests, err = c.explainProcedure(&operation)
+		requests, err = cs.explainProcedure(&operation)
 	}
 
 	if err != nil {
@@ -200,7 +212,7 @@ func (c *HTTPConnector) execMutationOperation(
 		return nil, err
 	}
 
-	client := c.upstreams.CreateHTTPClient(requests, requestArguments)
+	client := cs.upstreams.CreateHTTPClient(requests, requestArguments)
 
 	result, _, err := client.Send(ctx, operation.Fields)
 	if err != nil {
@@ -250,3 +250,62 @@ func TestDisableRawHTTPRequest(t *testing.T) {
 		})
 	})
 }
+
+func TestRawHTTPRequestEgressPolicy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/posts", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	connServer, err := connector.NewServer(NewHTTPConnector(), &connector.ServerOptions{
+		Configuration: "testdata/egress-policy",
+	}, connector.WithoutRecovery())
+	assert.NilError(t, err)
+	testServer := connServer.BuildTestServer()
+	defer testServer.Close()
+
+	for _, tc := range []struct {
+		Name     string
+		URL      string
+		Expected string
+	}{
+		{
+			Name:     "blocked_network",
+			URL:      server.URL + "/posts",
+			Expected: "egress denied: IP address 127.0.0.1 is in a blocked network",
+		},
+		{
+			Name:     "denied_host",
+			URL:      "http://169.254.169.254/latest/meta-data",
+			Expected: "egress denied: host 169.254.169.254 is not allowed",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			rawReqBody, err := json.Marshal(schema.MutationRequest{
+				CollectionRelationships: make(schema.MutationRequestCollectionRelationships),
+				Operations: []schema.MutationOperation{
+					{
+						Type:      schema.MutationOperationProcedure,
+						Name:      "sendHttpRequest",
+						Arguments: []byte(`{"method": "get", "url": "` + tc.URL + `"}`),
+					},
+				},
+			})
+			assert.NilError(t, err)
+
+			res, err := http.Post(
+				testServer.URL+"/mutation",
+				"application/json",
+				bytes.NewBuffer(rawReqBody),
+			)
+			assert.NilError(t, err)
+			assertHTTPResponse(t, res, http.StatusForbidden, schema.ErrorResponse{
+				Message: tc.Expected,
+				Details: map[string]any{},
+			})
+		})
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/hasura/ndc-http/connector/internal"
 	"github.com/hasura/ndc-http/ndc-http-schema/configuration"
-	restUtils "github.com/hasura/ndc-http/ndc-http-schema/utils"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
 	"github.com/hasura/ndc-sdk-go/v2/schema"
 	"github.com/hasura/ndc-sdk-go/v2/utils"
 	"go.opentelemetry.io/otel/codes"
@@ -21,6 +23,8 @@ func (c *HTTPConnector) Query(
 	state *State,
 	request *schema.QueryRequest,
 ) (schema.QueryResponse, error) {
+	snapshot := c.snapshot.Load()
+
 	valueField, err := utils.EvalFunctionSelectionFieldValue(request)
 	if err != nil {
 		return nil, schema.UnprocessableContentError(err.Error(), nil)
@@ -45,11 +49,25 @@ func (c *HTTPConnector) Query(
 		}
 	}
 
-	if len(requestVars) == 1 || c.config.Concurrency.Query <= 1 {
-		return c.execQuerySync(ctx, state, request, valueField, requestVars, requestArguments)
+	if len(requestVars) > 1 {
+		rowSets, err := snapshot.execJSONRPCBatchQuery(
+			ctx,
+			state,
+			request,
+			valueField,
+			requestVars,
+			requestArguments,
+		)
+		if err != nil || rowSets != nil {
+			return rowSets, err
+		}
+	}
+
+	if len(requestVars) == 1 || snapshot.config.Concurrency.Query <= 1 {
+		return snapshot.execQuerySync(ctx, state, request, valueField, requestVars, requestArguments)
 	}
 
-	return c.execQueryAsync(ctx, state, request, valueField, requestVars, requestArguments)
+	return snapshot.execQueryAsync(ctx, state, request, valueField, requestVars, requestArguments)
 }
 
 // QueryExplain explains a query by creating an execution plan.
@@ -59,12 +77,19 @@ func (c *HTTPConnector) QueryExplain(
 	state *State,
 	request *schema.QueryRequest,
 ) (*schema.ExplainResponse, error) {
+	snapshot := c.snapshot.Load()
+
 	requestVars := request.Variables
 	if len(requestVars) == 0 {
 		requestVars = []schema.QueryRequestVariablesElem{make(schema.QueryRequestVariablesElem)}
 	}
 
-	requests, err := c.explainQuery(request, requestVars[0])
+	valueField, err := utils.EvalFunctionSelectionFieldValue(request)
+	if err != nil {
+		return nil, schema.UnprocessableContentError(err.Error(), nil)
+	}
+
+	requests, err := snapshot.explainQuery(request, valueField, requestVars[0])
 	if err != nil {
 		return nil, err
 	}
@@ -83,14 +108,15 @@ func (c *HTTPConnector) QueryExplain(
 		}
 	}
 
-	return c.serializeExplainResponse(ctx, requests, requestArguments)
+	return snapshot.serializeExplainResponse(ctx, requests, requestArguments)
 }
 
-func (c *HTTPConnector) explainQuery(
+func (cs *connectorSnapshot) explainQuery(
 	request *schema.QueryRequest,
+	valueField schema.NestedField,
 	variables map[string]any,
 ) (*internal.RequestBuilderResults, error) {
-	function, metadata, err := c.metadata.GetFunction(request.Collection)
+	function, metadata, err := cs.metadata.GetFunction(request.Collection)
 	if err != nil {
 		return nil, err
 	}
@@ -105,10 +131,10 @@ func (c *HTTPConnector) explainQuery(
 		)
 	}
 
-	return c.upstreams.BuildRequests(metadata, request.Collection, function, rawArgs)
+	return cs.upstreams.BuildRequests(metadata, request.Collection, function, rawArgs, valueField)
 }
 
-func (c *HTTPConnector) execQuerySync(
+func (cs *connectorSnapshot) execQuerySync(
 	ctx context.Context,
 	state *State,
 	request *schema.QueryRequest,
@@ -119,7 +145,7 @@ func (c *HTTPConnector) execQuerySync(
 	rowSets := make([]schema.RowSet, len(requestVars))
 
 	for i, requestVar := range requestVars {
-		result, err := c.execQuery(ctx, state, request, valueField, requestVar, i, requestArguments)
+		result, err := cs.execQuery(ctx, state, request, valueField, requestVar, i, requestArguments)
 		if err != nil {
 			return nil, err
 		}
@@ -137,7 +163,7 @@ func (c *HTTPConnector) execQuerySync(
 	return rowSets, nil
 }
 
-func (c *HTTPConnector) execQueryAsync(
+func (cs *connectorSnapshot) execQueryAsync(
 	ctx context.Context,
 	state *State,
 	request *schema.QueryRequest,
@@ -148,12 +174,12 @@ func (c *HTTPConnector) execQueryAsync(
 	rowSets := make([]schema.RowSet, len(requestVars))
 
 	eg, ctx := errgroup.WithContext(ctx)
-	eg.SetLimit(int(c.config.Concurrency.Query))
+	eg.SetLimit(int(cs.config.Concurrency.Query))
 
 	for i, requestVar := range requestVars {
 		func(index int, vars schema.QueryRequestVariablesElem) {
 			eg.Go(func() error {
-				result, err := c.execQuery(
+				result, err := cs.execQuery(
 					ctx,
 					state,
 					request,
@@ -187,7 +213,84 @@ func (c *HTTPConnector) execQueryAsync(
 	return rowSets, nil
 }
 
-func (c *HTTPConnector) execQuery(
+// execJSONRPCBatchQuery sends requests of all variable sets in a JSON-RPC batch request.
+// Returns nil row sets if the function isn't a JSON-RPC method or requests can't be batched,
+// e.g. they are distributed to many servers or sent to different URLs.
+func (cs *connectorSnapshot) execJSONRPCBatchQuery(
+	ctx context.Context,
+	state *State,
+	request *schema.QueryRequest,
+	valueField schema.NestedField,
+	requestVars []schema.QueryRequestVariablesElem,
+	requestArguments internal.HTTPRequestArguments,
+) ([]schema.RowSet, error) {
+	function, _, err := cs.metadata.GetFunction(request.Collection)
+	if err != nil || function.Request == nil || function.Request.JSONRPC == nil {
+		return nil, nil
+	}
+
+	ctx, span := state.Tracer.Start(ctx, "Execute JSON-RPC Batch Query")
+	defer span.End()
+
+	var requests *internal.RequestBuilderResults
+
+	batch := make([]*internal.RetryableRequest, len(requestVars))
+
+	for i, requestVar := range requestVars {
+		results, err := cs.explainQuery(request, valueField, requestVar)
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to explain query")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		if results.Distributed || len(results.Requests) != 1 ||
+			(requests != nil && !canBatchJSONRPCRequests(requests.Requests[0], results.Requests[0])) {
+			return nil, nil
+		}
+
+		if requests == nil {
+			requests = results
+		}
+
+		batch[i] = results.Requests[0]
+	}
+
+	client := cs.upstreams.CreateHTTPClient(requests, requestArguments)
+
+	results, err := client.SendJSONRPCBatch(ctx, valueField, batch)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to execute the http request")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	rowSets := make([]schema.RowSet, len(results))
+
+	for i, result := range results {
+		rowSets[i] = schema.RowSet{
+			Aggregates: schema.RowSetAggregates{},
+			Rows: []map[string]any{
+				{
+					"__value": result,
+				},
+			},
+		}
+	}
+
+	return rowSets, nil
+}
+
+// JSON-RPC requests can be merged into a batch request if they are sent to the same endpoint with the same headers.
+func canBatchJSONRPCRequests(first *internal.RetryableRequest, other *internal.RetryableRequest) bool {
+	return first.ServerID == other.ServerID &&
+		first.URL.String() == other.URL.String() &&
+		reflect.DeepEqual(first.Headers, other.Headers)
+}
+
+func (cs *connectorSnapshot) execQuery(
 	ctx context.Context,
 	state *State,
 	request *schema.QueryRequest,
@@ -199,7 +302,7 @@ func (c *HTTPConnector) execQuery(
 	ctx, span := state.Tracer.Start(ctx, fmt.Sprintf("Execute Query %d", index))
 	defer span.End()
 
-	requests, err := c.explainQuery(request, variables)
+	requests, err := cs.explainQuery(request, queryFields, variables)
 	if err != nil {
 		span.SetStatus(codes.Error, "failed to explain query")
 		span.RecordError(err)
@@ -207,7 +310,7 @@ func (c *HTTPConnector) execQuery(
 		return nil, err
 	}
 
-	client := c.upstreams.CreateHTTPClient(requests, requestArguments)
+	client := cs.upstreams.CreateHTTPClient(requests, requestArguments)
 
 	result, _, err := client.Send(ctx, queryFields)
 	if err != nil {
@@ -220,7 +323,7 @@ func (c *HTTPConnector) execQuery(
 	return result, nil
 }
 
-func (c *HTTPConnector) serializeExplainResponse(
+func (cs *connectorSnapshot) serializeExplainResponse(
 	ctx context.Context,
 	requests *internal.RequestBuilderResults,
 	requestArguments internal.HTTPRequestArguments,
@@ -230,9 +333,10 @@ func (c *HTTPConnector) serializeExplainResponse(
 	}
 
 	httpRequest := requests.Requests[0]
+	redactor := cs.upstreams.RuntimeSettings.Redactor
 
 	if httpRequest.Body != nil {
-		explainResp.Details["body"] = string(httpRequest.Body)
+		explainResp.Details["body"] = redactor.RedactBody(httpRequest.Body)
 		httpRequest.Body = nil
 	}
 
@@ -243,29 +347,34 @@ func (c *HTTPConnector) serializeExplainResponse(
 
 	defer cancel()
 
-	// mask sensitive forwarded headers if exists
-	for key, value := range req.Header {
-		if internal.IsSensitiveHeader(key) {
-			req.Header.Set(key, restUtils.MaskString(value[0]))
-		}
-	}
-
-	c.upstreams.InjectMockRequestSettings(
+	securityName := cs.upstreams.InjectMockRequestSettings(
 		req,
 		requests.Schema.Name,
 		httpRequest.RawRequest.Security,
 	)
+	if securityName != "" {
+		explainResp.Details["security"] = securityName
+	}
 
 	// merge headers from request-level arguments if exist
 	for key, value := range requestArguments.Headers {
-		if internal.IsSensitiveHeader(key) {
-			value = restUtils.MaskString(value)
+		if strings.EqualFold(key, rest.CookieHeader) {
+			internal.MergeCookieHeader(req.Header, value)
+
+			continue
 		}
 
 		req.Header.Set(key, value)
 	}
 
-	explainResp.Details["url"] = req.URL.String()
+	// mask sensitive headers after all headers are set
+	req.Header = redactor.RedactHeaders(req.Header)
+
+	if cookie := req.Header.Get(rest.CookieHeader); cookie != "" {
+		req.Header.Set(rest.CookieHeader, internal.MaskCookieHeader(cookie))
+	}
+
+	explainResp.Details["url"] = redactor.RedactURL(req.URL)
 
 	rawHeaders, err := json.Marshal(req.Header)
 	if err != nil {
@@ -0,0 +1,134 @@
+package connector
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/hasura/ndc-http/connector/internal"
+	"github.com/hasura/ndc-sdk-go/v2/connector"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// watch starts watchers of secret and TLS files of upstreams.
+// The configuration is also watched if the watchConfig setting is enabled.
+func (c *HTTPConnector) watch(ctx context.Context, tracer trace.Tracer) {
+	if c.fileWatchInterval <= 0 {
+		return
+	}
+
+	snapshot := c.snapshot.Load()
+	if !snapshot.upstreams.RuntimeSettings.WatchConfig {
+		snapshot.upstreams.WatchFiles(ctx, tracer, c.fileWatchInterval)
+
+		return
+	}
+
+	go c.watchConfiguration(ctx, tracer, snapshot)
+}
+
+// watchConfiguration polls configuration files and reloads the connector when any file changes.
+func (c *HTTPConnector) watchConfiguration(
+	ctx context.Context,
+	tracer trace.Tracer,
+	snapshot *connectorSnapshot,
+) {
+	fingerprints := internal.GetFileFingerprints(snapshot.files)
+
+	stopUpstreamWatcher := c.watchUpstreamFiles(ctx, tracer, snapshot)
+	ticker := time.NewTicker(c.fileWatchInterval)
+
+	defer func() {
+		ticker.Stop()
+		stopUpstreamWatcher()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := internal.GetFileFingerprints(snapshot.files)
+		if maps.Equal(fingerprints, current) {
+			continue
+		}
+
+		changedFiles := getChangedFiles(fingerprints, current)
+		// the invalid configuration isn't reloaded until files change again.
+		fingerprints = current
+
+		newSnapshot, err := c.reload(ctx, tracer, changedFiles)
+		if err != nil {
+			continue
+		}
+
+		stopUpstreamWatcher()
+
+		stopUpstreamWatcher = c.watchUpstreamFiles(ctx, tracer, newSnapshot)
+		snapshot.upstreams.CloseIdleConnections()
+
+		snapshot = newSnapshot
+		fingerprints = internal.GetFileFingerprints(snapshot.files)
+	}
+}
+
+// watchUpstreamFiles watches secret and TLS files of upstreams of the snapshot until the returned function is called.
+func (c *HTTPConnector) watchUpstreamFiles(
+	ctx context.Context,
+	tracer trace.Tracer,
+	snapshot *connectorSnapshot,
+) context.CancelFunc {
+	upstreamCtx, cancel := context.WithCancel(ctx)
+	snapshot.upstreams.WatchFiles(upstreamCtx, tracer, c.fileWatchInterval)
+
+	return cancel
+}
+
+// reload reads and validates the configuration, then replaces the current snapshot.
+// The current snapshot stays active if the new configuration is invalid.
+func (c *HTTPConnector) reload(
+	ctx context.Context,
+	tracer trace.Tracer,
+	changedFiles []string,
+) (*connectorSnapshot, error) {
+	ctx, span := tracer.Start(ctx, "Reload configuration")
+	defer span.End()
+
+	span.SetAttributes(attribute.StringSlice("files", changedFiles))
+
+	logger := connector.GetLogger(ctx).With(slog.Any("files", changedFiles))
+
+	snapshot, err := c.loadSnapshot(ctx, c.configurationDir, logger)
+	if err != nil {
+		logger.Error("failed to reload the configuration: " + err.Error())
+		span.SetStatus(codes.Error, "failed to reload the configuration")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	c.snapshot.Store(snapshot)
+	logger.Info("reloaded the configuration")
+
+	return snapshot, nil
+}
+
+func getChangedFiles(previous map[string]string, current map[string]string) []string {
+	var results []string
+
+	for filePath, checksum := range current {
+		if previous[filePath] != checksum {
+			results = append(results, filePath)
+		}
+	}
+
+	slices.Sort(results)
+
+	return results
+}
@@ -3,42 +3,131 @@ package connector
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"path/filepath"
 
 	"github.com/hasura/ndc-http/connector/internal"
 	"github.com/hasura/ndc-http/ndc-http-schema/configuration"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
 	"github.com/hasura/ndc-sdk-go/v2/schema"
 )
 
+// connectorSnapshot holds the configuration and states that are derived from it.
+// The snapshot is replaced as a whole when the configuration is reloaded,
+// so in-flight requests keep using the snapshot that they started with.
+type connectorSnapshot struct {
+	config              *configuration.Configuration
+	metadata            internal.MetadataCollection
+	rawSchema           *schema.RawSchemaResponse
+	upstreams           *internal.UpstreamManager
+	procSendHttpRequest *rest.OperationInfo
+	// local files that the configuration is built from.
+	files []string
+}
+
 // GetSchema gets the connector's schema.
 func (c *HTTPConnector) GetSchema(
 	ctx context.Context,
 	configuration *configuration.Configuration,
 	_ *State,
 ) (schema.SchemaResponseMarshaler, error) {
-	return c.rawSchema, nil
+	return c.snapshot.Load().rawSchema, nil
 }
 
-// ApplyNDCHttpSchemas applies slice of raw NDC HTTP schemas to the connector.
+// ApplyNDCHttpSchemas applies slice of raw NDC HTTP schemas to the connector with the current configuration.
 func (c *HTTPConnector) ApplyNDCHttpSchemas(
 	ctx context.Context,
 	schemas []configuration.NDCHttpRuntimeSchema,
 	logger *slog.Logger,
 ) error {
-	config := c.config
+	current := c.snapshot.Load()
+	if current == nil {
+		return errors.New("the configuration of the connector hasn't been parsed")
+	}
+
+	snapshot, err := c.newSnapshot(ctx, current.config, schemas, logger)
+	if err != nil {
+		return err
+	}
+
+	c.snapshot.Store(snapshot)
+
+	return nil
+}
+
+// loadSnapshot reads the configuration and NDC HTTP schemas from the configuration directory.
+func (c *HTTPConnector) loadSnapshot(
+	ctx context.Context,
+	configurationDir string,
+	logger *slog.Logger,
+) (*connectorSnapshot, error) {
+	config, err := configuration.ReadConfigurationFile(configurationDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// file paths must be resolved before building schemas because the builder mutates paths of patch files.
+	files := configuration.GetConfigurationFilePaths(configurationDir, config)
+
+	schemas, err := configuration.ReadSchemaOutputFile(configurationDir, config.Output, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if schemas == nil {
+		logger.Debug(
+			fmt.Sprintf(
+				"output file at %s does not exist. Parsing files...",
+				filepath.Join(configurationDir, config.Output),
+			),
+		)
+
+		var errs map[string][]string
+
+		schemas, errs = configuration.BuildSchemaFromConfig(config, configurationDir, logger)
+		if len(errs) > 0 {
+			printSchemaValidationError(logger, errs)
+
+			return nil, errBuildSchemaFailed
+		}
+	}
+
+	snapshot, err := c.newSnapshot(ctx, config, schemas, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate NDC HTTP schema: %w", err)
+	}
+
+	snapshot.files = files
+
+	return snapshot, nil
+}
+
+// newSnapshot validates NDC HTTP schemas and creates a snapshot of the connector states.
+func (c *HTTPConnector) newSnapshot(
+	ctx context.Context,
+	config *configuration.Configuration,
+	schemas []configuration.NDCHttpRuntimeSchema,
+	logger *slog.Logger,
+) (*connectorSnapshot, error) {
+	upstreams, err := internal.NewUpstreamManager(c.httpClient, config, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	httpSchema, metadata, errs := configuration.MergeNDCHttpSchemas(config, schemas)
 	if len(errs) > 0 {
 		printSchemaValidationError(logger, errs)
 
 		if httpSchema == nil || config.Strict {
-			return errBuildSchemaFailed
+			return nil, errBuildSchemaFailed
 		}
 	}
 
 	for _, meta := range metadata {
-		if err := c.upstreams.Register(ctx, &meta, httpSchema); err != nil {
-			return err
+		if err := upstreams.Register(ctx, &meta, httpSchema); err != nil {
+			return nil, err
 		}
 	}
 
@@ -48,17 +137,19 @@ func (c *HTTPConnector) ApplyNDCHttpSchemas(
 	)
 
 	schemaBytes, err := json.Marshal(
-		internal.ApplyPromptQLSettingsToSchema(ndcSchema, c.upstreams.RuntimeSettings),
+		internal.ApplyPromptQLSettingsToSchema(ndcSchema, upstreams.RuntimeSettings),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	c.metadata = metadata
-	c.rawSchema = schema.NewRawSchemaResponseUnsafe(schemaBytes)
-	c.procSendHttpRequest = procSendHttp
-
-	return nil
+	return &connectorSnapshot{
+		config:              config,
+		metadata:            metadata,
+		rawSchema:           schema.NewRawSchemaResponseUnsafe(schemaBytes),
+		upstreams:           upstreams,
+		procSendHttpRequest: procSendHttp,
+	}, nil
 }
 
 func printSchemaValidationError(logger *slog.Logger, errors map[string][]string) {
@@ -22,6 +22,12 @@ function generate_client() {
     -extfile <(printf "$ADDEXT") \
     -CA ca.crt -CAkey ca.key -out ${CLIENT}.crt -days ${DAYS} -sha256 -CAcreateserial
   cat ${CLIENT}.crt ${CLIENT}.key > ${CLIENT}.pem
+
+  # PKCS#12 bundles with AES-256 (default) and legacy RC2/3DES encryption.
+  openssl pkcs12 -export -in ${CLIENT}.crt -inkey ${CLIENT}.key -certfile ca.crt \
+    -out ${CLIENT}.p12 -passout pass:p12secret
+  openssl pkcs12 -export -legacy -in ${CLIENT}.crt -inkey ${CLIENT}.key \
+    -out ${CLIENT}-legacy.p12 -passout pass:p12secret
 }
 
 function generate_cert() {
@@ -1,8 +1,10 @@
 package connector
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/hasura/ndc-sdk-go/v2/connector"
 )
@@ -12,16 +14,20 @@ var errBuildSchemaFailed = errors.New("failed to build NDC HTTP schema")
 // State is the global state which is shared for every connector request.
 type State struct {
 	Tracer *connector.Tracer
+
+	stopWatcher context.CancelFunc
 }
 
 type options struct {
-	client *http.Client
+	client            *http.Client
+	fileWatchInterval time.Duration
 }
 
 var defaultOptions options = options{
 	client: &http.Client{
 		Transport: http.DefaultTransport,
 	},
+	fileWatchInterval: 10 * time.Second,
 }
 
 // Option is an interface to set custom HTTP connector options.
@@ -33,3 +39,11 @@ func WithClient(client *http.Client) Option {
 		opts.client = client
 	}
 }
+
+// WithFileWatchInterval sets the interval to check changes of secret, TLS and configuration files.
+// Watchers are disabled if the interval is zero.
+func WithFileWatchInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.fileWatchInterval = interval
+	}
+}
@@ -0,0 +1,242 @@
+package exhttp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	authorizationHeader   = "Authorization"
+	wwwAuthenticateHeader = "WWW-Authenticate"
+)
+
+// authChallenge represents a challenge of the WWW-Authenticate header.
+type authChallenge struct {
+	Scheme string
+	// Token68 is the raw credential of schemes that don't use parameters, e.g. NTLM.
+	Token68 string
+	Params  map[string]string
+}
+
+// parseAuthChallenges parses challenges from WWW-Authenticate header values following [RFC 9110].
+// A header value may contain many challenges separated by commas.
+//
+// [RFC 9110]: https://www.rfc-editor.org/rfc/rfc9110#name-www-authenticate
+func parseAuthChallenges(values []string) []authChallenge {
+	var results []authChallenge
+
+	for _, value := range values {
+		p := &authChallengeParser{input: value}
+		results = append(results, p.parse()...)
+	}
+
+	return results
+}
+
+// findAuthChallenge finds the first challenge of the scheme. The scheme name is case-insensitive.
+func findAuthChallenge(challenges []authChallenge, scheme string) *authChallenge {
+	for i, challenge := range challenges {
+		if strings.EqualFold(challenge.Scheme, scheme) {
+			return &challenges[i]
+		}
+	}
+
+	return nil
+}
+
+type authChallengeParser struct {
+	input string
+	pos   int
+}
+
+func (p *authChallengeParser) parse() []authChallenge {
+	var results []authChallenge
+
+	for {
+		p.skipSeparators()
+
+		scheme := p.readToken()
+		if scheme == "" {
+			return results
+		}
+
+		challenge := authChallenge{
+			Scheme: scheme,
+			Params: map[string]string{},
+		}
+
+		p.skipSpaces()
+
+		if token68, ok := p.readToken68(); ok {
+			challenge.Token68 = token68
+		} else {
+			p.readParams(challenge.Params)
+		}
+
+		results = append(results, challenge)
+	}
+}
+
+// readParams reads auth-params until the next challenge.
+func (p *authChallengeParser) readParams(params map[string]string) {
+	for {
+		p.skipSeparators()
+
+		start := p.pos
+
+		name := p.readToken()
+		if name == "" {
+			return
+		}
+
+		p.skipSpaces()
+
+		if p.pos >= len(p.input) || p.input[p.pos] != '=' {
+			// the token is the scheme of the next challenge.
+			p.pos = start
+
+			return
+		}
+
+		p.pos++
+		p.skipSpaces()
+
+		params[strings.ToLower(name)] = p.readValue()
+	}
+}
+
+// readToken68 reads the token68 credential if the next value isn't an auth-param.
+func (p *authChallengeParser) readToken68() (string, bool) {
+	start := p.pos
+
+	for p.pos < len(p.input) && isToken68Char(p.input[p.pos]) {
+		p.pos++
+	}
+
+	if p.pos == start {
+		return "", false
+	}
+
+	for p.pos < len(p.input) && p.input[p.pos] == '=' {
+		p.pos++
+	}
+
+	// token68 must be the only value of the challenge, otherwise the token is the name of an auth-param.
+	rest := strings.TrimLeft(p.input[p.pos:], " \t")
+	if rest != "" && rest[0] != ',' {
+		p.pos = start
+
+		return "", false
+	}
+
+	return p.input[start:p.pos], true
+}
+
+func (p *authChallengeParser) readValue() string {
+	if p.pos >= len(p.input) {
+		return ""
+	}
+
+	if p.input[p.pos] != '"' {
+		return p.readToken()
+	}
+
+	p.pos++
+
+	var sb strings.Builder
+
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+
+		switch c {
+		case '\\':
+			if p.pos < len(p.input) {
+				sb.WriteByte(p.input[p.pos])
+				p.pos++
+			}
+		case '"':
+			return sb.String()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String()
+}
+
+func (p *authChallengeParser) readToken() string {
+	start := p.pos
+
+	for p.pos < len(p.input) && isTokenChar(p.input[p.pos]) {
+		p.pos++
+	}
+
+	return p.input[start:p.pos]
+}
+
+func (p *authChallengeParser) skipSpaces() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *authChallengeParser) skipSeparators() {
+	for p.pos < len(p.input) && strings.IndexByte(" \t,", p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func isTokenChar(c byte) bool {
+	return c > 0x20 && c < 0x7f && !strings.ContainsRune("\"(),/:;<=>?@[\\]{}", rune(c))
+}
+
+func isToken68Char(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		strings.IndexByte("-._~+/", c) >= 0
+}
+
+// readRequestBody reads the request body so the request can be sent many times during the authentication handshake.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	defer req.Body.Close()
+
+	if req.GetBody == nil {
+		return io.ReadAll(req.Body)
+	}
+
+	reader, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// cloneRequestWithBody clones the request with a fresh reader of the body.
+func cloneRequestWithBody(req *http.Request, body []byte) *http.Request {
+	newReq := req.Clone(req.Context())
+
+	if body != nil {
+		newReq.Body = io.NopCloser(bytes.NewReader(body))
+		newReq.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		newReq.ContentLength = int64(len(body))
+	}
+
+	return newReq
+}
+
+// discardResponse drains and closes the response body so the connection can be reused.
+func discardResponse(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
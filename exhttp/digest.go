@@ -0,0 +1,285 @@
+package exhttp
+
+import (
+	"crypto/md5" //nolint:gosec
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedDigestAlgorithm occurs when the server requires an unsupported digest algorithm.
+var ErrUnsupportedDigestAlgorithm = errors.New("unsupported digest algorithm")
+
+type digestSession struct {
+	challenge  *authChallenge
+	nonceCount uint32
+}
+
+type digestTransport struct {
+	transport http.RoundTripper
+	username  string
+	password  string
+
+	// digest sessions are cached by host so subsequent requests are authorized preemptively
+	// with the same nonce and an incremented nonce count.
+	lock     sync.Mutex
+	sessions map[string]*digestSession
+}
+
+// NewDigestTransport creates a transport that authenticates requests with the HTTP Digest scheme following [RFC 7616].
+// The transport answers 401 challenges and sends the request again with the Authorization header.
+// The request body is buffered in memory to be replayed.
+//
+// [RFC 7616]: https://datatracker.ietf.org/doc/html/rfc7616
+func NewDigestTransport(transport http.RoundTripper, username string, password string) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &digestTransport{
+		transport: transport,
+		username:  username,
+		password:  password,
+		sessions:  map[string]*digestSession{},
+	}
+}
+
+// RoundTrip sends the request with digest authentication.
+func (dt *digestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, usedChallenge, err := dt.roundTripOnce(req, body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := findDigestChallenge(parseAuthChallenges(resp.Header.Values(wwwAuthenticateHeader)))
+	if challenge == nil {
+		return resp, nil
+	}
+
+	// the credential was rejected. Retry only if the server reports that the cached nonce is stale.
+	if usedChallenge != nil && !strings.EqualFold(challenge.Params["stale"], "true") {
+		return resp, nil
+	}
+
+	dt.setSession(req.URL.Host, challenge)
+
+	discardResponse(resp)
+
+	resp, _, err = dt.roundTripOnce(req, body)
+
+	return resp, err
+}
+
+func (dt *digestTransport) roundTripOnce(
+	req *http.Request,
+	body []byte,
+) (*http.Response, *authChallenge, error) {
+	newReq := cloneRequestWithBody(req, body)
+
+	challenge, nonceCount := dt.nextNonceCount(req.URL.Host)
+	if challenge == nil {
+		resp, err := dt.transport.RoundTrip(newReq)
+
+		return resp, nil, err
+	}
+
+	authorization, err := dt.authorize(req, body, challenge, nonceCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newReq.Header.Set(authorizationHeader, authorization)
+
+	resp, err := dt.transport.RoundTrip(newReq)
+
+	return resp, challenge, err
+}
+
+func (dt *digestTransport) setSession(host string, challenge *authChallenge) {
+	dt.lock.Lock()
+	defer dt.lock.Unlock()
+
+	dt.sessions[host] = &digestSession{
+		challenge: challenge,
+	}
+}
+
+// nextNonceCount increases and returns the nonce count of the session.
+// The nonce count must be unique for each request that uses the same nonce.
+func (dt *digestTransport) nextNonceCount(host string) (*authChallenge, uint32) {
+	dt.lock.Lock()
+	defer dt.lock.Unlock()
+
+	session, ok := dt.sessions[host]
+	if !ok {
+		return nil, 0
+	}
+
+	session.nonceCount++
+
+	return session.challenge, session.nonceCount
+}
+
+func (dt *digestTransport) authorize(
+	req *http.Request,
+	body []byte,
+	challenge *authChallenge,
+	nonceCount uint32,
+) (string, error) {
+	algorithm := challenge.Params["algorithm"]
+	if algorithm == "" {
+		algorithm = "MD5"
+	}
+
+	newHash, sess, err := getDigestHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	h := func(values ...string) string {
+		hasher := newHash()
+		_, _ = io.WriteString(hasher, strings.Join(values, ":"))
+
+		return hex.EncodeToString(hasher.Sum(nil))
+	}
+
+	realm := challenge.Params["realm"]
+	nonce := challenge.Params["nonce"]
+	qop := selectDigestQOP(challenge.Params["qop"])
+	uri := req.URL.RequestURI()
+	cnonce := generateCNonce()
+	nc := fmt.Sprintf("%08x", nonceCount)
+
+	username := dt.username
+	userhash := strings.EqualFold(challenge.Params["userhash"], "true")
+
+	if userhash {
+		username = h(dt.username, realm)
+	}
+
+	ha1 := h(dt.username, realm, dt.password)
+	if sess {
+		ha1 = h(ha1, nonce, cnonce)
+	}
+
+	ha2 := h(req.Method, uri)
+
+	if qop == "auth-int" {
+		hasher := newHash()
+		_, _ = hasher.Write(body)
+		ha2 = h(req.Method, uri, hex.EncodeToString(hasher.Sum(nil)))
+	}
+
+	var response string
+	if qop == "" {
+		// legacy RFC 2069 digest.
+		response = h(ha1, nonce, ha2)
+	} else {
+		response = h(ha1, nonce, nc, cnonce, qop, ha2)
+	}
+
+	params := []string{
+		fmt.Sprintf(`username="%s"`, escapeQuotedString(username)),
+		fmt.Sprintf(`realm="%s"`, escapeQuotedString(realm)),
+		fmt.Sprintf(`nonce="%s"`, escapeQuotedString(nonce)),
+		fmt.Sprintf(`uri="%s"`, escapeQuotedString(uri)),
+		"algorithm=" + algorithm,
+		fmt.Sprintf(`response="%s"`, response),
+	}
+
+	if qop != "" {
+		params = append(params, "qop="+qop, "nc="+nc, fmt.Sprintf(`cnonce="%s"`, cnonce))
+	}
+
+	if opaque, ok := challenge.Params["opaque"]; ok {
+		params = append(params, fmt.Sprintf(`opaque="%s"`, escapeQuotedString(opaque)))
+	}
+
+	if userhash {
+		params = append(params, "userhash=true")
+	}
+
+	return "Digest " + strings.Join(params, ", "), nil
+}
+
+// findDigestChallenge selects the strongest supported challenge if the server offers many digest algorithms.
+func findDigestChallenge(challenges []authChallenge) *authChallenge {
+	var result *authChallenge
+
+	for i, challenge := range challenges {
+		if !strings.EqualFold(challenge.Scheme, "Digest") {
+			continue
+		}
+
+		if _, _, err := getDigestHash(challenge.Params["algorithm"]); err != nil {
+			continue
+		}
+
+		if result == nil || !strings.HasPrefix(strings.ToUpper(result.Params["algorithm"]), "SHA-") {
+			result = &challenges[i]
+		}
+	}
+
+	return result
+}
+
+func getDigestHash(algorithm string) (func() hash.Hash, bool, error) {
+	if algorithm == "" {
+		algorithm = "MD5"
+	}
+
+	name, sess := strings.CutSuffix(strings.ToUpper(algorithm), "-SESS")
+
+	switch name {
+	case "MD5":
+		return md5.New, sess, nil
+	case "SHA-256":
+		return sha256.New, sess, nil
+	case "SHA-512-256":
+		return sha512.New512_256, sess, nil
+	default:
+		return nil, false, fmt.Errorf("%w: %s", ErrUnsupportedDigestAlgorithm, algorithm)
+	}
+}
+
+// selectDigestQOP selects the quality of protection from the list of the challenge. auth is preferred.
+func selectDigestQOP(rawQOP string) string {
+	var options []string
+
+	for option := range strings.SplitSeq(rawQOP, ",") {
+		options = append(options, strings.ToLower(strings.TrimSpace(option)))
+	}
+
+	for _, qop := range []string{"auth", "auth-int"} {
+		if slices.Contains(options, qop) {
+			return qop
+		}
+	}
+
+	return ""
+}
+
+func generateCNonce() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
+
+func escapeQuotedString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
@@ -0,0 +1,270 @@
+package exhttp
+
+import (
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+type mockDigestServer struct {
+	algorithm string
+	username  string
+	password  string
+	// the number of uses before the nonce becomes stale.
+	maxNonceUses int
+
+	lock       sync.Mutex
+	nonceIndex int
+	nonce      string
+	usedNC     map[int64]bool
+	challenges int
+	bodies     []string
+}
+
+func (mds *mockDigestServer) newHash() hash.Hash {
+	if mds.algorithm == "SHA-256" {
+		return sha256.New()
+	}
+
+	return md5.New() //nolint:gosec
+}
+
+func (mds *mockDigestServer) h(values ...string) string {
+	hasher := mds.newHash()
+	_, _ = io.WriteString(hasher, strings.Join(values, ":"))
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func (mds *mockDigestServer) rotateNonce() {
+	mds.nonceIndex++
+	mds.nonce = fmt.Sprintf("nonce-%d", mds.nonceIndex)
+	mds.usedNC = map[int64]bool{}
+}
+
+func (mds *mockDigestServer) challenge(w http.ResponseWriter, stale bool) {
+	mds.challenges++
+	mds.rotateNonce()
+
+	value := fmt.Sprintf(
+		`Digest realm="test", qop="auth,auth-int", algorithm=%s, nonce="%s", opaque="abc"`,
+		mds.algorithm,
+		mds.nonce,
+	)
+	if stale {
+		value += ", stale=true"
+	}
+
+	w.Header().Add("WWW-Authenticate", `Basic realm="test"`)
+	w.Header().Add("WWW-Authenticate", value)
+	w.WriteHeader(http.StatusUnauthorized)
+}
+
+func (mds *mockDigestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	mds.lock.Lock()
+	defer mds.lock.Unlock()
+
+	challenges := parseAuthChallenges(r.Header.Values("Authorization"))
+	if len(challenges) == 0 || challenges[0].Scheme != "Digest" {
+		mds.challenge(w, false)
+
+		return
+	}
+
+	params := challenges[0].Params
+	if params["nonce"] != mds.nonce || params["opaque"] != "abc" ||
+		params["uri"] != r.URL.RequestURI() ||
+		params["algorithm"] != mds.algorithm {
+		mds.challenge(w, true)
+
+		return
+	}
+
+	nc, err := strconv.ParseInt(params["nc"], 16, 32)
+	if err != nil || mds.usedNC[nc] {
+		// replayed nonce count.
+		mds.challenge(w, false)
+
+		return
+	}
+
+	if int(nc) > mds.maxNonceUses {
+		mds.challenge(w, true)
+
+		return
+	}
+
+	ha2 := mds.h(r.Method, params["uri"])
+	if params["qop"] == "auth-int" {
+		ha2 = mds.h(r.Method, params["uri"], mds.h(string(body)))
+	}
+
+	expected := mds.h(
+		mds.h(mds.username, "test", mds.password),
+		mds.nonce,
+		params["nc"],
+		params["cnonce"],
+		params["qop"],
+		ha2,
+	)
+
+	if params["username"] != mds.username || params["response"] != expected {
+		mds.challenge(w, false)
+
+		return
+	}
+
+	mds.usedNC[nc] = true
+	mds.bodies = append(mds.bodies, string(body))
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func TestDigestTransport(t *testing.T) {
+	for _, algorithm := range []string{"MD5", "SHA-256"} {
+		t.Run(algorithm, func(t *testing.T) {
+			handler := &mockDigestServer{
+				algorithm:    algorithm,
+				username:     "user",
+				password:     "secret",
+				maxNonceUses: 3,
+			}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			client := &http.Client{
+				Transport: NewDigestTransport(server.Client().Transport, "user", "secret"),
+			}
+
+			// the first request is challenged. The next requests reuse the nonce until it is stale.
+			for i := range 5 {
+				resp, err := client.Get(server.URL + "/pets?status=" + strconv.Itoa(i))
+				assert.NilError(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				discardResponse(resp)
+			}
+
+			assert.Equal(t, 2, handler.challenges)
+
+			resp, err := client.Post(server.URL+"/pets", "text/plain", strings.NewReader("hello"))
+			assert.NilError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			discardResponse(resp)
+			assert.Equal(t, "hello", handler.bodies[len(handler.bodies)-1])
+		})
+	}
+
+	t.Run("invalid_password", func(t *testing.T) {
+		handler := &mockDigestServer{
+			algorithm:    "MD5",
+			username:     "user",
+			password:     "secret",
+			maxNonceUses: 10,
+		}
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: NewDigestTransport(server.Client().Transport, "user", "invalid"),
+		}
+
+		resp, err := client.Get(server.URL)
+		assert.NilError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		discardResponse(resp)
+
+		// the stale nonce is renewed once before the credential is rejected.
+		resp, err = client.Get(server.URL)
+		assert.NilError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		discardResponse(resp)
+		assert.Equal(t, 4, handler.challenges)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		handler := &mockDigestServer{
+			algorithm:    "SHA-256",
+			username:     "user",
+			password:     "secret",
+			maxNonceUses: 1000,
+		}
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: NewDigestTransport(server.Client().Transport, "user", "secret"),
+		}
+
+		resp, err := client.Get(server.URL)
+		assert.NilError(t, err)
+		discardResponse(resp)
+
+		var wg sync.WaitGroup
+
+		statuses := make([]int, 10)
+
+		for i := range statuses {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				resp, err := client.Get(server.URL)
+				if err != nil {
+					return
+				}
+
+				statuses[i] = resp.StatusCode
+				discardResponse(resp)
+			}()
+		}
+
+		wg.Wait()
+
+		for _, status := range statuses {
+			assert.Equal(t, http.StatusOK, status)
+		}
+	})
+}
+
+func TestParseAuthChallenges(t *testing.T) {
+	challenges := parseAuthChallenges([]string{
+		`Negotiate, NTLM`,
+		`Digest realm="a \"b\"", qop="auth,auth-int", nonce=abc, Basic realm=test`,
+		`NTLM TlRMTVNTUAACAAAAAAAAAA==`,
+	})
+
+	assert.DeepEqual(t, []authChallenge{
+		{Scheme: "Negotiate", Params: map[string]string{}},
+		{Scheme: "NTLM", Params: map[string]string{}},
+		{
+			Scheme: "Digest",
+			Params: map[string]string{
+				"realm": `a "b"`,
+				"qop":   "auth,auth-int",
+				"nonce": "abc",
+			},
+		},
+		{Scheme: "Basic", Params: map[string]string{"realm": "test"}},
+		{Scheme: "NTLM", Token68: "TlRMTVNTUAACAAAAAAAAAA==", Params: map[string]string{}},
+	}, challenges)
+}
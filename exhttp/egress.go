@@ -0,0 +1,249 @@
+package exhttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+)
+
+// defaultEgressMaxRedirects is the default number of redirects that the client follows, the same as the Go HTTP client.
+const defaultEgressMaxRedirects = 10
+
+var defaultEgressSchemes = []string{"http", "https"}
+
+// EgressPolicyConfig restricts destinations of outbound requests to protect internal networks from SSRF.
+type EgressPolicyConfig struct {
+	// Host name patterns that requests are allowed to reach. A pattern is an exact host name, or starts with *. to match sub-domains,
+	// for example, *.example.com. Allow all hosts if empty.
+	AllowedHosts []string `json:"allowedHosts,omitempty" mapstructure:"allowedHosts" yaml:"allowedHosts,omitempty"`
+	// URL schemes that requests are allowed to use. Default is [http, https].
+	AllowedSchemes []string `json:"allowedSchemes,omitempty" mapstructure:"allowedSchemes" yaml:"allowedSchemes,omitempty"`
+	// Ports that requests are allowed to reach. Allow all ports if empty.
+	AllowedPorts []uint16 `json:"allowedPorts,omitempty" mapstructure:"allowedPorts" yaml:"allowedPorts,omitempty"`
+	// Block loopback, private, link-local and unspecified IP addresses after DNS resolution. Default is true.
+	BlockPrivateNetworks *bool `json:"blockPrivateNetworks,omitempty" mapstructure:"blockPrivateNetworks" yaml:"blockPrivateNetworks,omitempty" jsonschema:"nullable"`
+	// The maximum number of redirects that are followed. Zero disables redirects. Default is 10.
+	MaxRedirects *uint `json:"maxRedirects,omitempty" mapstructure:"maxRedirects" yaml:"maxRedirects,omitempty" jsonschema:"nullable,min=0"`
+}
+
+// EgressDeniedError occurs when the destination of an outbound request is not allowed by the egress policy.
+type EgressDeniedError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (ede EgressDeniedError) Error() string {
+	return "egress denied: " + ede.Reason
+}
+
+// EgressPolicy checks destinations of outbound requests.
+type EgressPolicy struct {
+	hosts        []string
+	schemes      []string
+	ports        []uint16
+	blockPrivate bool
+	maxRedirects int
+}
+
+// NewEgressPolicy validates the configuration and creates a new EgressPolicy instance.
+func NewEgressPolicy(config EgressPolicyConfig) (*EgressPolicy, error) {
+	policy := &EgressPolicy{
+		schemes:      defaultEgressSchemes,
+		ports:        config.AllowedPorts,
+		blockPrivate: config.BlockPrivateNetworks == nil || *config.BlockPrivateNetworks,
+		maxRedirects: defaultEgressMaxRedirects,
+	}
+
+	for i, host := range config.AllowedHosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" || strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			return nil, fmt.Errorf("allowedHosts[%d]: invalid host pattern %q", i, config.AllowedHosts[i])
+		}
+
+		policy.hosts = append(policy.hosts, host)
+	}
+
+	if len(config.AllowedSchemes) > 0 {
+		policy.schemes = make([]string, len(config.AllowedSchemes))
+
+		for i, scheme := range config.AllowedSchemes {
+			scheme = strings.ToLower(scheme)
+			if !slices.Contains(defaultEgressSchemes, scheme) {
+				return nil, fmt.Errorf("allowedSchemes[%d]: invalid http(s) scheme, got: %s", i, scheme)
+			}
+
+			policy.schemes[i] = scheme
+		}
+	}
+
+	if config.MaxRedirects != nil {
+		policy.maxRedirects = int(*config.MaxRedirects)
+	}
+
+	return policy, nil
+}
+
+// CheckURL checks the scheme, host and port of the request URL.
+func (ep *EgressPolicy) CheckURL(u *url.URL) error {
+	scheme := strings.ToLower(u.Scheme)
+	if !slices.Contains(ep.schemes, scheme) {
+		return &EgressDeniedError{Reason: fmt.Sprintf("scheme %s is not allowed", scheme)}
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if len(ep.hosts) > 0 && !slices.ContainsFunc(ep.hosts, func(pattern string) bool {
+		return matchHostPattern(pattern, host)
+	}) {
+		return &EgressDeniedError{Reason: fmt.Sprintf("host %s is not allowed", host)}
+	}
+
+	if len(ep.ports) == 0 {
+		return nil
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if scheme == "https" {
+			port = "443"
+		}
+	}
+
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || !slices.Contains(ep.ports, uint16(portNumber)) {
+		return &EgressDeniedError{Reason: fmt.Sprintf("port %s is not allowed", port)}
+	}
+
+	return nil
+}
+
+// CheckAddress checks the resolved IP address before the connection is established.
+func (ep *EgressPolicy) CheckAddress(address string) error {
+	if !ep.blockPrivate {
+		return nil
+	}
+
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return &EgressDeniedError{Reason: fmt.Sprintf("invalid address %s", address)}
+	}
+
+	ip := addrPort.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return &EgressDeniedError{Reason: fmt.Sprintf("IP address %s is in a blocked network", ip)}
+	}
+
+	return nil
+}
+
+// CheckRedirect limits the number of redirects. Destinations of redirects are checked by the transport.
+func (ep *EgressPolicy) CheckRedirect(_ *http.Request, via []*http.Request) error {
+	if len(via) > ep.maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", ep.maxRedirects)
+	}
+
+	return nil
+}
+
+type egressDialContextKey struct{}
+
+// egressDialState carries the egress policy from the transport to the dialer control.
+type egressDialState struct {
+	policy  *EgressPolicy
+	checked atomic.Bool
+}
+
+// controlEgress is the control function of dialers created by [HTTPTransportConfig.ToTransport].
+// It checks resolved IP addresses before connecting if the egress policy is set in the dial context.
+func controlEgress(ctx context.Context, _ string, address string, _ syscall.RawConn) error {
+	state, ok := ctx.Value(egressDialContextKey{}).(*egressDialState)
+	if !ok {
+		return nil
+	}
+
+	state.checked.Store(true)
+
+	return state.policy.CheckAddress(address)
+}
+
+// NewEgressTransport creates a new HTTP transport that enforces the egress policy.
+// The dialer of the base transport is kept so dialer timeouts and custom resolvers still apply.
+// Resolved IP addresses are checked by the dialer control so DNS rebinding can't bypass the policy.
+// If the base dialer isn't created by [HTTPTransportConfig.ToTransport], the remote address is checked after connecting.
+// The proxy is disabled because the dialer would only see the address of the proxy.
+func NewEgressTransport(baseTransport http.RoundTripper, policy *EgressPolicy) http.RoundTripper {
+	bTransport, ok := baseTransport.(*http.Transport)
+	if !ok {
+		bTransport = HTTPTransportConfig{}.ToTransport()
+	}
+
+	transport := bTransport.Clone()
+	transport.Proxy = nil
+
+	dialContext := transport.DialContext
+	if dialContext == nil {
+		dialContext = (&net.Dialer{}).DialContext
+	}
+
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		state := &egressDialState{policy: policy}
+
+		conn, err := dialContext(context.WithValue(ctx, egressDialContextKey{}, state), network, address)
+		if err != nil || state.checked.Load() {
+			return conn, err
+		}
+
+		if err := policy.CheckAddress(conn.RemoteAddr().String()); err != nil {
+			_ = conn.Close()
+
+			return nil, err
+		}
+
+		return conn, nil
+	}
+
+	return egressTransport{
+		transport: transport,
+		policy:    policy,
+	}
+}
+
+type egressTransport struct {
+	transport http.RoundTripper
+	policy    *EgressPolicy
+}
+
+// RoundTrip checks the request URL, including URLs of redirects, before sending the request.
+func (et egressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := et.policy.CheckURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	return et.transport.RoundTrip(req)
+}
+
+// matchHostPattern checks if the host matches the pattern. The *. prefix matches sub-domains only.
+func matchHostPattern(pattern string, host string) bool {
+	if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+		return strings.HasSuffix(host, suffix)
+	}
+
+	return pattern == host
+}
+
+// IsEgressDeniedError checks if the error is caused by the egress policy.
+func IsEgressDeniedError(err error) bool {
+	var egressErr *EgressDeniedError
+
+	return errors.As(err, &egressErr)
+}
@@ -0,0 +1,176 @@
+package exhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hasura/ndc-sdk-go/v2/utils"
+	"gotest.tools/v3/assert"
+)
+
+func TestEgressPolicyCheckURL(t *testing.T) {
+	policy, err := NewEgressPolicy(EgressPolicyConfig{
+		AllowedHosts:   []string{"api.example.com", "*.partner.com"},
+		AllowedSchemes: []string{"https"},
+		AllowedPorts:   []uint16{443, 8443},
+	})
+	assert.NilError(t, err)
+
+	for rawURL, expected := range map[string]string{
+		"https://api.example.com/pets":       "",
+		"https://API.EXAMPLE.COM./pets":      "",
+		"https://pets.partner.com:8443":      "",
+		"https://a.b.partner.com":            "",
+		"https://partner.com":                "egress denied: host partner.com is not allowed",
+		"http://api.example.com":             "egress denied: scheme http is not allowed",
+		"https://api.example.com:8080":       "egress denied: port 8080 is not allowed",
+		"https://169.254.169.254/latest":     "egress denied: host 169.254.169.254 is not allowed",
+		"https://api.example.com.evil.co/ok": "egress denied: host api.example.com.evil.co is not allowed",
+	} {
+		t.Run(rawURL, func(t *testing.T) {
+			u, err := url.Parse(rawURL)
+			assert.NilError(t, err)
+
+			err = policy.CheckURL(u)
+			if expected == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, expected)
+				assert.Assert(t, IsEgressDeniedError(err))
+			}
+		})
+	}
+}
+
+func TestEgressPolicyCheckAddress(t *testing.T) {
+	policy, err := NewEgressPolicy(EgressPolicyConfig{})
+	assert.NilError(t, err)
+
+	for address, blocked := range map[string]bool{
+		"93.184.216.34:443":           false,
+		"[2606:4700::1111]:443":       false,
+		"127.0.0.1:80":                true,
+		"10.1.2.3:80":                 true,
+		"172.16.0.1:80":               true,
+		"192.168.1.1:80":              true,
+		"169.254.169.254:80":          true,
+		"0.0.0.0:80":                  true,
+		"[::1]:80":                    true,
+		"[fd00::1]:80":                true,
+		"[fe80::1]:80":                true,
+		"[::ffff:127.0.0.1]:80":       true,
+		"[::ffff:169.254.169.254]:80": true,
+	} {
+		t.Run(address, func(t *testing.T) {
+			err := policy.CheckAddress(address)
+			assert.Equal(t, blocked, err != nil)
+		})
+	}
+
+	policy, err = NewEgressPolicy(EgressPolicyConfig{
+		BlockPrivateNetworks: utils.ToPtr(false),
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, policy.CheckAddress("127.0.0.1:80"))
+}
+
+func TestEgressPolicyValidate(t *testing.T) {
+	_, err := NewEgressPolicy(EgressPolicyConfig{
+		AllowedHosts: []string{"api.*.com"},
+	})
+	assert.ErrorContains(t, err, "allowedHosts[0]: invalid host pattern")
+
+	_, err = NewEgressPolicy(EgressPolicyConfig{
+		AllowedSchemes: []string{"ftp"},
+	})
+	assert.ErrorContains(t, err, "allowedSchemes[0]: invalid http(s) scheme")
+}
+
+func TestEgressTransport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Query().Get("to"), http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	newClient := func(config EgressPolicyConfig) *http.Client {
+		policy, err := NewEgressPolicy(config)
+		assert.NilError(t, err)
+
+		return &http.Client{
+			Transport:     NewEgressTransport(http.DefaultTransport, policy),
+			CheckRedirect: policy.CheckRedirect,
+		}
+	}
+
+	t.Run("block_loopback", func(t *testing.T) {
+		client := newClient(EgressPolicyConfig{})
+
+		_, err := client.Get(server.URL + "/ok")
+		assert.ErrorContains(t, err, "egress denied: IP address 127.0.0.1 is in a blocked network")
+		assert.Assert(t, IsEgressDeniedError(err))
+	})
+
+	t.Run("allow_private_networks", func(t *testing.T) {
+		client := newClient(EgressPolicyConfig{
+			BlockPrivateNetworks: utils.ToPtr(false),
+		})
+
+		resp, err := client.Get(server.URL + "/ok")
+		assert.NilError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("redirect_to_denied_host", func(t *testing.T) {
+		client := newClient(EgressPolicyConfig{
+			AllowedHosts:         []string{"127.0.0.1"},
+			BlockPrivateNetworks: utils.ToPtr(false),
+		})
+
+		_, err := client.Get(server.URL + "/redirect?to=" + url.QueryEscape("http://localhost/ok"))
+		assert.ErrorContains(t, err, "egress denied: host localhost is not allowed")
+		assert.Assert(t, IsEgressDeniedError(err))
+	})
+
+	t.Run("max_redirects", func(t *testing.T) {
+		client := newClient(EgressPolicyConfig{
+			BlockPrivateNetworks: utils.ToPtr(false),
+			MaxRedirects:         utils.ToPtr[uint](0),
+		})
+
+		_, err := client.Get(server.URL + "/redirect?to=/ok")
+		assert.ErrorContains(t, err, "stopped after 0 redirects")
+	})
+
+	t.Run("base_dialer", func(t *testing.T) {
+		serverURL, err := url.Parse(server.URL)
+		assert.NilError(t, err)
+
+		baseTransport := HTTPTransportConfig{
+			Resolve: map[string][]string{"api.example.com": {"127.0.0.1"}},
+		}.ToTransport()
+		targetURL := "http://api.example.com:" + serverURL.Port() + "/ok"
+
+		allowPolicy, err := NewEgressPolicy(EgressPolicyConfig{BlockPrivateNetworks: utils.ToPtr(false)})
+		assert.NilError(t, err)
+
+		resp, err := (&http.Client{Transport: NewEgressTransport(baseTransport, allowPolicy)}).Get(targetURL)
+		assert.NilError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		blockPolicy, err := NewEgressPolicy(EgressPolicyConfig{})
+		assert.NilError(t, err)
+
+		_, err = (&http.Client{Transport: NewEgressTransport(baseTransport, blockPolicy)}).Get(targetURL)
+		assert.ErrorContains(t, err, "egress denied: IP address 127.0.0.1 is in a blocked network")
+	})
+}
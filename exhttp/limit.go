@@ -0,0 +1,132 @@
+package exhttp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ResponseTooLargeError occurs when the response body exceeds the size limit.
+type ResponseTooLargeError struct {
+	// The size limit in bytes.
+	Limit int64
+	// The limit applies to the body after decompression.
+	Decompressed bool
+}
+
+// Error implements the error interface.
+func (rte ResponseTooLargeError) Error() string {
+	if rte.Decompressed {
+		return fmt.Sprintf("decompressed response body exceeds the limit of %d bytes", rte.Limit)
+	}
+
+	return fmt.Sprintf("response body exceeds the limit of %d bytes", rte.Limit)
+}
+
+// LimitedReadCloser reads the body until the limit and fails with ResponseTooLargeError if there are remaining bytes.
+// Limit errors of inner readers are also recorded so the caller can check the cause after decoding.
+type LimitedReadCloser struct {
+	body         io.ReadCloser
+	limit        int64
+	remaining    int64
+	decompressed bool
+	err          *ResponseTooLargeError
+}
+
+// NewLimitedReadCloser creates a new LimitedReadCloser. A zero limit doesn't limit the body.
+func NewLimitedReadCloser(body io.ReadCloser, limit int64, decompressed bool) *LimitedReadCloser {
+	return &LimitedReadCloser{
+		body:         body,
+		limit:        limit,
+		remaining:    limit,
+		decompressed: decompressed,
+	}
+}
+
+// Read implements the io.Reader interface.
+func (lrc *LimitedReadCloser) Read(p []byte) (int, error) {
+	if lrc.err != nil {
+		return 0, lrc.err
+	}
+
+	if lrc.limit <= 0 {
+		n, err := lrc.body.Read(p)
+
+		return n, lrc.recordError(err)
+	}
+
+	// read one more byte than the limit to detect the overflow.
+	if int64(len(p)) > lrc.remaining+1 {
+		p = p[:lrc.remaining+1]
+	}
+
+	n, err := lrc.body.Read(p)
+	if int64(n) > lrc.remaining {
+		n = int(lrc.remaining)
+		lrc.remaining = 0
+		lrc.err = &ResponseTooLargeError{
+			Limit:        lrc.limit,
+			Decompressed: lrc.decompressed,
+		}
+
+		return n, lrc.err
+	}
+
+	lrc.remaining -= int64(n)
+
+	return n, lrc.recordError(err)
+}
+
+// Close implements the io.Closer interface.
+func (lrc *LimitedReadCloser) Close() error {
+	return lrc.body.Close()
+}
+
+// Err returns the limit error if the body or an inner reader exceeded the limit.
+func (lrc *LimitedReadCloser) Err() *ResponseTooLargeError {
+	return lrc.err
+}
+
+func (lrc *LimitedReadCloser) recordError(err error) error {
+	var limitErr *ResponseTooLargeError
+	if errors.As(err, &limitErr) {
+		lrc.err = limitErr
+	}
+
+	return err
+}
+
+// NewResponseLimitMiddleware creates a middleware that limits the size of response bodies before decompression.
+// Responses with a larger Content-Length are rejected without reading the body.
+func NewResponseLimitMiddleware(limit int64) Middleware {
+	return func(doer Doer) Doer {
+		return &responseLimitMiddleware{
+			doer:  doer,
+			limit: limit,
+		}
+	}
+}
+
+type responseLimitMiddleware struct {
+	doer  Doer
+	limit int64
+}
+
+// Do sends the request and limits the response body.
+func (rlm *responseLimitMiddleware) Do(req *http.Request) (*http.Response, error) {
+	resp, err := rlm.doer.Do(req)
+	if resp == nil || resp.Body == nil || rlm.limit <= 0 {
+		return resp, err
+	}
+
+	if resp.ContentLength > rlm.limit {
+		_ = resp.Body.Close()
+
+		return nil, &ResponseTooLargeError{Limit: rlm.limit}
+	}
+
+	resp.Body = NewLimitedReadCloser(resp.Body, rlm.limit, false)
+
+	return resp, err
+}
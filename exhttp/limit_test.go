@@ -0,0 +1,103 @@
+package exhttp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLimitedReadCloser(t *testing.T) {
+	t.Run("within_limit", func(t *testing.T) {
+		body := NewLimitedReadCloser(io.NopCloser(strings.NewReader("hello")), 5, false)
+		result, err := io.ReadAll(body)
+		assert.NilError(t, err)
+		assert.Equal(t, "hello", string(result))
+		assert.Assert(t, body.Err() == nil)
+	})
+
+	t.Run("exceed_limit", func(t *testing.T) {
+		body := NewLimitedReadCloser(io.NopCloser(strings.NewReader("hello world")), 5, true)
+		result, err := io.ReadAll(body)
+		assert.Error(t, err, "decompressed response body exceeds the limit of 5 bytes")
+		assert.Equal(t, "hello", string(result))
+		assert.DeepEqual(t, &ResponseTooLargeError{Limit: 5, Decompressed: true}, body.Err())
+	})
+
+	t.Run("record_inner_error", func(t *testing.T) {
+		inner := NewLimitedReadCloser(io.NopCloser(strings.NewReader("hello world")), 5, false)
+		body := NewLimitedReadCloser(inner, 0, true)
+		_, err := io.ReadAll(body)
+		assert.Error(t, err, "response body exceeds the limit of 5 bytes")
+		assert.DeepEqual(t, &ResponseTooLargeError{Limit: 5}, body.Err())
+	})
+}
+
+func TestResponseLimitMiddleware(t *testing.T) {
+	payload := bytes.Repeat([]byte("a"), 1024)
+
+	var compressed bytes.Buffer
+
+	gw := gzip.NewWriter(&compressed)
+	_, err := gw.Write(bytes.Repeat([]byte("0"), 1<<20))
+	assert.NilError(t, err)
+	assert.NilError(t, gw.Close())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fixed", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(payload)
+	})
+	mux.HandleFunc("/stream", func(w http.ResponseWriter, _ *http.Request) {
+		for range 4 {
+			_, _ = w.Write(payload)
+			w.(http.Flusher).Flush()
+		}
+	})
+	mux.HandleFunc("/gzip", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(contentEncodingHeader, "gzip")
+		_, _ = w.Write(compressed.Bytes())
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(http.DefaultClient, NewResponseLimitMiddleware(2048))
+
+	t.Run("content_length", func(t *testing.T) {
+		client := NewClient(http.DefaultClient, NewResponseLimitMiddleware(512))
+
+		_, err := client.Get(server.URL + "/fixed")
+		assert.Error(t, err, "response body exceeds the limit of 512 bytes")
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		resp, err := client.Get(server.URL + "/stream")
+		assert.NilError(t, err)
+
+		defer resp.Body.Close()
+
+		_, err = io.ReadAll(resp.Body)
+
+		var limitErr *ResponseTooLargeError
+		assert.Assert(t, errors.As(err, &limitErr))
+		assert.Equal(t, int64(2048), limitErr.Limit)
+	})
+
+	t.Run("gzip_bomb", func(t *testing.T) {
+		resp, err := client.Get(server.URL + "/gzip")
+		assert.NilError(t, err)
+
+		defer resp.Body.Close()
+
+		body := NewLimitedReadCloser(resp.Body, 64*1024, true)
+		result, err := io.ReadAll(body)
+		assert.Error(t, err, "decompressed response body exceeds the limit of 65536 bytes")
+		assert.Equal(t, 64*1024, len(result))
+	})
+}
@@ -0,0 +1,347 @@
+package exhttp
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4" //nolint:staticcheck
+)
+
+// ErrInvalidNTLMChallenge occurs when the server responds an invalid NTLM challenge message.
+var ErrInvalidNTLMChallenge = errors.New("invalid NTLM challenge message")
+
+const (
+	ntlmNegotiateUnicode                 uint32 = 0x00000001
+	ntlmRequestTarget                    uint32 = 0x00000004
+	ntlmNegotiateNTLM                    uint32 = 0x00000200
+	ntlmNegotiateAlwaysSign              uint32 = 0x00008000
+	ntlmNegotiateExtendedSessionSecurity uint32 = 0x00080000
+	ntlmNegotiateTargetInfo              uint32 = 0x00800000
+	ntlmNegotiate128                     uint32 = 0x20000000
+	ntlmNegotiate56                      uint32 = 0x80000000
+
+	ntlmNegotiateFlags = ntlmNegotiateUnicode | ntlmRequestTarget | ntlmNegotiateNTLM |
+		ntlmNegotiateAlwaysSign | ntlmNegotiateExtendedSessionSecurity | ntlmNegotiateTargetInfo |
+		ntlmNegotiate128 | ntlmNegotiate56
+
+	ntlmAvIDEOL       uint16 = 0
+	ntlmAvIDTimestamp uint16 = 7
+
+	// the number of 100-nanosecond intervals between 1601-01-01 and 1970-01-01.
+	ntlmEpochOffset = 116444736000000000
+)
+
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+type ntlmTransport struct {
+	transport http.RoundTripper
+	domain    string
+	username  string
+	password  string
+
+	// NTLM authenticates the connection instead of the request. Requests share the read lock so authenticated
+	// connections are used concurrently, while the handshake holds the write lock so no other request can take
+	// the idle connection between the negotiate and authenticate messages.
+	lock sync.RWMutex
+}
+
+// NewNTLMTransport creates a transport that authenticates requests with the NTLMv2 handshake.
+// The username can contain the domain with the DOMAIN\user format if the domain is empty.
+// The request body is buffered in memory to be replayed in every message of the handshake.
+func NewNTLMTransport(
+	transport http.RoundTripper,
+	domain string,
+	username string,
+	password string,
+) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if domain == "" {
+		if d, u, ok := strings.Cut(username, `\`); ok {
+			domain = d
+			username = u
+		}
+	}
+
+	return &ntlmTransport{
+		transport: transport,
+		domain:    domain,
+		username:  username,
+		password:  password,
+	}
+}
+
+// RoundTrip sends the request with NTLM authentication.
+func (nt *ntlmTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the connection may be authenticated by previous requests.
+	nt.lock.RLock()
+	resp, err := nt.transport.RoundTrip(cloneRequestWithBody(req, body))
+	nt.lock.RUnlock()
+
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenges := parseAuthChallenges(resp.Header.Values(wwwAuthenticateHeader))
+
+	scheme := "NTLM"
+	if findAuthChallenge(challenges, scheme) == nil {
+		// Negotiate falls back to NTLM if the client sends a NTLM message.
+		scheme = "Negotiate"
+		if findAuthChallenge(challenges, scheme) == nil {
+			return resp, nil
+		}
+	}
+
+	discardResponse(resp)
+
+	nt.lock.Lock()
+	defer nt.lock.Unlock()
+
+	negotiateReq := cloneRequestWithBody(req, body)
+	negotiateReq.Header.Set(
+		authorizationHeader,
+		scheme+" "+base64.StdEncoding.EncodeToString(newNTLMNegotiateMessage()),
+	)
+
+	resp, err = nt.transport.RoundTrip(negotiateReq)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := findAuthChallenge(
+		parseAuthChallenges(resp.Header.Values(wwwAuthenticateHeader)),
+		scheme,
+	)
+	if challenge == nil || challenge.Token68 == "" {
+		return resp, nil
+	}
+
+	challengeMessage, err := base64.StdEncoding.DecodeString(challenge.Token68)
+	if err != nil {
+		return resp, nil //nolint:nilerr
+	}
+
+	discardResponse(resp)
+
+	authenticateMessage, err := nt.newAuthenticateMessage(challengeMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	authReq := cloneRequestWithBody(req, body)
+	authReq.Header.Set(
+		authorizationHeader,
+		scheme+" "+base64.StdEncoding.EncodeToString(authenticateMessage),
+	)
+
+	return nt.transport.RoundTrip(authReq)
+}
+
+// newNTLMNegotiateMessage creates the NEGOTIATE_MESSAGE without domain and workstation.
+func newNTLMNegotiateMessage() []byte {
+	msg := make([]byte, 32)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], 1)
+	binary.LittleEndian.PutUint32(msg[12:], ntlmNegotiateFlags)
+
+	return msg
+}
+
+type ntlmChallengeMessage struct {
+	Flags           uint32
+	ServerChallenge []byte
+	TargetInfo      []byte
+}
+
+func parseNTLMChallengeMessage(msg []byte) (*ntlmChallengeMessage, error) {
+	if len(msg) < 32 || !bytes.Equal(msg[:8], ntlmSignature) ||
+		binary.LittleEndian.Uint32(msg[8:]) != 2 {
+		return nil, ErrInvalidNTLMChallenge
+	}
+
+	result := &ntlmChallengeMessage{
+		Flags:           binary.LittleEndian.Uint32(msg[20:]),
+		ServerChallenge: msg[24:32],
+	}
+
+	// the target info fields are available since NTLMv2.
+	if len(msg) >= 48 {
+		length := int(binary.LittleEndian.Uint16(msg[40:]))
+		offset := int(binary.LittleEndian.Uint32(msg[44:]))
+
+		if offset+length > len(msg) {
+			return nil, ErrInvalidNTLMChallenge
+		}
+
+		result.TargetInfo = msg[offset : offset+length]
+	}
+
+	return result, nil
+}
+
+func (nt *ntlmTransport) newAuthenticateMessage(rawChallenge []byte) ([]byte, error) {
+	challenge, err := parseNTLMChallengeMessage(rawChallenge)
+	if err != nil {
+		return nil, err
+	}
+
+	clientChallenge := make([]byte, 8)
+	if _, err := rand.Read(clientChallenge); err != nil {
+		return nil, err
+	}
+
+	timestamp, hasTimestamp := findNTLMTimestamp(challenge.TargetInfo)
+	if !hasTimestamp {
+		timestamp = uint64(time.Now().UnixNano()/100) + ntlmEpochOffset //nolint:gosec
+	}
+
+	ntResponse, lmResponse := computeNTLMv2Response(
+		nt.domain,
+		nt.username,
+		nt.password,
+		challenge.ServerChallenge,
+		clientChallenge,
+		timestamp,
+		challenge.TargetInfo,
+	)
+
+	// the LMv2 response must be zero if the server sends the timestamp.
+	if hasTimestamp {
+		lmResponse = make([]byte, 24)
+	}
+
+	payloads := [][]byte{
+		lmResponse,
+		ntResponse,
+		encodeUTF16LE(nt.domain),
+		encodeUTF16LE(nt.username),
+		{}, // workstation
+		{}, // encrypted random session key
+	}
+
+	const headerLength = 64
+
+	msg := make([]byte, headerLength)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], 3)
+
+	offset := headerLength
+
+	for i, payload := range payloads {
+		field := 12 + i*8
+		binary.LittleEndian.PutUint16(msg[field:], uint16(len(payload)))   //nolint:gosec
+		binary.LittleEndian.PutUint16(msg[field+2:], uint16(len(payload))) //nolint:gosec
+		binary.LittleEndian.PutUint32(msg[field+4:], uint32(offset))       //nolint:gosec
+
+		offset += len(payload)
+	}
+
+	binary.LittleEndian.PutUint32(msg[60:], challenge.Flags&ntlmNegotiateFlags)
+
+	for _, payload := range payloads {
+		msg = append(msg, payload...)
+	}
+
+	return msg, nil
+}
+
+// computeNTLMv2Response computes the NTLMv2 and LMv2 responses following [MS-NLMP] section 3.3.2.
+//
+// [MS-NLMP]: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-nlmp
+func computeNTLMv2Response(
+	domain string,
+	username string,
+	password string,
+	serverChallenge []byte,
+	clientChallenge []byte,
+	timestamp uint64,
+	targetInfo []byte,
+) ([]byte, []byte) {
+	responseKey := ntlmV2Hash(domain, username, password)
+
+	temp := make([]byte, 0, 28+len(targetInfo)+4)
+	temp = append(temp, 1, 1, 0, 0, 0, 0, 0, 0)
+	temp = binary.LittleEndian.AppendUint64(temp, timestamp)
+	temp = append(temp, clientChallenge...)
+	temp = append(temp, 0, 0, 0, 0)
+	temp = append(temp, targetInfo...)
+	temp = append(temp, 0, 0, 0, 0)
+
+	ntProofStr := hmacMD5(responseKey, serverChallenge, temp)
+	lmResponse := append(hmacMD5(responseKey, serverChallenge, clientChallenge), clientChallenge...)
+
+	return append(ntProofStr, temp...), lmResponse
+}
+
+// ntlmV2Hash computes the NTOWFv2 hash from the password.
+func ntlmV2Hash(domain string, username string, password string) []byte {
+	return hmacMD5(ntlmHash(password), encodeUTF16LE(strings.ToUpper(username)+domain))
+}
+
+// ntlmHash computes the NTOWFv1 hash, the MD4 checksum of the UTF-16 password.
+// MD4 is broken and must not be used for anything except the NTLM password hash.
+func ntlmHash(password string) []byte {
+	hash := md4.New()
+	_, _ = hash.Write(encodeUTF16LE(password))
+
+	return hash.Sum(nil)
+}
+
+// findNTLMTimestamp finds the MsvAvTimestamp value of the target info.
+func findNTLMTimestamp(targetInfo []byte) (uint64, bool) {
+	for len(targetInfo) >= 4 {
+		avID := binary.LittleEndian.Uint16(targetInfo)
+		avLen := int(binary.LittleEndian.Uint16(targetInfo[2:]))
+
+		if avID == ntlmAvIDEOL || len(targetInfo) < 4+avLen {
+			return 0, false
+		}
+
+		if avID == ntlmAvIDTimestamp && avLen == 8 {
+			return binary.LittleEndian.Uint64(targetInfo[4:]), true
+		}
+
+		targetInfo = targetInfo[4+avLen:]
+	}
+
+	return 0, false
+}
+
+func hmacMD5(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(md5.New, key)
+
+	for _, d := range data {
+		_, _ = mac.Write(d)
+	}
+
+	return mac.Sum(nil)
+}
+
+func encodeUTF16LE(value string) []byte {
+	codes := utf16.Encode([]rune(value))
+	result := make([]byte, len(codes)*2)
+
+	for i, code := range codes {
+		binary.LittleEndian.PutUint16(result[i*2:], code)
+	}
+
+	return result
+}
@@ -0,0 +1,256 @@
+package exhttp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// test vectors from [MS-NLMP] section 4.2.4.
+func TestComputeNTLMv2Response(t *testing.T) {
+	ntHash := ntlmHash("Password")
+	assert.Equal(t, "a4f49c406510bdcab6824ee7c30fd852", hex.EncodeToString(ntHash))
+	assert.Equal(
+		t,
+		"0c868a403bfd7a93a3001ef22ef02e3f",
+		hex.EncodeToString(ntlmV2Hash("Domain", "User", "Password")),
+	)
+
+	var targetInfo []byte
+
+	targetInfo = appendNTLMAvPair(targetInfo, 2, encodeUTF16LE("Domain"))
+	targetInfo = appendNTLMAvPair(targetInfo, 1, encodeUTF16LE("Server"))
+	targetInfo = appendNTLMAvPair(targetInfo, 0, nil)
+
+	serverChallenge, _ := hex.DecodeString("0123456789abcdef")
+	clientChallenge := bytes.Repeat([]byte{0xaa}, 8)
+
+	ntResponse, lmResponse := computeNTLMv2Response(
+		"Domain",
+		"User",
+		"Password",
+		serverChallenge,
+		clientChallenge,
+		0,
+		targetInfo,
+	)
+	assert.Equal(t, "68cd0ab851e51c96aabc927bebef6a1c", hex.EncodeToString(ntResponse[:16]))
+	assert.Equal(
+		t,
+		"86c35097ac9cec102554764a57cccc19aaaaaaaaaaaaaaaa",
+		hex.EncodeToString(lmResponse),
+	)
+}
+
+type mockNTLMServer struct {
+	domain   string
+	username string
+	password string
+
+	lock sync.Mutex
+	// NTLM sessions are bound to connections.
+	challenges    map[string][]byte
+	authenticated map[string]bool
+	handshakes    int
+	bodies        []string
+}
+
+func newMockNTLMServer() *mockNTLMServer {
+	return &mockNTLMServer{
+		domain:        "Domain",
+		username:      "User",
+		password:      "Password",
+		challenges:    map[string][]byte{},
+		authenticated: map[string]bool{},
+	}
+}
+
+func (mns *mockNTLMServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	mns.lock.Lock()
+	defer mns.lock.Unlock()
+
+	if mns.authenticated[r.RemoteAddr] {
+		mns.bodies = append(mns.bodies, string(body))
+		_, _ = w.Write([]byte("ok"))
+
+		return
+	}
+
+	rawToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "NTLM ")
+	if !ok {
+		w.Header().Add("WWW-Authenticate", "Negotiate")
+		w.Header().Add("WWW-Authenticate", "NTLM")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	msg, err := base64.StdEncoding.DecodeString(rawToken)
+	if err != nil || len(msg) < 12 || !bytes.Equal(msg[:8], ntlmSignature) {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	switch binary.LittleEndian.Uint32(msg[8:]) {
+	case 1:
+		mns.handshakes++
+
+		serverChallenge := []byte{1, 2, 3, 4, 5, 6, 7, byte(mns.handshakes)}
+		mns.challenges[r.RemoteAddr] = serverChallenge
+
+		var targetInfo []byte
+
+		targetInfo = appendNTLMAvPair(targetInfo, 2, encodeUTF16LE(mns.domain))
+		targetInfo = appendNTLMAvPair(targetInfo, 0, nil)
+
+		challengeMsg := make([]byte, 48)
+		copy(challengeMsg, ntlmSignature)
+		binary.LittleEndian.PutUint32(challengeMsg[8:], 2)
+		binary.LittleEndian.PutUint32(challengeMsg[20:], ntlmNegotiateFlags)
+		copy(challengeMsg[24:], serverChallenge)
+		binary.LittleEndian.PutUint16(challengeMsg[40:], uint16(len(targetInfo)))
+		binary.LittleEndian.PutUint16(challengeMsg[42:], uint16(len(targetInfo)))
+		binary.LittleEndian.PutUint32(challengeMsg[44:], 48)
+		challengeMsg = append(challengeMsg, targetInfo...)
+
+		w.Header().Set("WWW-Authenticate", "NTLM "+base64.StdEncoding.EncodeToString(challengeMsg))
+		w.WriteHeader(http.StatusUnauthorized)
+	case 3:
+		serverChallenge, ok := mns.challenges[r.RemoteAddr]
+		if !ok {
+			// the authenticate message must be sent through the connection of the challenge.
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		ntResponse := readNTLMPayload(msg, 20)
+		domain := readNTLMPayload(msg, 28)
+		user := readNTLMPayload(msg, 36)
+		responseKey := ntlmV2Hash(mns.domain, mns.username, mns.password)
+
+		if len(ntResponse) <= 16 || !bytes.Equal(domain, encodeUTF16LE(mns.domain)) ||
+			!bytes.Equal(user, encodeUTF16LE(mns.username)) ||
+			!bytes.Equal(ntResponse[:16], hmacMD5(responseKey, serverChallenge, ntResponse[16:])) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		mns.authenticated[r.RemoteAddr] = true
+		mns.bodies = append(mns.bodies, string(body))
+		_, _ = w.Write([]byte("ok"))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestNTLMTransport(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler := newMockNTLMServer()
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: NewNTLMTransport(server.Client().Transport, "", `Domain\User`, "Password"),
+		}
+
+		for range 3 {
+			resp, err := client.Post(server.URL, "text/plain", strings.NewReader("hello"))
+			assert.NilError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			discardResponse(resp)
+		}
+
+		// the authenticated connection is reused by next requests.
+		assert.Equal(t, 1, handler.handshakes)
+		assert.DeepEqual(t, []string{"hello", "hello", "hello"}, handler.bodies)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		handler := newMockNTLMServer()
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: NewNTLMTransport(server.Client().Transport, "Domain", "User", "Password"),
+		}
+
+		var wg sync.WaitGroup
+
+		statuses := make([]int, 10)
+
+		for i := range statuses {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				resp, err := client.Get(server.URL)
+				if err != nil {
+					return
+				}
+
+				statuses[i] = resp.StatusCode
+				discardResponse(resp)
+			}()
+		}
+
+		wg.Wait()
+
+		for _, status := range statuses {
+			assert.Equal(t, http.StatusOK, status)
+		}
+	})
+
+	t.Run("invalid_password", func(t *testing.T) {
+		handler := newMockNTLMServer()
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: NewNTLMTransport(server.Client().Transport, "Domain", "User", "invalid"),
+		}
+
+		resp, err := client.Get(server.URL)
+		assert.NilError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		discardResponse(resp)
+		assert.Equal(t, 1, handler.handshakes)
+	})
+}
+
+func appendNTLMAvPair(targetInfo []byte, avID uint16, value []byte) []byte {
+	targetInfo = binary.LittleEndian.AppendUint16(targetInfo, avID)
+	targetInfo = binary.LittleEndian.AppendUint16(targetInfo, uint16(len(value)))
+
+	return append(targetInfo, value...)
+}
+
+func readNTLMPayload(msg []byte, field int) []byte {
+	length := int(binary.LittleEndian.Uint16(msg[field:]))
+	offset := int(binary.LittleEndian.Uint32(msg[field+4:]))
+
+	if offset+length > len(msg) {
+		return nil
+	}
+
+	return msg[offset : offset+length]
+}
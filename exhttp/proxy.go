@@ -0,0 +1,237 @@
+package exhttp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+)
+
+// ErrInvalidProxyScheme occurs when the scheme of the proxy URL is not supported.
+var ErrInvalidProxyScheme = errors.New(
+	"invalid proxy URL scheme, expected one of http, https, socks5, socks5h",
+)
+
+// ProxyConfig represents the outbound proxy configuration of HTTP clients.
+// The proxy replaces the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+type ProxyConfig struct {
+	// URL of the proxy server. Supported schemes are http, https (HTTP CONNECT), socks5 and socks5h.
+	// Requests are sent directly if the URL is empty.
+	URL goenvconf.EnvString `json:"url" mapstructure:"url" yaml:"url"`
+	// Username for the proxy authentication.
+	Username *goenvconf.EnvString `json:"username,omitempty" mapstructure:"username" yaml:"username,omitempty"`
+	// Password for the proxy authentication.
+	Password *goenvconf.EnvString `json:"password,omitempty" mapstructure:"password" yaml:"password,omitempty"`
+	// Hosts that are connected directly without the proxy. Each entry is a host name, a domain suffix (.example.com),
+	// an IP address or a CIDR range, with an optional port. The wildcard (*) matches all hosts.
+	NoProxy []string `json:"noProxy,omitempty" mapstructure:"noProxy" yaml:"noProxy,omitempty"`
+}
+
+// Validate if the current instance is valid.
+func (pc ProxyConfig) Validate() error {
+	_, err := pc.GetURL()
+
+	return err
+}
+
+// GetURL evaluates the proxy URL with credentials. Returns nil if the URL is empty.
+func (pc ProxyConfig) GetURL() (*url.URL, error) {
+	rawURL, err := pc.URL.GetOrDefault("")
+	if err != nil {
+		return nil, fmt.Errorf("ProxyConfig.url: %w", err)
+	}
+
+	if rawURL == "" {
+		return nil, nil
+	}
+
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("ProxyConfig.url: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("ProxyConfig.url: %w, got: %s", ErrInvalidProxyScheme, proxyURL.Scheme)
+	}
+
+	if proxyURL.Host == "" {
+		return nil, errors.New("ProxyConfig.url: host is required")
+	}
+
+	var username, password string
+
+	if pc.Username != nil {
+		username, err = pc.Username.GetOrDefault("")
+		if err != nil {
+			return nil, fmt.Errorf("ProxyConfig.username: %w", err)
+		}
+	}
+
+	if pc.Password != nil {
+		password, err = pc.Password.GetOrDefault("")
+		if err != nil {
+			return nil, fmt.Errorf("ProxyConfig.password: %w", err)
+		}
+	}
+
+	if username != "" || password != "" {
+		proxyURL.User = url.UserPassword(username, password)
+	}
+
+	return proxyURL, nil
+}
+
+// ProxyFunc returns the proxy function of the HTTP transport.
+func (pc ProxyConfig) ProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	proxyURL, err := pc.GetURL()
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyURL == nil {
+		return nil, nil
+	}
+
+	noProxy := make([]noProxyMatcher, 0, len(pc.NoProxy))
+
+	for _, entry := range pc.NoProxy {
+		matcher, err := parseNoProxyEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("ProxyConfig.noProxy: %w", err)
+		}
+
+		if matcher != nil {
+			noProxy = append(noProxy, *matcher)
+		}
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		host, port := req.URL.Hostname(), req.URL.Port()
+		if port == "" {
+			port = defaultPortOfScheme(req.URL.Scheme)
+		}
+
+		for _, matcher := range noProxy {
+			if matcher.Match(host, port) {
+				return nil, nil
+			}
+		}
+
+		return proxyURL, nil
+	}, nil
+}
+
+// NewProxyTransport creates a new HTTP transport with the proxy configuration.
+func NewProxyTransport(
+	baseTransport http.RoundTripper,
+	proxyConfig *ProxyConfig,
+) (*http.Transport, error) {
+	bTransport, ok := baseTransport.(*http.Transport)
+	if !ok {
+		bTransport, _ = http.DefaultTransport.(*http.Transport)
+	}
+
+	proxy, err := proxyConfig.ProxyFunc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proxy config: %w", err)
+	}
+
+	transport := bTransport.Clone()
+	transport.Proxy = proxy
+
+	return transport, nil
+}
+
+type noProxyMatcher struct {
+	all    bool
+	prefix *netip.Prefix
+	ip     *netip.Addr
+	// the domain suffix with the leading dot matches sub-domains only.
+	domain string
+	port   string
+}
+
+func parseNoProxyEntry(entry string) (*noProxyMatcher, error) {
+	entry = strings.ToLower(strings.TrimSpace(entry))
+	if entry == "" {
+		return nil, nil
+	}
+
+	if entry == "*" {
+		return &noProxyMatcher{all: true}, nil
+	}
+
+	if prefix, err := netip.ParsePrefix(entry); err == nil {
+		return &noProxyMatcher{prefix: &prefix}, nil
+	}
+
+	result := &noProxyMatcher{}
+
+	if host, port, err := net.SplitHostPort(entry); err == nil {
+		entry = host
+		result.port = port
+	}
+
+	if ip, err := netip.ParseAddr(strings.Trim(entry, "[]")); err == nil {
+		result.ip = &ip
+
+		return result, nil
+	}
+
+	if strings.Contains(entry, "/") {
+		return nil, fmt.Errorf("invalid entry: %s", entry)
+	}
+
+	result.domain = strings.TrimPrefix(entry, "*")
+
+	return result, nil
+}
+
+// Match checks if the host and port are connected directly.
+func (npm noProxyMatcher) Match(host string, port string) bool {
+	if npm.all {
+		return true
+	}
+
+	if npm.port != "" && npm.port != port {
+		return false
+	}
+
+	host = strings.ToLower(host)
+
+	if npm.prefix != nil || npm.ip != nil {
+		ip, err := netip.ParseAddr(host)
+		if err != nil {
+			return false
+		}
+
+		ip = ip.Unmap()
+
+		if npm.prefix != nil {
+			return npm.prefix.Contains(ip)
+		}
+
+		return npm.ip.Unmap() == ip
+	}
+
+	if strings.HasPrefix(npm.domain, ".") {
+		return strings.HasSuffix(host, npm.domain)
+	}
+
+	return host == npm.domain || strings.HasSuffix(host, "."+npm.domain)
+}
+
+func defaultPortOfScheme(scheme string) string {
+	if scheme == "https" {
+		return "443"
+	}
+
+	return "80"
+}
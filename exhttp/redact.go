@@ -0,0 +1,262 @@
+package exhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hasura/ndc-sdk-go/v2/connector"
+	"github.com/theory/jsonpath"
+	"github.com/theory/jsonpath/spec"
+)
+
+// defaultSensitiveHeaderRegex matches names of headers that are always masked.
+var defaultSensitiveHeaderRegex = regexp.MustCompile(`auth|key|secret|token`)
+
+var maskedQueryReplacer = strings.NewReplacer("%2A", "*", "%28", "(", "%29", ")")
+
+// RedactionConfig holds rules to mask sensitive data in logs, traces and explain output.
+type RedactionConfig struct {
+	// Regular expressions of sensitive header names in addition to the default pattern auth|key|secret|token.
+	// Header names are matched in lower case.
+	Headers []string `json:"headers,omitempty" mapstructure:"headers" yaml:"headers,omitempty"`
+	// Regular expressions of sensitive query parameter names. Parameter names are matched in lower case.
+	QueryParams []string `json:"queryParams,omitempty" mapstructure:"queryParams" yaml:"queryParams,omitempty"`
+	// JSONPath expressions of sensitive fields in JSON request and response bodies, e.g. $..cardNumber.
+	BodyFields []string `json:"bodyFields,omitempty" mapstructure:"bodyFields" yaml:"bodyFields,omitempty"`
+}
+
+// Redactor masks sensitive headers, query parameters and body fields.
+// A nil Redactor only masks headers of the default pattern.
+type Redactor struct {
+	headers     []*regexp.Regexp
+	queryParams []*regexp.Regexp
+	bodyFields  []*jsonpath.Path
+}
+
+// NewRedactor validates the configuration and creates a new Redactor instance.
+func NewRedactor(config RedactionConfig) (*Redactor, error) {
+	redactor := &Redactor{}
+
+	for i, pattern := range config.Headers {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("headers[%d]: %w", i, err)
+		}
+
+		redactor.headers = append(redactor.headers, re)
+	}
+
+	for i, pattern := range config.QueryParams {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("queryParams[%d]: %w", i, err)
+		}
+
+		redactor.queryParams = append(redactor.queryParams, re)
+	}
+
+	for i, rawPath := range config.BodyFields {
+		path, err := jsonpath.Parse(rawPath)
+		if err != nil {
+			return nil, fmt.Errorf("bodyFields[%d]: %w", i, err)
+		}
+
+		redactor.bodyFields = append(redactor.bodyFields, path)
+	}
+
+	return redactor, nil
+}
+
+// IsSensitiveHeader checks if the header name is sensitive.
+func (r *Redactor) IsSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	if defaultSensitiveHeaderRegex.MatchString(name) {
+		return true
+	}
+
+	return r != nil && matchAnyRegex(r.headers, name)
+}
+
+// RedactHeaders returns a copy of headers with sensitive values masked.
+func (r *Redactor) RedactHeaders(headers http.Header) http.Header {
+	result := make(http.Header, len(headers))
+
+	for key, values := range headers {
+		if !r.IsSensitiveHeader(key) {
+			result[key] = values
+
+			continue
+		}
+
+		masked := make([]string, len(values))
+		for i, value := range values {
+			masked[i] = connector.MaskString(value)
+		}
+
+		result[key] = masked
+	}
+
+	return result
+}
+
+// RedactURL returns the URL string with the user information and sensitive query parameters masked.
+func (r *Redactor) RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	result := *u
+
+	if result.User != nil {
+		maskedUser := strings.Repeat("x", len(result.User.Username()))
+		if password, hasPassword := result.User.Password(); hasPassword {
+			result.User = url.UserPassword(maskedUser, strings.Repeat("x", len(password)))
+		} else {
+			result.User = url.User(maskedUser)
+		}
+	}
+
+	if r == nil || len(r.queryParams) == 0 || result.RawQuery == "" {
+		return result.String()
+	}
+
+	query := result.Query()
+	masked := false
+
+	for key, values := range query {
+		if !matchAnyRegex(r.queryParams, strings.ToLower(key)) {
+			continue
+		}
+
+		for i, value := range values {
+			values[i] = connector.MaskString(value)
+		}
+
+		masked = true
+	}
+
+	if masked {
+		// mask characters are valid in the query, so they are kept readable.
+		result.RawQuery = maskedQueryReplacer.Replace(query.Encode())
+	}
+
+	return result.String()
+}
+
+// RedactBody masks sensitive fields of the JSON body. Other bodies are returned as they are.
+func (r *Redactor) RedactBody(body []byte) string {
+	if r == nil || len(r.bodyFields) == 0 || !json.Valid(body) {
+		return string(body)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+
+	for _, path := range r.bodyFields {
+		for node := range path.SelectLocated(value).All() {
+			value = setJSONPathValue(value, node.Path, maskJSONValue(node.Node))
+		}
+	}
+
+	result, err := json.Marshal(value)
+	if err != nil {
+		return string(body)
+	}
+
+	return string(result)
+}
+
+type redactorContextKey struct{}
+
+// ContextWithRedactor returns a copy of the context with the redactor that the telemetry transport uses.
+func ContextWithRedactor(ctx context.Context, redactor *Redactor) context.Context {
+	return context.WithValue(ctx, redactorContextKey{}, redactor)
+}
+
+// GetRedactor gets the redactor from the context. Returns nil if not exist.
+func GetRedactor(ctx context.Context) *Redactor {
+	redactor, _ := ctx.Value(redactorContextKey{}).(*Redactor)
+
+	return redactor
+}
+
+func matchAnyRegex(patterns []*regexp.Regexp, value string) bool {
+	for _, re := range patterns {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func maskJSONValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return connector.MaskString(v)
+	case json.Number:
+		return connector.MaskString(v.String())
+	default:
+		// objects and arrays are masked as a whole.
+		return connector.MaskString(fmt.Sprint(v))
+	}
+}
+
+// setJSONPathValue replaces the value at the normalized path and returns the root value.
+func setJSONPathValue(root any, path spec.NormalizedPath, value any) any {
+	if len(path) == 0 {
+		return value
+	}
+
+	parent := root
+
+	for i, selector := range path {
+		isLast := i == len(path)-1
+
+		switch sel := selector.(type) {
+		case spec.Name:
+			obj, ok := parent.(map[string]any)
+			if !ok {
+				return root
+			}
+
+			if isLast {
+				obj[string(sel)] = value
+
+				return root
+			}
+
+			parent = obj[string(sel)]
+		case spec.Index:
+			arr, ok := parent.([]any)
+			if !ok || int(sel) >= len(arr) {
+				return root
+			}
+
+			if isLast {
+				arr[sel] = value
+
+				return root
+			}
+
+			parent = arr[sel]
+		default:
+			return root
+		}
+	}
+
+	return root
+}
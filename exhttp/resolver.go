@@ -0,0 +1,126 @@
+package exhttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+)
+
+// validateResolver validates static addresses and DNS servers of the dialer.
+func validateResolver(resolve map[string][]string, dnsServers []string) error {
+	for host, addresses := range resolve {
+		if len(addresses) == 0 {
+			return fmt.Errorf("resolve.%s: addresses are required", host)
+		}
+
+		for _, address := range addresses {
+			if _, err := netip.ParseAddr(address); err != nil {
+				return fmt.Errorf("resolve.%s: %w", host, err)
+			}
+		}
+	}
+
+	for i, server := range dnsServers {
+		if _, err := parseDNSServerAddress(server); err != nil {
+			return fmt.Errorf("dnsServers[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// resolverDialer dials static addresses of overridden hosts and resolves other hosts with custom DNS servers.
+type resolverDialer struct {
+	dialer  *net.Dialer
+	resolve map[string][]string
+}
+
+// newResolverDialer wraps the dialer with static addresses and custom DNS servers.
+// Invalid addresses are ignored, they should be validated in advance.
+func newResolverDialer(
+	dialer *net.Dialer,
+	staticAddresses map[string][]string,
+	dnsServerAddresses []string,
+) *resolverDialer {
+	resolve := make(map[string][]string, len(staticAddresses))
+
+	for host, addresses := range staticAddresses {
+		for _, address := range addresses {
+			if ip, err := netip.ParseAddr(address); err == nil {
+				key := strings.ToLower(host)
+				resolve[key] = append(resolve[key], ip.String())
+			}
+		}
+	}
+
+	dnsServers := make([]string, 0, len(dnsServerAddresses))
+
+	for _, server := range dnsServerAddresses {
+		if address, err := parseDNSServerAddress(server); err == nil {
+			dnsServers = append(dnsServers, address)
+		}
+	}
+
+	if len(dnsServers) > 0 {
+		var counter atomic.Uint32
+
+		dnsDialer := &net.Dialer{Timeout: dialer.Timeout}
+		dialer.Resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				// rotate DNS servers to spread lookups.
+				server := dnsServers[int(counter.Add(1)-1)%len(dnsServers)]
+
+				return dnsDialer.DialContext(ctx, network, server)
+			},
+		}
+	}
+
+	return &resolverDialer{
+		dialer:  dialer,
+		resolve: resolve,
+	}
+}
+
+// DialContext connects to the address. Static addresses of the host are tried in order.
+func (rd *resolverDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return rd.dialer.DialContext(ctx, network, address)
+	}
+
+	addresses, ok := rd.resolve[strings.ToLower(host)]
+	if !ok {
+		return rd.dialer.DialContext(ctx, network, address)
+	}
+
+	var errs []error
+
+	for _, ip := range addresses {
+		conn, err := rd.dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+func parseDNSServerAddress(server string) (string, error) {
+	if _, err := netip.ParseAddrPort(server); err == nil {
+		return server, nil
+	}
+
+	ip, err := netip.ParseAddr(strings.Trim(server, "[]"))
+	if err != nil {
+		return "", fmt.Errorf("invalid DNS server address: %s", server)
+	}
+
+	return net.JoinHostPort(ip.String(), "53"), nil
+}
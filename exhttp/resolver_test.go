@@ -0,0 +1,168 @@
+package exhttp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gotest.tools/v3/assert"
+)
+
+func TestResolverTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host + " " + r.TLS.ServerName))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	assert.NilError(t, err)
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(server.Certificate())
+
+	// the certificate of the test server is issued for example.com.
+	transport := HTTPTransportConfig{
+		Resolve: map[string][]string{
+			"Example.com": {"127.0.0.2", "127.0.0.1"},
+		},
+	}.ToTransport()
+	transport.TLSClientConfig = &tls.Config{RootCAs: certPool, MinVersion: tls.VersionTLS12}
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client := NewTelemetryTransport(transport, TelemetryConfig{
+		Tracer: tracerProvider.Tracer("test"),
+	})
+	assert.Equal(
+		t,
+		"example.com:"+serverURL.Port()+" example.com",
+		sendTestRequest(t, client, "https://example.com:"+serverURL.Port()),
+	)
+
+	spans := exporter.GetSpans()
+	assert.Equal(t, 1, len(spans))
+
+	attributes := attribute.NewSet(spans[0].Attributes...)
+	serverAddress, _ := attributes.Value("server.address")
+	assert.Equal(t, "example.com", serverAddress.AsString())
+
+	peerAddress, _ := attributes.Value("network.peer.address")
+	assert.Equal(t, "127.0.0.1", peerAddress.AsString())
+
+	peerPort, _ := attributes.Value("network.peer.port")
+	assert.Equal(t, serverURL.Port(), peerPort.Emit())
+}
+
+func TestResolverDNSServers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	assert.NilError(t, err)
+
+	dnsAddress := startMockDNSServer(t, net.IPv4(127, 0, 0, 1))
+
+	transport := HTTPTransportConfig{
+		DNSServers: []string{dnsAddress},
+	}.ToTransport()
+
+	rawURL := "http://api.partner.test:" + serverURL.Port()
+	assert.Equal(t, "api.partner.test:"+serverURL.Port(), sendTestRequest(t, transport, rawURL))
+}
+
+func TestResolverValidate(t *testing.T) {
+	assert.NilError(t, HTTPTransportConfig{
+		Resolve:    map[string][]string{"api.partner.com": {"10.0.0.10", "::1"}},
+		DNSServers: []string{"10.0.0.2", "10.0.0.3:5353", "[::1]:53"},
+	}.Validate())
+
+	assert.ErrorContains(t, HTTPTransportConfig{
+		Resolve: map[string][]string{"api.partner.com": {"partner.internal"}},
+	}.Validate(), "resolve.api.partner.com")
+
+	assert.ErrorContains(t, HTTPTransportConfig{
+		Resolve: map[string][]string{"api.partner.com": {}},
+	}.Validate(), "addresses are required")
+
+	assert.ErrorContains(t, HTTPTransportConfig{
+		DNSServers: []string{"dns.google"},
+	}.Validate(), "dnsServers[0]: invalid DNS server address")
+}
+
+// startMockDNSServer starts a UDP DNS server that answers A questions with the IP address.
+func startMockDNSServer(t *testing.T, ip net.IP) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	go func() {
+		buf := make([]byte, 512)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			if response := buildMockDNSResponse(buf[:n], ip); response != nil {
+				_, _ = conn.WriteTo(response, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func buildMockDNSResponse(query []byte, ip net.IP) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+
+	// skip the question name.
+	offset := 12
+	for offset < len(query) && query[offset] != 0 {
+		offset += int(query[offset]) + 1
+	}
+
+	questionEnd := offset + 5
+	if questionEnd > len(query) {
+		return nil
+	}
+
+	qType := binary.BigEndian.Uint16(query[offset+1:])
+	response := make([]byte, 0, questionEnd+16)
+	response = append(response, query[:2]...)
+	// standard response without errors.
+	response = append(response, 0x81, 0x80)
+	response = binary.BigEndian.AppendUint16(response, 1)
+
+	if qType != 1 {
+		// answer AAAA questions with no records.
+		response = append(response, 0, 0, 0, 0, 0, 0)
+
+		return append(response, query[12:questionEnd]...)
+	}
+
+	response = append(response, 0, 1, 0, 0, 0, 0)
+	response = append(response, query[12:questionEnd]...)
+	// the pointer to the question name, type A, class IN, TTL 60 seconds.
+	response = append(response, 0xc0, 0x0c, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4)
+
+	return append(response, ip.To4()...)
+}
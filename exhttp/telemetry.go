@@ -5,11 +5,12 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
-	"net/url"
+	"net/http/httptrace"
+	"strconv"
 	"strings"
 
-	"github.com/hasura/ndc-sdk-go/v2/connector"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -49,22 +50,20 @@ func (tt TelemetryConfig) do(
 	)
 	defer span.End()
 
+	// record the dialed address that may be overridden by static DNS settings.
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			setNetworkPeerAttributes(span, info.Conn.RemoteAddr())
+		},
+	}))
+
 	port := tt.Port
 	if port == 0 {
 		port, _ = ParsePort(req.URL.Port(), req.URL.Scheme)
 	}
 
-	urlAttr := *req.URL
-	password, hasPassword := urlAttr.User.Password()
-
-	if urlAttr.User.String() != "" || hasPassword {
-		maskedUser := strings.Repeat("x", len(urlAttr.User.Username()))
-		if hasPassword {
-			urlAttr.User = url.UserPassword(maskedUser, strings.Repeat("x", len(password)))
-		} else {
-			urlAttr.User = url.User(maskedUser)
-		}
-	}
+	redactor := GetRedactor(ctx)
+	urlAttr := redactor.RedactURL(req.URL)
 
 	if len(tt.Attributes) > 0 {
 		span.SetAttributes(tt.Attributes...)
@@ -72,13 +71,13 @@ func (tt TelemetryConfig) do(
 
 	span.SetAttributes(
 		attribute.String("http.request.method", req.Method),
-		attribute.String("url.full", req.URL.String()),
+		attribute.String("url.full", urlAttr),
 		attribute.String("server.address", req.URL.Hostname()),
 		attribute.Int("server.port", port),
 		attribute.String("network.protocol.name", "http"),
 	)
 
-	connector.SetSpanHeaderAttributes(span, "http.request.header.", req.Header)
+	setSpanHeaderAttributes(span, "http.request.header.", redactor.RedactHeaders(req.Header))
 
 	if req.ContentLength > 0 {
 		span.SetAttributes(attribute.Int64("http.request.body.size", req.ContentLength))
@@ -87,9 +86,9 @@ func (tt TelemetryConfig) do(
 	isDebug := tt.isDebug(ctx)
 	tt.Propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
 	requestLogAttrs := map[string]any{
-		"url":     req.URL.String(),
+		"url":     urlAttr,
 		"method":  req.Method,
-		"headers": connector.NewTelemetryHeaders(req.Header),
+		"headers": redactor.RedactHeaders(req.Header),
 	}
 
 	if isDebug && req.Body != nil && req.ContentLength > 0 && req.ContentLength <= 100*1024 {
@@ -98,7 +97,7 @@ func (tt TelemetryConfig) do(
 			return nil, err
 		}
 
-		requestLogAttrs["body"] = string(rawBody)
+		requestLogAttrs["body"] = redactor.RedactBody(rawBody)
 
 		_ = req.Body.Close()
 		req.Body = io.NopCloser(bytes.NewBuffer(rawBody))
@@ -123,7 +122,7 @@ func (tt TelemetryConfig) do(
 	}
 
 	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
-	connector.SetSpanHeaderAttributes(span, "http.response.header.", resp.Header)
+	setSpanHeaderAttributes(span, "http.response.header.", redactor.RedactHeaders(resp.Header))
 
 	if resp.ContentLength >= 0 {
 		span.SetAttributes(attribute.Int64("http.response.size", resp.ContentLength))
@@ -131,7 +130,7 @@ func (tt TelemetryConfig) do(
 
 	respLogAttrs := map[string]any{
 		"http_status": resp.StatusCode,
-		"headers":     resp.Header,
+		"headers":     redactor.RedactHeaders(resp.Header),
 	}
 
 	if isDebug && resp.ContentLength > 0 && resp.ContentLength < 1024*1024 && resp.Body != nil {
@@ -153,7 +152,7 @@ func (tt TelemetryConfig) do(
 			return resp, err
 		}
 
-		respLogAttrs["body"] = string(respBody)
+		respLogAttrs["body"] = redactor.RedactBody(respBody)
 		resp.Body = io.NopCloser(bytes.NewBuffer(respBody))
 
 		span.SetAttributes(attribute.Int("http.response.size", len(respBody)))
@@ -169,6 +168,13 @@ func (tt TelemetryConfig) do(
 	return resp, err
 }
 
+// setSpanHeaderAttributes sets headers to span attributes. Sensitive values must be masked by the caller.
+func setSpanHeaderAttributes(span trace.Span, prefix string, headers http.Header) {
+	for key, values := range headers {
+		span.SetAttributes(attribute.StringSlice(prefix+strings.ToLower(key), values))
+	}
+}
+
 func (tt TelemetryConfig) getRequestSpanName(req *http.Request) string {
 	spanName := req.Method
 	if !tt.DisableHighCardinalityPath {
@@ -243,3 +249,22 @@ func NewTelemetryMiddleware(config TelemetryConfig) Middleware {
 func (tm TelemetryMiddleware) Do(req *http.Request) (*http.Response, error) {
 	return tm.do(tm.doer.Do, req)
 }
+
+func setNetworkPeerAttributes(span trace.Span, addr net.Addr) {
+	if addr == nil || addr.String() == "" {
+		return
+	}
+
+	host, rawPort, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		span.SetAttributes(attribute.String("network.peer.address", addr.String()))
+
+		return
+	}
+
+	span.SetAttributes(attribute.String("network.peer.address", host))
+
+	if port, err := strconv.Atoi(rawPort); err == nil {
+		span.SetAttributes(attribute.Int("network.peer.port", port))
+	}
+}
@@ -0,0 +1,17 @@
+#!/bin/bash
+
+set -e
+
+pushd `dirname $0` > /dev/null
+
+openssl req -x509 -newkey rsa:2048 -nodes -days 3650 \
+  -keyout client.key -out client.crt \
+  -subj "/O=Random Company/CN=pkcs12-client"
+
+# PKCS#12 bundles with AES-256 (default) and legacy RC2/3DES encryption.
+openssl pkcs12 -export -in client.crt -inkey client.key -out client.p12 -passout pass:p12secret
+openssl pkcs12 -export -legacy -in client.crt -inkey client.key -out client-legacy.p12 -passout pass:p12secret
+
+rm client.key
+
+popd > /dev/null
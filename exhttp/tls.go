@@ -82,6 +82,17 @@ type TLSConfig struct {
 	// This sets the ServerName in the TLSConfig. Please refer to
 	// https://godoc.org/crypto/tls#Config for more information. (optional)
 	ServerName *goenvconf.EnvString `json:"serverName,omitempty" mapstructure:"serverName" yaml:"serverName,omitempty"`
+	// Path to the PKCS#12 (.p12, .pfx) bundle that contains the client certificate and key.
+	// Alternative to the certificate and key files.
+	PKCS12File *goenvconf.EnvString `json:"pkcs12File,omitempty" mapstructure:"pkcs12File" yaml:"pkcs12File,omitempty"`
+	// Alternative to pkcs12File. Provide the PKCS#12 bundle contents as a base64-encoded string instead of a filepath.
+	PKCS12Pem *goenvconf.EnvString `json:"pkcs12Pem,omitempty" mapstructure:"pkcs12Pem" yaml:"pkcs12Pem,omitempty"`
+	// Password to decrypt the PKCS#12 bundle.
+	PKCS12Password *goenvconf.EnvString `json:"pkcs12Password,omitempty" mapstructure:"pkcs12Password" yaml:"pkcs12Password,omitempty"`
+	// Base64-encoded SHA-256 hashes of the subject public key info (SPKI) of trusted certificates.
+	// The connection is rejected if no certificate in the verified chain matches any pin.
+	// The optional sha256/ prefix is allowed, e.g. sha256/YLh1dUR9y6Kja30RrAn7JKnbQG/uEtLMkBgFF2Fuihg=.
+	PinnedPublicKeys []string `json:"pinnedPublicKeys,omitempty" mapstructure:"pinnedPublicKeys" yaml:"pinnedPublicKeys,omitempty"`
 }
 
 // Validate if the current instance is valid.
@@ -156,6 +167,14 @@ func (tc TLSConfig) Validate() error {
 		}
 	}
 
+	if err := tc.validatePKCS12(); err != nil {
+		return err
+	}
+
+	if _, err := parsePinnedPublicKeys(tc.PinnedPublicKeys); err != nil {
+		return fmt.Errorf("TLSConfig.pinnedPublicKeys: %w", err)
+	}
+
 	if tc.IncludeSystemCACertsPool != nil {
 		_, err := tc.IncludeSystemCACertsPool.GetOrDefault(false)
 		if err != nil {
@@ -173,6 +192,54 @@ func (tc TLSConfig) Validate() error {
 	return nil
 }
 
+func (tc TLSConfig) validatePKCS12() error {
+	if tc.PKCS12File == nil && tc.PKCS12Pem == nil {
+		return nil
+	}
+
+	var pkcs12File, pkcs12Pem string
+
+	var err error
+
+	if tc.PKCS12File != nil {
+		pkcs12File, err = tc.PKCS12File.GetOrDefault("")
+		if err != nil {
+			return fmt.Errorf("TLSConfig.pkcs12File: %w", err)
+		}
+	}
+
+	if tc.PKCS12Pem != nil {
+		pkcs12Pem, err = tc.PKCS12Pem.GetOrDefault("")
+		if err != nil {
+			return fmt.Errorf("TLSConfig.pkcs12Pem: %w", err)
+		}
+	}
+
+	if pkcs12File != "" && pkcs12Pem != "" {
+		return errors.New(
+			"invalid TLS configuration: provide either a PKCS#12 file or the base64-encoded string, but not both",
+		)
+	}
+
+	if pkcs12File == "" && pkcs12Pem == "" {
+		return nil
+	}
+
+	for _, value := range []*goenvconf.EnvString{tc.CertFile, tc.CertPem, tc.KeyFile, tc.KeyPem} {
+		if value == nil {
+			continue
+		}
+
+		if v, err := value.GetOrDefault(""); err == nil && v != "" {
+			return errors.New(
+				"for auth via TLS, provide either a PKCS#12 bundle or the certificate and key, but not both",
+			)
+		}
+	}
+
+	return nil
+}
+
 // GetMinVersion parses the minx TLS version from string.
 func (tc TLSConfig) GetMinVersion() (uint16, error) {
 	return tc.convertTLSVersion(tc.MinVersion, defaultMinTLSVersion)
@@ -183,6 +250,28 @@ func (tc TLSConfig) GetMaxVersion() (uint16, error) {
 	return tc.convertTLSVersion(tc.MinVersion, defaultMaxTLSVersion)
 }
 
+// GetFilePaths returns paths of the certificate, key, CA and PKCS#12 files that are loaded into the TLS config.
+func (tc TLSConfig) GetFilePaths() ([]string, error) {
+	var results []string
+
+	for _, file := range []*goenvconf.EnvString{tc.CertFile, tc.KeyFile, tc.CAFile, tc.PKCS12File} {
+		if file == nil {
+			continue
+		}
+
+		filePath, err := file.GetOrDefault("")
+		if err != nil {
+			return nil, err
+		}
+
+		if filePath != "" {
+			results = append(results, filePath)
+		}
+	}
+
+	return results, nil
+}
+
 func (tc TLSConfig) convertTLSVersion(v string, defaultVersion uint16) (uint16, error) {
 	// Use a default that is explicitly defined
 	if v == "" {
@@ -237,16 +326,28 @@ func loadTLSConfig(tlsConfig *TLSConfig, logger *slog.Logger) (*tls.Config, erro
 		}
 	}
 
-	cert, err := loadCertificate(tlsConfig, insecureSkipVerify, logger)
+	pinnedPublicKeys, err := parsePinnedPublicKeys(tlsConfig.PinnedPublicKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS pinnedPublicKeys: %w", err)
+	}
+
+	cert, err := loadPKCS12Certificate(tlsConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	if cert == nil {
+		cert, err = loadCertificate(tlsConfig, insecureSkipVerify, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var certificates []tls.Certificate
 
 	if cert != nil {
 		certificates = append(certificates, *cert)
-	} else if !insecureSkipVerify {
+	} else if !insecureSkipVerify && len(pinnedPublicKeys) == 0 {
 		return nil, nil
 	}
 
@@ -260,6 +361,10 @@ func loadTLSConfig(tlsConfig *TLSConfig, logger *slog.Logger) (*tls.Config, erro
 		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec
 	}
 
+	if len(pinnedPublicKeys) > 0 {
+		result.VerifyConnection = newPinnedPublicKeysVerifier(pinnedPublicKeys)
+	}
+
 	return result, nil
 }
 
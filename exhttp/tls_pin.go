@@ -0,0 +1,159 @@
+package exhttp
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var (
+	// ErrPinnedPublicKeyMismatch occurs when no certificate of the server matches pinned public keys.
+	ErrPinnedPublicKeyMismatch = errors.New("tls: no certificate matches pinned public keys")
+	// ErrIncorrectPKCS12Password occurs when the PKCS#12 bundle can't be decrypted with the password.
+	ErrIncorrectPKCS12Password = pkcs12.ErrIncorrectPassword
+)
+
+// ValidatePinnedPublicKeys validates that pins are base64-encoded SHA-256 hashes.
+func ValidatePinnedPublicKeys(pins []string) error {
+	_, err := parsePinnedPublicKeys(pins)
+
+	return err
+}
+
+// parsePinnedPublicKeys decodes base64-encoded SHA-256 hashes of the subject public key info.
+func parsePinnedPublicKeys(pins []string) (map[[sha256.Size]byte]bool, error) {
+	if len(pins) == 0 {
+		return nil, nil
+	}
+
+	results := make(map[[sha256.Size]byte]bool, len(pins))
+
+	for i, pin := range pins {
+		rawPin := strings.TrimPrefix(strings.TrimSpace(pin), "sha256/")
+
+		digest, err := base64.StdEncoding.DecodeString(rawPin)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+
+		if len(digest) != sha256.Size {
+			return nil, fmt.Errorf("[%d]: expected a SHA-256 hash of %d bytes, got %d", i, sha256.Size, len(digest))
+		}
+
+		results[[sha256.Size]byte(digest)] = true
+	}
+
+	return results, nil
+}
+
+// newPinnedPublicKeysVerifier creates a function to verify that a certificate of the server chain matches a pinned public key.
+// Verified chains are checked if the chain verification is enabled, otherwise peer certificates of the server.
+// The function is used as VerifyConnection that also runs on resumed sessions, unlike VerifyPeerCertificate.
+func newPinnedPublicKeysVerifier(
+	pins map[[sha256.Size]byte]bool,
+) func(cs tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		for _, chain := range cs.VerifiedChains {
+			for _, cert := range chain {
+				if pins[sha256.Sum256(cert.RawSubjectPublicKeyInfo)] {
+					return nil
+				}
+			}
+		}
+
+		if len(cs.VerifiedChains) > 0 {
+			return ErrPinnedPublicKeyMismatch
+		}
+
+		// without a verified chain, only the key of the leaf certificate is proven by the handshake.
+		// Other certificates in the chain are public and can be sent by anyone.
+		if len(cs.PeerCertificates) > 0 &&
+			pins[sha256.Sum256(cs.PeerCertificates[0].RawSubjectPublicKeyInfo)] {
+			return nil
+		}
+
+		return ErrPinnedPublicKeyMismatch
+	}
+}
+
+// loadPKCS12Certificate loads the client certificate from the PKCS#12 bundle. Returns nil if the bundle is empty.
+func loadPKCS12Certificate(tlsConfig *TLSConfig) (*tls.Certificate, error) {
+	var data []byte
+
+	var err error
+
+	if tlsConfig.PKCS12Pem != nil {
+		pkcs12Pem, err := tlsConfig.PKCS12Pem.GetOrDefault("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load PKCS#12 PEM: %w", err)
+		}
+
+		if pkcs12Pem != "" {
+			data, err = base64.StdEncoding.DecodeString(pkcs12Pem)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode PKCS#12 PEM from base64: %w", err)
+			}
+		}
+	}
+
+	if len(data) == 0 && tlsConfig.PKCS12File != nil {
+		pkcs12File, err := tlsConfig.PKCS12File.GetOrDefault("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load PKCS#12 file: %w", err)
+		}
+
+		if pkcs12File != "" {
+			data, err = os.ReadFile(pkcs12File)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read PKCS#12 file: %w", err)
+			}
+		}
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var password string
+
+	if tlsConfig.PKCS12Password != nil {
+		password, err = tlsConfig.PKCS12Password.GetOrDefault("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load PKCS#12 password: %w", err)
+		}
+	}
+
+	cert, err := decodePKCS12(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the PKCS#12 bundle: %w", err)
+	}
+
+	return cert, nil
+}
+
+// decodePKCS12 decodes the private key and certificates of a PKCS#12 bundle into a TLS certificate.
+// The leaf certificate is the certificate of the private key. CA certificates are appended to the chain.
+func decodePKCS12(data []byte, password string) (*tls.Certificate, error) {
+	privateKey, leaf, caCerts, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &tls.Certificate{
+		Certificate: [][]byte{leaf.Raw},
+		PrivateKey:  privateKey,
+		Leaf:        leaf,
+	}
+
+	for _, cert := range caCerts {
+		result.Certificate = append(result.Certificate, cert.Raw)
+	}
+
+	return result, nil
+}
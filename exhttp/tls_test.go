@@ -0,0 +1,268 @@
+package exhttp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hasura/goenvconf"
+	"github.com/hasura/ndc-sdk-go/v2/utils"
+	"gotest.tools/v3/assert"
+)
+
+func TestDecodePKCS12(t *testing.T) {
+	rawCert, err := os.ReadFile(filepath.Join("testdata", "pkcs12", "client.crt"))
+	assert.NilError(t, err)
+
+	block, _ := pem.Decode(rawCert)
+	assert.Assert(t, block != nil)
+
+	for _, name := range []string{"client.p12", "client-legacy.p12"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "pkcs12", name))
+			assert.NilError(t, err)
+
+			cert, err := decodePKCS12(data, "p12secret")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, [][]byte{block.Bytes}, cert.Certificate)
+			assert.Equal(t, "pkcs12-client", cert.Leaf.Subject.CommonName)
+
+			_, err = decodePKCS12(data, "invalid")
+			assert.ErrorIs(t, err, ErrIncorrectPKCS12Password)
+		})
+	}
+
+	_, err = decodePKCS12([]byte("invalid"), "")
+	assert.ErrorContains(t, err, "pkcs12: ")
+}
+
+func TestTLSConfigPKCS12(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAnyClientCert,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	p12Data, err := os.ReadFile(filepath.Join("testdata", "pkcs12", "client.p12"))
+	assert.NilError(t, err)
+
+	for name, tlsConfig := range map[string]*TLSConfig{
+		"file": {
+			PKCS12File:     utils.ToPtr(goenvconf.NewEnvStringValue(filepath.Join("testdata", "pkcs12", "client-legacy.p12"))),
+			PKCS12Password: utils.ToPtr(goenvconf.NewEnvStringValue("p12secret")),
+		},
+		"pem": {
+			PKCS12Pem:      utils.ToPtr(goenvconf.NewEnvStringValue(base64.StdEncoding.EncodeToString(p12Data))),
+			PKCS12Password: utils.ToPtr(goenvconf.NewEnvStringValue("p12secret")),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tlsConfig.CAPem = utils.ToPtr(goenvconf.NewEnvStringValue(encodeTestCertificate(server.Certificate())))
+
+			assert.NilError(t, tlsConfig.Validate())
+
+			transport, err := NewTLSTransport(nil, tlsConfig, slog.Default())
+			assert.NilError(t, err)
+			assert.Equal(t, "pkcs12-client", sendTestRequest(t, transport, server.URL))
+		})
+	}
+
+	t.Run("invalid_password", func(t *testing.T) {
+		_, err := NewTLSTransport(nil, &TLSConfig{
+			PKCS12Pem:      utils.ToPtr(goenvconf.NewEnvStringValue(base64.StdEncoding.EncodeToString(p12Data))),
+			PKCS12Password: utils.ToPtr(goenvconf.NewEnvStringValue("invalid")),
+		}, slog.Default())
+		assert.ErrorIs(t, err, ErrIncorrectPKCS12Password)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		err := TLSConfig{
+			PKCS12Pem: utils.ToPtr(goenvconf.NewEnvStringValue("abc")),
+			CertFile:  utils.ToPtr(goenvconf.NewEnvStringValue("client.crt")),
+		}.Validate()
+		assert.ErrorContains(t, err, "provide either a PKCS#12 bundle or the certificate and key")
+	})
+}
+
+func TestTLSConfigPinnedPublicKeys(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	spkiHash := sha256.Sum256(server.Certificate().RawSubjectPublicKeyInfo)
+	pin := base64.StdEncoding.EncodeToString(spkiHash[:])
+	invalidPin := base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+	caPem := utils.ToPtr(goenvconf.NewEnvStringValue(encodeTestCertificate(server.Certificate())))
+
+	for name, tc := range map[string]struct {
+		Config   TLSConfig
+		Expected error
+	}{
+		"verified": {
+			Config: TLSConfig{CAPem: caPem, PinnedPublicKeys: []string{invalidPin, "sha256/" + pin}},
+		},
+		"verified_mismatch": {
+			Config:   TLSConfig{CAPem: caPem, PinnedPublicKeys: []string{invalidPin}},
+			Expected: ErrPinnedPublicKeyMismatch,
+		},
+		"insecure": {
+			Config: TLSConfig{
+				InsecureSkipVerify: utils.ToPtr(goenvconf.NewEnvBoolValue(true)),
+				PinnedPublicKeys:   []string{pin},
+			},
+		},
+		"insecure_mismatch": {
+			Config: TLSConfig{
+				InsecureSkipVerify: utils.ToPtr(goenvconf.NewEnvBoolValue(true)),
+				PinnedPublicKeys:   []string{invalidPin},
+			},
+			Expected: ErrPinnedPublicKeyMismatch,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.NilError(t, tc.Config.Validate())
+
+			transport, err := NewTLSTransport(nil, &tc.Config, slog.Default())
+			assert.NilError(t, err)
+
+			if tc.Expected == nil {
+				assert.Equal(t, "ok", sendTestRequest(t, transport, server.URL))
+
+				return
+			}
+
+			_, err = (&http.Client{Transport: transport}).Get(server.URL)
+			assert.ErrorIs(t, err, tc.Expected)
+		})
+	}
+
+	t.Run("resumed_session", func(t *testing.T) {
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(server.Certificate())
+
+		baseConfig := &tls.Config{
+			RootCAs:            rootCAs,
+			ClientSessionCache: tls.NewLRUClientSessionCache(1),
+			MinVersion:         tls.VersionTLS12,
+		}
+
+		dial := func(config *tls.Config) (*tls.Conn, error) {
+			conn, err := tls.Dial("tcp", server.Listener.Addr().String(), config)
+			if err != nil {
+				return nil, err
+			}
+
+			// session tickets of TLS 1.3 are received after the handshake.
+			_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\nConnection: close\r\n\r\n"))
+			assert.NilError(t, err)
+
+			_, err = io.ReadAll(conn)
+			assert.NilError(t, err)
+
+			return conn, conn.Close()
+		}
+
+		_, err := dial(baseConfig)
+		assert.NilError(t, err)
+
+		conn, err := dial(baseConfig)
+		assert.NilError(t, err)
+		assert.Assert(t, conn.ConnectionState().DidResume)
+
+		pins, err := parsePinnedPublicKeys([]string{invalidPin})
+		assert.NilError(t, err)
+
+		pinnedConfig := baseConfig.Clone()
+		pinnedConfig.VerifyConnection = newPinnedPublicKeysVerifier(pins)
+
+		_, err = dial(pinnedConfig)
+		assert.ErrorIs(t, err, ErrPinnedPublicKeyMismatch)
+	})
+
+	t.Run("insecure_unrelated_chain", func(t *testing.T) {
+		leafDER, leafKey := generateTestCertificate(t)
+		pinnedDER, _ := generateTestCertificate(t)
+
+		chainServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		chainServer.TLS = &tls.Config{
+			Certificates: []tls.Certificate{{
+				Certificate: [][]byte{leafDER, pinnedDER},
+				PrivateKey:  leafKey,
+			}},
+			MinVersion: tls.VersionTLS12,
+		}
+		chainServer.StartTLS()
+		defer chainServer.Close()
+
+		pinnedCert, err := x509.ParseCertificate(pinnedDER)
+		assert.NilError(t, err)
+
+		pinnedHash := sha256.Sum256(pinnedCert.RawSubjectPublicKeyInfo)
+		config := TLSConfig{
+			InsecureSkipVerify: utils.ToPtr(goenvconf.NewEnvBoolValue(true)),
+			PinnedPublicKeys:   []string{base64.StdEncoding.EncodeToString(pinnedHash[:])},
+		}
+
+		transport, err := NewTLSTransport(nil, &config, slog.Default())
+		assert.NilError(t, err)
+
+		_, err = (&http.Client{Transport: transport}).Get(chainServer.URL)
+		assert.ErrorIs(t, err, ErrPinnedPublicKeyMismatch)
+	})
+
+	t.Run("invalid_pin", func(t *testing.T) {
+		err := TLSConfig{PinnedPublicKeys: []string{"YWJj"}}.Validate()
+		assert.ErrorContains(t, err, "TLSConfig.pinnedPublicKeys: [0]: expected a SHA-256 hash of 32 bytes, got 3")
+	})
+}
+
+func generateTestCertificate(t *testing.T) ([]byte, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NilError(t, err)
+
+	return der, key
+}
+
+func encodeTestCertificate(cert *x509.Certificate) string {
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert.Raw,
+	}))
+}
@@ -56,12 +56,26 @@ type HTTPTransportConfig struct {
 	// WriteBufferSize specifies the size of the write buffer used when writing to the transport.
 	// If zero, a default (currently 4KB) is used.
 	WriteBufferSize *int `json:"writeBufferSize,omitempty" mapstructure:"writeBufferSize" yaml:"writeBufferSize" jsonschema:"nullable,min=0"`
+	// Send requests of http URLs with HTTP/2 prior knowledge (h2c) instead of HTTP/1.1.
+	// Requests of https URLs still negotiate HTTP/2 with TLS.
+	H2C *bool `json:"h2c,omitempty" mapstructure:"h2c" yaml:"h2c" jsonschema:"nullable"`
+	// Static IP addresses of host names that are dialed without DNS lookups, e.g. api.partner.com: [10.0.0.10].
+	// TLS SNI and the Host header still use the original host name.
+	Resolve map[string][]string `json:"resolve,omitempty" mapstructure:"resolve" yaml:"resolve,omitempty"`
+	// Addresses of DNS servers that resolve other host names instead of the system resolver. The default port is 53.
+	DNSServers []string `json:"dnsServers,omitempty" mapstructure:"dnsServers" yaml:"dnsServers,omitempty"`
+}
+
+// Validate if the current instance is valid.
+func (ttc HTTPTransportConfig) Validate() error {
+	return validateResolver(ttc.Resolve, ttc.DNSServers)
 }
 
 // ToTransport creates an http transport from the configuration.
 func (ttc HTTPTransportConfig) ToTransport() *http.Transport {
 	dialer := &net.Dialer{
-		Timeout: 30 * time.Second,
+		ControlContext: controlEgress,
+		Timeout:        30 * time.Second,
 		KeepAliveConfig: net.KeepAliveConfig{
 			Enable:   true,
 			Interval: 30 * time.Second,
@@ -90,9 +104,14 @@ func (ttc HTTPTransportConfig) ToTransport() *http.Transport {
 		}
 	}
 
+	dialContext := dialer.DialContext
+	if len(ttc.Resolve) > 0 || len(ttc.DNSServers) > 0 {
+		dialContext = newResolverDialer(dialer, ttc.Resolve, ttc.DNSServers).DialContext
+	}
+
 	defaultTransport := &http.Transport{
 		Proxy:                 http.ProxyFromEnvironment,
-		DialContext:           dialer.DialContext,
+		DialContext:           dialContext,
 		MaxIdleConns:          256,
 		MaxIdleConnsPerHost:   16,
 		ResponseHeaderTimeout: time.Minute,
@@ -142,6 +161,14 @@ func (ttc HTTPTransportConfig) ToTransport() *http.Transport {
 		defaultTransport.WriteBufferSize = *ttc.WriteBufferSize
 	}
 
+	if ttc.H2C != nil && *ttc.H2C {
+		// HTTP/1 must be disabled to send unencrypted HTTP/2 requests with prior knowledge.
+		protocols := &http.Protocols{}
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+		defaultTransport.Protocols = protocols
+	}
+
 	return defaultTransport
 }
 
@@ -0,0 +1,62 @@
+package exhttp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// UnixSocketScheme is the URL scheme of servers that listen on Unix domain sockets.
+const UnixSocketScheme = "unix"
+
+// unixSocketHost is the virtual host of HTTP requests that are sent through Unix domain sockets.
+const unixSocketHost = "localhost"
+
+// errUnixSocketPathRequired occurs when the Unix socket URL doesn't have the socket path.
+var errUnixSocketPathRequired = errors.New("the socket path of the unix URL is required")
+
+// ParseUnixSocketURL splits the Unix socket URL into the socket path and the HTTP URL.
+// The HTTP path is separated from the socket path by a colon, for example, unix:///var/run/app.sock:/api/v1.
+func ParseUnixSocketURL(u *url.URL) (string, *url.URL, error) {
+	socketPath, httpPath, _ := strings.Cut(u.Path, ":")
+	if socketPath == "" {
+		return "", nil, errUnixSocketPathRequired
+	}
+
+	httpURL := &url.URL{
+		Scheme:   "http",
+		Host:     unixSocketHost,
+		Path:     httpPath,
+		RawQuery: u.RawQuery,
+	}
+
+	return socketPath, httpURL, nil
+}
+
+// NewUnixSocketTransport creates a new HTTP transport that dials the Unix domain socket.
+// The proxy is disabled because connections never leave the host.
+// URLs of requests are still checked if the base transport enforces the egress policy.
+func NewUnixSocketTransport(baseTransport http.RoundTripper, socketPath string) http.RoundTripper {
+	if et, ok := baseTransport.(egressTransport); ok {
+		et.transport = NewUnixSocketTransport(et.transport, socketPath)
+
+		return et
+	}
+
+	bTransport, ok := baseTransport.(*http.Transport)
+	if !ok {
+		bTransport, _ = http.DefaultTransport.(*http.Transport)
+	}
+
+	dialer := &net.Dialer{}
+	transport := bTransport.Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", socketPath)
+	}
+
+	return transport
+}
@@ -0,0 +1,91 @@
+package exhttp
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/hasura/ndc-sdk-go/v2/utils"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseUnixSocketURL(t *testing.T) {
+	for rawURL, expected := range map[string][2]string{
+		"unix:///var/run/app.sock":               {"/var/run/app.sock", "http://localhost"},
+		"unix:///var/run/app.sock:/api/v1":       {"/var/run/app.sock", "http://localhost/api/v1"},
+		"unix:///var/run/app.sock:/api?limit=10": {"/var/run/app.sock", "http://localhost/api?limit=10"},
+	} {
+		t.Run(rawURL, func(t *testing.T) {
+			u, err := ParseServerURL(rawURL)
+			assert.NilError(t, err)
+
+			socketPath, httpURL, err := ParseUnixSocketURL(u)
+			assert.NilError(t, err)
+			assert.Equal(t, expected[0], socketPath)
+			assert.Equal(t, expected[1], httpURL.String())
+		})
+	}
+
+	_, err := ParseServerURL("unix://")
+	assert.ErrorIs(t, err, errUnixSocketPathRequired)
+}
+
+func TestUnixSocketTransport(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "app.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	assert.NilError(t, err)
+
+	protocols := &http.Protocols{}
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	server := &http.Server{
+		Protocols: protocols,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Proto + " " + r.URL.Path))
+		}),
+	}
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	defer server.Close()
+
+	u, err := url.Parse("unix://" + socketPath + ":/api")
+	assert.NilError(t, err)
+
+	_, httpURL, err := ParseUnixSocketURL(u)
+	assert.NilError(t, err)
+
+	transport := NewUnixSocketTransport(nil, socketPath)
+	assert.Equal(t, "HTTP/1.1 /api/pets", sendTestRequest(t, transport, httpURL.String()+"/pets"))
+
+	h2cTransport := NewUnixSocketTransport(
+		HTTPTransportConfig{H2C: utils.ToPtr(true)}.ToTransport(),
+		socketPath,
+	)
+	assert.Equal(t, "HTTP/2.0 /api/pets", sendTestRequest(t, h2cTransport, httpURL.String()+"/pets"))
+
+	t.Run("egress", func(t *testing.T) {
+		newEgressTransport := func(config EgressPolicyConfig) http.RoundTripper {
+			policy, err := NewEgressPolicy(config)
+			assert.NilError(t, err)
+
+			return NewUnixSocketTransport(
+				NewEgressTransport(HTTPTransportConfig{H2C: utils.ToPtr(true)}.ToTransport(), policy),
+				socketPath,
+			)
+		}
+
+		transport := newEgressTransport(EgressPolicyConfig{AllowedHosts: []string{"localhost"}})
+		assert.Equal(t, "HTTP/2.0 /api/pets", sendTestRequest(t, transport, httpURL.String()+"/pets"))
+
+		transport = newEgressTransport(EgressPolicyConfig{AllowedHosts: []string{"api.example.com"}})
+		_, err := (&http.Client{Transport: transport}).Get(httpURL.String() + "/pets")
+		assert.ErrorContains(t, err, "egress denied: host localhost is not allowed")
+	})
+}
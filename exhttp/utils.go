@@ -25,7 +25,7 @@ func ParsePort(rawPort string, scheme string) (int, error) {
 	return port, nil
 }
 
-// ParseHttpURL parses and validate the input string to have http(s) scheme.
+// ParseHttpURL parses and validate the input string to have http or https scheme.
 func ParseHttpURL(input string) (*url.URL, error) {
 	u, err := url.Parse(input)
 	if err != nil {
@@ -38,3 +38,24 @@ func ParseHttpURL(input string) (*url.URL, error) {
 
 	return u, nil
 }
+
+// ParseServerURL parses and validate the URL of a server which may also listen on a Unix socket.
+// The input string must have http(s) or unix scheme.
+func ParseServerURL(input string) (*url.URL, error) {
+	u, err := url.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+	case UnixSocketScheme:
+		if _, _, err := ParseUnixSocketURL(u); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid http(s) or unix scheme, got: %s", u.Scheme)
+	}
+
+	return u, nil
+}
@@ -34,4 +34,18 @@ func TestParseHTTPUrl(t *testing.T) {
 
 	_, err = ParseHttpURL("gs://path/to/file")
 	assert.ErrorContains(t, err, "invalid http(s) scheme")
+
+	_, err = ParseHttpURL("unix:///var/run/app.sock")
+	assert.ErrorContains(t, err, "invalid http(s) scheme, got: unix")
+}
+
+func TestParseServerURL(t *testing.T) {
+	for _, expected := range []string{"https://localhost:8080/v1/api", "unix:///var/run/app.sock:/api"} {
+		result, err := ParseServerURL(expected)
+		assert.NilError(t, err)
+		assert.Equal(t, expected, result.String())
+	}
+
+	_, err := ParseServerURL("gs://path/to/file")
+	assert.ErrorContains(t, err, "invalid http(s) or unix scheme")
 }
@@ -70,6 +70,8 @@ func CommandConvertToNDCSchema(
 		slog.Any("allowed_content_types", config.AllowedContentTypes),
 		slog.Bool("pure", config.Pure),
 		slog.Bool("no_deprecation", config.NoDeprecation),
+		slog.Any("include", config.Include),
+		slog.Any("exclude", config.Exclude),
 	)
 
 	result, err := configuration.ConvertToNDCSchema(&config, logger)
@@ -79,6 +81,14 @@ func CommandConvertToNDCSchema(
 		return err
 	}
 
+	logger.Info(
+		"converted the document",
+		slog.Int("functions", len(result.Functions)),
+		slog.Int("procedures", len(result.Procedures)),
+		slog.Int("object_types", len(result.ObjectTypes)),
+		slog.Int("scalar_types", len(result.ScalarTypes)),
+	)
+
 	if config.Output != "" {
 		if config.Pure {
 			err = utils.WriteSchemaFile(config.Output, result.ToSchemaResponse())
@@ -27,6 +27,7 @@ func TestConvertToNDCSchema(t *testing.T) {
 		patchBefore         []string
 		patchAfter          []string
 		allowedContentTypes []string
+		includeTags         []string
 		expected            string
 		errorMsg            string
 	}{
@@ -70,6 +71,13 @@ func TestConvertToNDCSchema(t *testing.T) {
 			spec:     schema.OAS3Spec,
 			errorMsg: "unable to build openapi document, supplied spec is a different version (oas2)",
 		},
+		{
+			name:        "graphql_filter",
+			filePath:    "../graphql/testdata/blog/source.graphql",
+			spec:        schema.GraphQLSpec,
+			includeTags: []string{"post"},
+			errorMsg:    "include and exclude filters aren't supported by the graphql spec",
+		},
 		{
 			name:                "patch",
 			filePath:            "../openapi/testdata/onesignal/source.json",
@@ -102,6 +110,7 @@ func TestConvertToNDCSchema(t *testing.T) {
 				PatchBefore:         tc.patchBefore,
 				PatchAfter:          tc.patchAfter,
 				AllowedContentTypes: tc.allowedContentTypes,
+				IncludeTags:         tc.includeTags,
 			}
 			if tc.config != "" {
 				args = &configuration.ConvertCommandArguments{
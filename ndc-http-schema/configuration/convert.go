@@ -4,23 +4,44 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 
+	"github.com/hasura/ndc-http/ndc-http-schema/graphql"
+	"github.com/hasura/ndc-http/ndc-http-schema/har"
 	"github.com/hasura/ndc-http/ndc-http-schema/ndc"
+	"github.com/hasura/ndc-http/ndc-http-schema/odata"
 	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	"github.com/hasura/ndc-http/ndc-http-schema/openrpc"
+	"github.com/hasura/ndc-http/ndc-http-schema/postman"
+	"github.com/hasura/ndc-http/ndc-http-schema/proto"
+	"github.com/hasura/ndc-http/ndc-http-schema/raml"
 	"github.com/hasura/ndc-http/ndc-http-schema/schema"
 	"github.com/hasura/ndc-http/ndc-http-schema/utils"
+	"github.com/hasura/ndc-http/ndc-http-schema/wsdl"
 )
 
 // ConvertToNDCSchema converts to NDC HTTP schema from config.
 func ConvertToNDCSchema(config *ConvertConfig, logger *slog.Logger) (*schema.NDCHttpSchema, error) {
+	// operation filters are applied by the OpenAPI converter, so specs which aren't converted through OpenAPI can't be filtered.
+	if (config.Include != nil || config.Exclude != nil) && slices.Contains([]schema.SchemaSpecType{
+		schema.NDCSpec, schema.GraphQLSpec, schema.WSDLSpec, schema.ODataSpec, schema.ProtoSpec,
+	}, config.Spec) {
+		return nil, fmt.Errorf("include and exclude filters aren't supported by the %s spec", config.Spec)
+	}
+
 	rawContent, err := utils.ReadFileFromPath(config.File)
 	if err != nil {
 		return nil, err
 	}
 
-	rawContent, err = utils.ApplyPatch(rawContent, config.PatchBefore)
-	if err != nil {
-		return nil, err
+	// GraphQL SDL, WSDL, CSDL XML and proto documents are neither JSON nor YAML, so they are converted as is if there is no patch.
+	// RAML documents are also kept as is to validate the #%RAML header which is a YAML comment.
+	if (config.Spec != schema.GraphQLSpec && config.Spec != schema.WSDLSpec && config.Spec != schema.ODataSpec &&
+		config.Spec != schema.ProtoSpec && config.Spec != schema.RAMLSpec) || len(config.PatchBefore) > 0 {
+		rawContent, err = utils.ApplyPatch(rawContent, config.PatchBefore)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var result *schema.NDCHttpSchema
@@ -34,6 +55,8 @@ func ConvertToNDCSchema(config *ConvertConfig, logger *slog.Logger) (*schema.NDC
 		EnvPrefix:           config.EnvPrefix,
 		AllowedContentTypes: config.AllowedContentTypes,
 		NoDeprecation:       config.NoDeprecation,
+		Include:             config.Include,
+		Exclude:             config.Exclude,
 		Logger:              logger,
 	}
 
@@ -42,6 +65,22 @@ func ConvertToNDCSchema(config *ConvertConfig, logger *slog.Logger) (*schema.NDC
 		result, errs = openapi.OpenAPIv3ToNDCSchema(rawContent, options)
 	case schema.OpenAPIv2Spec, (schema.OAS2Spec):
 		result, errs = openapi.OpenAPIv2ToNDCSchema(rawContent, options)
+	case schema.PostmanSpec:
+		result, errs = postman.PostmanToNDCSchema(rawContent, options)
+	case schema.HARSpec:
+		result, errs = har.HARToNDCSchema(rawContent, options)
+	case schema.GraphQLSpec:
+		result, errs = graphql.GraphQLToNDCSchema(rawContent, options)
+	case schema.OpenRPCSpec:
+		result, errs = openrpc.OpenRPCToNDCSchema(rawContent, options)
+	case schema.WSDLSpec:
+		result, errs = wsdl.WSDLToNDCSchema(rawContent, options)
+	case schema.ODataSpec:
+		result, errs = odata.ODataToNDCSchema(rawContent, options)
+	case schema.ProtoSpec:
+		result, errs = proto.ProtoToNDCSchema(rawContent, options)
+	case schema.RAMLSpec:
+		result, errs = raml.RAMLToNDCSchema(rawContent, options)
 	case schema.NDCSpec:
 		result, err = ndc.BuildNDCSchema(rawContent, ndc.ConvertOptions{
 			Prefix: options.Prefix,
@@ -60,6 +99,14 @@ func ConvertToNDCSchema(config *ConvertConfig, logger *slog.Logger) (*schema.NDC
 				schema.OAS3Spec,
 				schema.OAS2Spec,
 				schema.NDCSpec,
+				schema.PostmanSpec,
+				schema.HARSpec,
+				schema.GraphQLSpec,
+				schema.OpenRPCSpec,
+				schema.WSDLSpec,
+				schema.ODataSpec,
+				schema.ProtoSpec,
+				schema.RAMLSpec,
 			},
 		)
 	}
@@ -116,6 +163,26 @@ func ResolveConvertConfigArguments(
 		if len(args.AllowedContentTypes) > 0 {
 			config.AllowedContentTypes = args.AllowedContentTypes
 		}
+
+		include := openapi.OperationFilter{
+			Tags:         args.IncludeTags,
+			Paths:        args.IncludePaths,
+			Methods:      args.IncludeMethods,
+			OperationIDs: args.IncludeOperationIds,
+		}
+		if !include.IsZero() {
+			config.Include = &include
+		}
+
+		exclude := openapi.OperationFilter{
+			Tags:         args.ExcludeTags,
+			Paths:        args.ExcludePaths,
+			Methods:      args.ExcludeMethods,
+			OperationIDs: args.ExcludeOperationIds,
+		}
+		if !exclude.IsZero() {
+			config.Exclude = &exclude
+		}
 	}
 
 	if config.Spec == "" {
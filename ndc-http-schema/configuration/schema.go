@@ -232,6 +232,10 @@ func buildSchemaFile(
 		return nil, fmt.Errorf("the servers setting of schema %s is empty", configItem.File)
 	}
 
+	if configItem.Transport != nil && ndcSchema.Settings.Transport == nil {
+		ndcSchema.Settings.Transport = configItem.Transport
+	}
+
 	// cache original result type to be used if header forwarding or distributed execution is enabled
 	for key, op := range ndcSchema.Functions {
 		op.BackupResultType()
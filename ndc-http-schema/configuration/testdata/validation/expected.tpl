@@ -10,6 +10,9 @@ WARNING:
 Environment Variables:
   Make sure that the following environment variable mappings were added to your subgraph configuration (with subgraph prefixes such as APP_MYAPI_):
 
+    - CAT_PARTNER_PKCS12_PASSWORD
+    - CAT_PARTNER_PKCS12_PEM
+    - CAT_PARTNER_URL
     - CAT_PET_HEADER
     - CAT_STORE_CA_PEM
     - CAT_STORE_CERT_PEM
@@ -31,6 +34,9 @@ Environment Variables:
   Use the DDN CLI to add environment variables if you haven't added them yet:
 
     ddn connector env add \
+      --env CAT_PARTNER_PKCS12_PASSWORD=<value> \
+      --env CAT_PARTNER_PKCS12_PEM=<value> \
+      --env CAT_PARTNER_URL=<value> \
       --env CAT_PET_HEADER=<value> \
       --env CAT_STORE_CA_PEM=<value> \
       --env CAT_STORE_CERT_PEM=<value> \
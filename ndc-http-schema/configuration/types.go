@@ -9,6 +9,7 @@ import (
 
 	"github.com/hasura/goenvconf"
 	"github.com/hasura/ndc-http/exhttp"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
 	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
 	restUtils "github.com/hasura/ndc-http/ndc-http-schema/utils"
 	"github.com/hasura/ndc-sdk-go/v2/schema"
@@ -118,6 +119,13 @@ type ConfigItem struct {
 	// configure the request timeout in seconds.
 	Timeout *goenvconf.EnvInt          `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout"`
 	Retry   *exhttp.RetryPolicySetting `json:"retry,omitempty"   yaml:"retry,omitempty"   mapstructure:"retry"`
+	// The maximum size of response bodies in bytes before decompression.
+	MaxResponseBytes *goenvconf.EnvInt `json:"maxResponseBytes,omitempty" yaml:"maxResponseBytes,omitempty" mapstructure:"maxResponseBytes"`
+	// The maximum size of response bodies in bytes after decompression. Defaults to the limit before decompression.
+	MaxDecompressedBytes *goenvconf.EnvInt `json:"maxDecompressedBytes,omitempty" yaml:"maxDecompressedBytes,omitempty" mapstructure:"maxDecompressedBytes"`
+	// Connection pool and timeout settings of the HTTP transport.
+	// The setting is applied if the transport setting of the schema is empty.
+	Transport *exhttp.HTTPTransportConfig `json:"transport,omitempty" yaml:"transport,omitempty" mapstructure:"transport"`
 }
 
 // IsDistributed checks if the distributed option is enabled.
@@ -162,6 +170,19 @@ func (ci ConfigItem) GetRuntimeSettings() (*rest.RuntimeSettings, error) {
 		result.Retry = *retryPolicy
 	}
 
+	maxResponseBytes, err := getResponseLimit(ci.MaxResponseBytes)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("maxResponseBytes: %w", err))
+	}
+
+	maxDecompressedBytes, err := getResponseLimit(ci.MaxDecompressedBytes)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("maxDecompressedBytes: %w", err))
+	}
+
+	result.MaxResponseBytes = maxResponseBytes
+	result.MaxDecompressedBytes = maxDecompressedBytes
+
 	if len(errs) > 0 {
 		return result, errors.Join(errs...)
 	}
@@ -193,6 +214,10 @@ type ConvertConfig struct {
 	PatchAfter []restUtils.PatchConfig `json:"patchAfter,omitempty" yaml:"patchAfter"`
 	// Allowed content types. All content types are allowed by default
 	AllowedContentTypes []string `json:"allowedContentTypes,omitempty" yaml:"allowedContentTypes"`
+	// Convert only operations which match the filter. Supported by OpenAPI specs
+	Include *openapi.OperationFilter `json:"include,omitempty" yaml:"include,omitempty"`
+	// Skip operations which match the filter. Supported by OpenAPI specs
+	Exclude *openapi.OperationFilter `json:"exclude,omitempty" yaml:"exclude,omitempty"`
 	// The location where the ndc schema file will be generated. Print to stdout if not set
 	Output string `json:"output,omitempty" yaml:"output,omitempty"`
 }
@@ -210,7 +235,7 @@ type ConvertCommandArguments struct {
 	File                string            `help:"File path needs to be converted."                                                                                            short:"f"`
 	Config              string            `help:"Path of the config file."                                                                                                    short:"c"`
 	Output              string            `help:"The location where the ndc schema file will be generated. Print to stdout if not set"                                        short:"o"`
-	Spec                string            `help:"The API specification of the file, is one of oas3 (openapi3), oas2 (openapi2)"`
+	Spec                string            `help:"The API specification of the file, is one of oas3 (openapi3), oas2 (openapi2), postman, har, graphql, openrpc, wsdl, odata, proto, raml"`
 	Format              string            `help:"The output format, is one of json, yaml. If the output is set, automatically detect the format in the output file extension"           default:"json"`
 	Strict              bool              `help:"Require strict validation"                                                                                                             default:"false"`
 	NoDeprecation       bool              `help:"Ignore deprecated fields"                                                                                                              default:"false"`
@@ -222,6 +247,14 @@ type ConvertCommandArguments struct {
 	AllowedContentTypes []string          `help:"Allowed content types. All content types are allowed by default"`
 	PatchBefore         []string          `help:"Patch files to be applied into the input file before converting"`
 	PatchAfter          []string          `help:"Patch files to be applied into the input file after converting"`
+	IncludeTags         []string          `help:"Convert only operations with any of the OpenAPI tags"`
+	IncludePaths        []string          `help:"Convert only operations whose paths match any of the glob patterns, e.g. /v1/customers/**"`
+	IncludeMethods      []string          `help:"Convert only operations with any of the HTTP methods"`
+	IncludeOperationIds []string          `help:"Convert only operations whose IDs match any of the regular expressions"`
+	ExcludeTags         []string          `help:"Skip operations with any of the OpenAPI tags"`
+	ExcludePaths        []string          `help:"Skip operations whose paths match any of the glob patterns"`
+	ExcludeMethods      []string          `help:"Skip operations with any of the HTTP methods"`
+	ExcludeOperationIds []string          `help:"Skip operations whose IDs match any of the regular expressions"`
 }
 
 // the object type of HTTP execution options for single server.
@@ -273,6 +306,16 @@ type RawRuntimeSettings struct {
 	EnableRawRequest *bool `json:"enableRawRequest,omitempty" yaml:"enableRawRequest,omitempty"`
 	// Treat the JSON scalar as a json string
 	StringifyJSON *goenvconf.EnvBool `json:"stringifyJson,omitempty" yaml:"stringifyJson,omitempty"`
+	// Reload the configuration without restarting the connector when the configuration, schema or patch files change.
+	WatchConfig *goenvconf.EnvBool `json:"watchConfig,omitempty" yaml:"watchConfig,omitempty"`
+	// Restrict destinations of the sendHttpRequest operation. Requests to private networks are blocked if the policy is set.
+	EgressPolicy *exhttp.EgressPolicyConfig `json:"egressPolicy,omitempty" yaml:"egressPolicy,omitempty"`
+	// The default maximum size of response bodies in bytes before decompression.
+	MaxResponseBytes *goenvconf.EnvInt `json:"maxResponseBytes,omitempty" yaml:"maxResponseBytes,omitempty"`
+	// The default maximum size of response bodies in bytes after decompression. Defaults to the limit before decompression.
+	MaxDecompressedBytes *goenvconf.EnvInt `json:"maxDecompressedBytes,omitempty" yaml:"maxDecompressedBytes,omitempty"`
+	// Rules to mask sensitive headers, query parameters and body fields in logs, traces and explain output.
+	Redaction *exhttp.RedactionConfig `json:"redaction,omitempty" yaml:"redaction,omitempty"`
 }
 
 // RuntimeSettings hold optional runtime settings.
@@ -281,6 +324,16 @@ type RuntimeSettings struct {
 	EnableRawRequest bool `json:"enableRawRequest,omitempty" yaml:"enableRawRequest,omitempty"`
 	// Treat the JSON scalar as a json string
 	StringifyJSON bool `json:"stringifyJson,omitempty" yaml:"stringifyJson,omitempty"`
+	// Reload the configuration when the configuration, schema or patch files change.
+	WatchConfig bool `json:"watchConfig,omitempty" yaml:"watchConfig,omitempty"`
+	// The egress policy of the sendHttpRequest operation.
+	EgressPolicy *exhttp.EgressPolicy `json:"-" yaml:"-"`
+	// The default maximum size of response bodies in bytes before decompression.
+	MaxResponseBytes int64 `json:"maxResponseBytes,omitempty" yaml:"maxResponseBytes,omitempty"`
+	// The default maximum size of response bodies in bytes after decompression. Defaults to the limit before decompression.
+	MaxDecompressedBytes int64 `json:"maxDecompressedBytes,omitempty" yaml:"maxDecompressedBytes,omitempty"`
+	// Masks sensitive data in logs, traces and explain output. Only the default header pattern is masked if nil.
+	Redactor *exhttp.Redactor `json:"-" yaml:"-"`
 }
 
 // Validate validates and returns validated settings.
@@ -298,5 +351,63 @@ func (rs RawRuntimeSettings) Validate() (*RuntimeSettings, error) {
 		result.StringifyJSON = stringifyJson
 	}
 
+	if rs.WatchConfig != nil {
+		watchConfig, err := rs.WatchConfig.GetOrDefault(false)
+		if err != nil {
+			return nil, fmt.Errorf("watchConfig: %w", err)
+		}
+
+		result.WatchConfig = watchConfig
+	}
+
+	if rs.EgressPolicy != nil {
+		egressPolicy, err := exhttp.NewEgressPolicy(*rs.EgressPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("egressPolicy: %w", err)
+		}
+
+		result.EgressPolicy = egressPolicy
+	}
+
+	maxResponseBytes, err := getResponseLimit(rs.MaxResponseBytes)
+	if err != nil {
+		return nil, fmt.Errorf("maxResponseBytes: %w", err)
+	}
+
+	maxDecompressedBytes, err := getResponseLimit(rs.MaxDecompressedBytes)
+	if err != nil {
+		return nil, fmt.Errorf("maxDecompressedBytes: %w", err)
+	}
+
+	result.MaxResponseBytes = maxResponseBytes
+	result.MaxDecompressedBytes = maxDecompressedBytes
+
+	if rs.Redaction != nil {
+		redactor, err := exhttp.NewRedactor(*rs.Redaction)
+		if err != nil {
+			return nil, fmt.Errorf("redaction: %w", err)
+		}
+
+		result.Redactor = redactor
+	}
+
 	return &result, nil
 }
+
+// getResponseLimit gets the size limit of response bodies. Zero means no limit.
+func getResponseLimit(setting *goenvconf.EnvInt) (int64, error) {
+	if setting == nil {
+		return 0, nil
+	}
+
+	limit, err := setting.GetOrDefault(0)
+	if err != nil {
+		return 0, err
+	}
+
+	if limit < 0 {
+		return 0, fmt.Errorf("must be positive, got: %d", limit)
+	}
+
+	return limit, nil
+}
@@ -7,6 +7,8 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/hasura/ndc-http/ndc-http-schema/schema"
 	"github.com/hasura/ndc-http/ndc-http-schema/utils"
@@ -108,3 +110,41 @@ func ReadConfigurationFile(configurationDir string) (*Configuration, error) {
 
 	return &config, nil
 }
+
+// GetConfigurationFilePaths returns paths of local files that the configuration is built from,
+// including the configuration file, the schema output file, API documents and patch files.
+func GetConfigurationFilePaths(configurationDir string, config *Configuration) []string {
+	results := []string{
+		configurationDir + "/config.json",
+		configurationDir + "/config.yaml",
+		configurationDir + "/config.yml",
+	}
+
+	addFilePath := func(filePath string) {
+		if filePath == "" || strings.HasPrefix(filePath, "http") {
+			return
+		}
+
+		results = append(results, utils.ResolveFilePath(configurationDir, filePath))
+	}
+
+	if config.Output != "" {
+		results = append(results, filepath.Join(configurationDir, config.Output))
+	}
+
+	for _, item := range config.Files {
+		addFilePath(item.File)
+
+		for _, patch := range item.PatchBefore {
+			addFilePath(patch.Path)
+		}
+
+		for _, patch := range item.PatchAfter {
+			addFilePath(patch.Path)
+		}
+	}
+
+	slices.Sort(results)
+
+	return slices.Compact(results)
+}
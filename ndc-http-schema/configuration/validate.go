@@ -124,6 +124,10 @@ func (cv *ConfigValidator) evaluateSchema(ndcSchema *NDCHttpRuntimeSchema) error
 		cv.validateTLS(ndcSchema.Name, "settings.tls", ndcSchema.Settings.TLS)
 	}
 
+	if ndcSchema.Settings.Proxy != nil {
+		cv.validateProxy(ndcSchema.Name, "settings.proxy", ndcSchema.Settings.Proxy)
+	}
+
 	cv.validateArgumentPresets(
 		ndcSchema.Name,
 		"settings.argumentPresets",
@@ -166,6 +170,10 @@ func (cv *ConfigValidator) evaluateSchema(ndcSchema *NDCHttpRuntimeSchema) error
 			cv.validateTLS(ndcSchema.Name, serverPath+".tls", server.TLS)
 		}
 
+		if server.Proxy != nil {
+			cv.validateProxy(ndcSchema.Name, serverPath+".proxy", server.Proxy)
+		}
+
 		cv.validateArgumentPresets(
 			ndcSchema.Name,
 			serverPath+".argumentPresets",
@@ -219,7 +227,32 @@ func (cv *ConfigValidator) validateTLS(namespace string, key string, tlsConfig *
 	cv.validateTLSCert(tlsConfig)
 	cv.validateTLSCA(tlsConfig)
 	cv.validateTLSKey(tlsConfig)
+	cv.validateTLSPKCS12(tlsConfig)
 	cv.validateInsecureSkipVerify(namespace, key, tlsConfig)
+
+	if err := exhttp.ValidatePinnedPublicKeys(tlsConfig.PinnedPublicKeys); err != nil {
+		cv.addError(namespace, fmt.Sprintf("%s.pinnedPublicKeys: %s", key, err))
+	}
+}
+
+// validateProxy validates the proxy config. Variables of the proxy are optional.
+// Requests are sent directly if the proxy URL is empty.
+func (cv *ConfigValidator) validateProxy(
+	namespace string,
+	key string,
+	proxyConfig *exhttp.ProxyConfig,
+) {
+	schemaDoc := cv.getLastSchemaDoc()
+
+	for _, value := range []*goenvconf.EnvString{&proxyConfig.URL, proxyConfig.Username, proxyConfig.Password} {
+		if value != nil && !cv.validateEnvString(schemaDoc, value) {
+			return
+		}
+	}
+
+	if _, err := proxyConfig.ProxyFunc(); err != nil {
+		cv.addError(namespace, fmt.Sprintf("%s: %s", key, err))
+	}
 }
 
 func (cv *ConfigValidator) validateTLSCert(tlsConfig *exhttp.TLSConfig) {
@@ -288,6 +321,33 @@ func (cv *ConfigValidator) validateTLSKey(tlsConfig *exhttp.TLSConfig) {
 	}
 }
 
+func (cv *ConfigValidator) validateTLSPKCS12(tlsConfig *exhttp.TLSConfig) {
+	if tlsConfig.PKCS12Pem == nil && tlsConfig.PKCS12File == nil {
+		return
+	}
+
+	schemaDoc := cv.getLastSchemaDoc()
+
+	if tlsConfig.PKCS12Password != nil && !cv.validateEnvString(schemaDoc, tlsConfig.PKCS12Password) &&
+		tlsConfig.PKCS12Password.Variable != nil {
+		cv.requiredVariables[*tlsConfig.PKCS12Password.Variable] = true
+	}
+
+	if cv.validateEnvString(schemaDoc, tlsConfig.PKCS12Pem) {
+		return
+	}
+
+	if cv.validateEnvString(schemaDoc, tlsConfig.PKCS12File) {
+		return
+	}
+
+	if tlsConfig.PKCS12Pem != nil && tlsConfig.PKCS12Pem.Variable != nil {
+		cv.requiredVariables[*tlsConfig.PKCS12Pem.Variable] = true
+	} else if tlsConfig.PKCS12File != nil && tlsConfig.PKCS12File.Variable != nil {
+		cv.requiredVariables[*tlsConfig.PKCS12File.Variable] = true
+	}
+}
+
 func (cv *ConfigValidator) validateInsecureSkipVerify(
 	namespace string,
 	key string,
@@ -332,26 +392,30 @@ func (cv *ConfigValidator) validateSecurityScheme(
 
 	switch schemer := ss.SecuritySchemer.(type) {
 	case *schema.APIKeyAuthConfig:
-		if !cv.validateEnvString(schemaDoc, &schemer.Value) && schemer.Value.Variable != nil {
-			cv.requiredVariables[*schemer.Value.Variable] = true
-		}
+		cv.validateSecretString(schemaDoc, &schemer.Value)
 	case *schema.HTTPAuthConfig:
-		if !cv.validateEnvString(schemaDoc, &schemer.Value) && schemer.Value.Variable != nil {
-			cv.requiredVariables[*schemer.Value.Variable] = true
-		}
+		cv.validateSecretString(schemaDoc, &schemer.Value)
 	case *schema.BasicAuthConfig:
-		if !cv.validateEnvString(schemaDoc, &schemer.Username) && schemer.Username.Variable != nil {
-			cv.requiredVariables[*schemer.Username.Variable] = true
+		cv.validateSecretString(schemaDoc, &schemer.Username)
+		cv.validateSecretString(schemaDoc, &schemer.Password)
+	case *schema.DigestAuthConfig:
+		cv.validateSecretString(schemaDoc, &schemer.Username)
+		cv.validateSecretString(schemaDoc, &schemer.Password)
+	case *schema.NTLMAuthConfig:
+		if schemer.Domain != nil && !cv.validateEnvString(schemaDoc, schemer.Domain) &&
+			schemer.Domain.Variable != nil {
+			cv.requiredVariables[*schemer.Domain.Variable] = true
 		}
 
-		if !cv.validateEnvString(schemaDoc, &schemer.Password) && schemer.Password.Variable != nil {
-			cv.requiredVariables[*schemer.Password.Variable] = true
-		}
+		cv.validateSecretString(schemaDoc, &schemer.Username)
+		cv.validateSecretString(schemaDoc, &schemer.Password)
 	case *schema.MutualTLSAuthConfig:
 	case *schema.OAuth2Config:
 		cv.validateOAuth2Config(namespace, key, schemer)
+	case *schema.TokenEndpointAuthConfig:
+		cv.validateTokenEndpointConfig(schemer)
 	case *schema.CookieAuthConfig:
-		cv.forwardedHeaderNames["Cookie"] = true
+		cv.forwardedHeaderNames[schema.CookieHeader] = true
 		cv.requiredHeadersForwarding[schemer.GetType()] = true
 	default:
 		cv.requiredHeadersForwarding[schemer.GetType()] = true
@@ -385,8 +449,8 @@ func (cv *ConfigValidator) validateOAuth2Config(
 
 		if flow.ClientID == nil {
 			cv.addWarning(namespace, fmt.Sprintf("%s.flow.clientId is null%s", key, defaultMessage))
-		} else if !cv.validateEnvString(schemaDoc, flow.ClientID) && flow.ClientID.Variable != nil {
-			cv.requiredVariables[*flow.ClientID.Variable] = true
+		} else {
+			cv.validateSecretString(schemaDoc, flow.ClientID)
 		}
 
 		if flow.ClientSecret == nil {
@@ -394,8 +458,8 @@ func (cv *ConfigValidator) validateOAuth2Config(
 				namespace,
 				fmt.Sprintf("%s.flow.clientSecret is null%s", key, defaultMessage),
 			)
-		} else if !cv.validateEnvString(schemaDoc, flow.ClientSecret) && flow.ClientSecret.Variable != nil {
-			cv.requiredVariables[*flow.ClientSecret.Variable] = true
+		} else {
+			cv.validateSecretString(schemaDoc, flow.ClientSecret)
 		}
 
 		for _, param := range flow.EndpointParams {
@@ -406,6 +470,25 @@ func (cv *ConfigValidator) validateOAuth2Config(
 	}
 }
 
+func (cv *ConfigValidator) validateTokenEndpointConfig(schemer *schema.TokenEndpointAuthConfig) {
+	schemaDoc := cv.getLastSchemaDoc()
+
+	if !cv.validateEnvString(schemaDoc, &schemer.Request.URL) &&
+		schemer.Request.URL.Variable != nil {
+		cv.requiredVariables[*schemer.Request.URL.Variable] = true
+	}
+
+	for _, header := range schemer.Request.Headers {
+		if !cv.validateEnvString(schemaDoc, &header) && header.Variable != nil {
+			cv.requiredVariables[*header.Variable] = true
+		}
+	}
+
+	for _, field := range schemer.Request.Body {
+		cv.validateSecretString(schemaDoc, &field)
+	}
+}
+
 type manifestDefinition struct {
 	Definition struct {
 		Name string `yaml:"name"`
@@ -478,6 +561,22 @@ func (cv *ConfigValidator) addError(namespace string, value string) {
 	}
 }
 
+// validateSecretString validates the secret and marks the environment variable as required if the value is empty.
+// Secrets from files only require the variable of the file path.
+func (cv *ConfigValidator) validateSecretString(
+	schemaDoc *schemaDocInfo,
+	value *schema.SecretString,
+) {
+	envValue := &value.EnvString
+	if value.File != nil {
+		envValue = value.File
+	}
+
+	if !cv.validateEnvString(schemaDoc, envValue) && envValue.Variable != nil {
+		cv.requiredVariables[*envValue.Variable] = true
+	}
+}
+
 func (cv *ConfigValidator) validateEnvString(
 	schemaDoc *schemaDocInfo,
 	value *goenvconf.EnvString,
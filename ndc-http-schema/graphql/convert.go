@@ -0,0 +1,407 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-http/ndc-http-schema/utils"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
+)
+
+var errUnsupportedUnionType = errors.New("union types are not supported")
+
+// builtin GraphQL scalars and their equivalent NDC scalars.
+var builtinScalars = map[string]rest.ScalarName{
+	"Int":     rest.ScalarInt32,
+	"Float":   rest.ScalarFloat64,
+	"String":  rest.ScalarString,
+	"Boolean": rest.ScalarBoolean,
+	"ID":      rest.ScalarString,
+}
+
+var builtinScalarRepresentations = map[rest.ScalarName]schema.TypeRepresentation{
+	rest.ScalarInt32:   schema.NewTypeRepresentationInt32().Encode(),
+	rest.ScalarFloat64: schema.NewTypeRepresentationFloat64().Encode(),
+	rest.ScalarString:  schema.NewTypeRepresentationString().Encode(),
+	rest.ScalarBoolean: schema.NewTypeRepresentationBoolean().Encode(),
+}
+
+type converter struct {
+	document *Schema
+	options  openapi.ConvertOptions
+	logger   *slog.Logger
+	schema   *rest.NDCHttpSchema
+	types    map[string]*FullType
+	// converted NDC type names of GraphQL types
+	typeNames map[string]string
+}
+
+func newConverter(document *Schema, options openapi.ConvertOptions) *converter {
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	types := make(map[string]*FullType)
+	for i, t := range document.Types {
+		types[t.Name] = &document.Types[i]
+	}
+
+	return &converter{
+		document:  document,
+		options:   options,
+		logger:    logger,
+		schema:    rest.NewNDCHttpSchema(),
+		types:     types,
+		typeNames: map[string]string{},
+	}
+}
+
+// Build converts root fields of the GraphQL schema to NDC operations.
+func (c *converter) Build() (*rest.NDCHttpSchema, error) {
+	c.schema.Settings.Servers = []rest.ServerConfig{
+		{
+			URL: goenvconf.NewEnvStringVariable(
+				utils.StringSliceToConstantCase([]string{c.options.EnvPrefix, "SERVER_URL"}),
+			),
+		},
+	}
+
+	if c.document.QueryType != nil {
+		if err := c.convertRootType(rest.GraphQLQuery, c.document.QueryType.Name, c.schema.Functions); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.document.MutationType != nil {
+		if err := c.convertRootType(rest.GraphQLMutation, c.document.MutationType.Name, c.schema.Procedures); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.schema.Functions) == 0 && len(c.schema.Procedures) == 0 {
+		return nil, errors.New("there is no API to be converted")
+	}
+
+	return c.schema, nil
+}
+
+func (c *converter) convertRootType(
+	operationType rest.GraphQLOperationType,
+	typeName string,
+	operations map[string]rest.OperationInfo,
+) error {
+	rootType, ok := c.types[typeName]
+	if !ok {
+		return fmt.Errorf("%s: the root type %s does not exist", operationType, typeName)
+	}
+
+	for _, field := range rootType.Fields {
+		if field.IsDeprecated && c.options.NoDeprecation {
+			continue
+		}
+
+		operation, err := c.convertOperation(operationType, field)
+		if errors.Is(err, errUnsupportedUnionType) {
+			c.logger.Warn(
+				"skipped the field which returns a union type",
+				slog.String("operation", string(operationType)),
+				slog.String("field", field.Name),
+			)
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", typeName, field.Name, err)
+		}
+
+		operations[c.formatOperationName(field.Name)] = *operation
+	}
+
+	return nil
+}
+
+func (c *converter) convertOperation(
+	operationType rest.GraphQLOperationType,
+	field Field,
+) (*rest.OperationInfo, error) {
+	resultType, err := c.convertTypeRef(&field.Type, false)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments := map[string]rest.ArgumentInfo{}
+	variables := map[string]string{}
+
+	for _, arg := range field.Args {
+		argType, err := c.convertTypeRef(&arg.Type, true)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", arg.Name, err)
+		}
+
+		if arg.DefaultValue != nil {
+			argType = utils.WrapNullableTypeEncoder(argType)
+		}
+
+		arguments[arg.Name] = rest.ArgumentInfo{
+			ArgumentInfo: schema.ArgumentInfo{
+				Description: toDescription(arg.Description),
+				Type:        argType.Encode(),
+			},
+		}
+		variables[arg.Name] = arg.Type.String()
+	}
+
+	return &rest.OperationInfo{
+		Request: &rest.Request{
+			// the server URL is the GraphQL endpoint.
+			URL:    "/",
+			Method: "post",
+			RequestBody: &rest.RequestBody{
+				ContentType: rest.ContentTypeJSON,
+			},
+			Response: rest.Response{
+				ContentType: rest.ContentTypeJSON,
+			},
+			GraphQL: &rest.GraphQLRequest{
+				OperationType: operationType,
+				Field:         field.Name,
+				Variables:     variables,
+			},
+		},
+		Arguments:   arguments,
+		Description: toDescription(field.Description),
+		ResultType:  resultType.Encode(),
+	}, nil
+}
+
+// convertTypeRef converts the GraphQL type reference to NDC type.
+// Types are nullable unless they are wrapped by the non-null modifier.
+func (c *converter) convertTypeRef(ref *TypeRef, input bool) (schema.TypeEncoder, error) {
+	if ref.Kind == KindNonNull {
+		if ref.OfType == nil {
+			return nil, errors.New("the inner type of the non-null type is required")
+		}
+
+		return c.convertNonNullTypeRef(ref.OfType, input)
+	}
+
+	result, err := c.convertNonNullTypeRef(ref, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.NewNullableType(result), nil
+}
+
+func (c *converter) convertNonNullTypeRef(ref *TypeRef, input bool) (schema.TypeEncoder, error) {
+	if ref.Kind == KindList {
+		if ref.OfType == nil {
+			return nil, errors.New("the element type of the list type is required")
+		}
+
+		elementType, err := c.convertTypeRef(ref.OfType, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return schema.NewArrayType(elementType), nil
+	}
+
+	typeName, err := c.convertNamedType(ref.Name, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.NewNamedType(typeName), nil
+}
+
+func (c *converter) convertNamedType(name string, input bool) (string, error) {
+	if scalarName, ok := builtinScalars[name]; ok {
+		scalar := schema.NewScalarType()
+		scalar.Representation = builtinScalarRepresentations[scalarName]
+		c.schema.AddScalar(string(scalarName), *scalar)
+
+		return string(scalarName), nil
+	}
+
+	if typeName, ok := c.typeNames[name]; ok {
+		return typeName, nil
+	}
+
+	typeDef, ok := c.types[name]
+	if !ok {
+		return "", fmt.Errorf("type %s does not exist", name)
+	}
+
+	typeName := c.formatTypeName(name)
+
+	switch typeDef.Kind {
+	case KindScalar:
+		// the format of custom scalars is unknown so the value is passed through as arbitrary JSON.
+		scalar := schema.NewScalarType()
+		scalar.Representation = schema.NewTypeRepresentationJSON().Encode()
+		c.schema.AddScalar(typeName, *scalar)
+	case KindEnum:
+		values := make([]string, 0, len(typeDef.EnumValues))
+
+		for _, value := range typeDef.EnumValues {
+			if value.IsDeprecated && c.options.NoDeprecation {
+				continue
+			}
+
+			values = append(values, value.Name)
+		}
+
+		scalar := schema.NewScalarType()
+		scalar.Representation = schema.NewTypeRepresentationEnum(values).Encode()
+		c.schema.AddScalar(typeName, *scalar)
+	case KindUnion:
+		return "", fmt.Errorf("%s: %w", name, errUnsupportedUnionType)
+	case KindInputObject, KindObject, KindInterface:
+		if input != (typeDef.Kind == KindInputObject) {
+			return "", fmt.Errorf("type %s of kind %s can not be used as %s", name, typeDef.Kind, ioName(input))
+		}
+
+		// register the name before converting fields to support recursive types.
+		c.typeNames[name] = typeName
+
+		objectType, err := c.convertObjectType(typeDef)
+		if err != nil {
+			delete(c.typeNames, name)
+
+			return "", err
+		}
+
+		if typeName != name {
+			objectType.Alias = name
+		}
+
+		c.schema.ObjectTypes[typeName] = *objectType
+	default:
+		return "", fmt.Errorf("type %s has unsupported kind %s", name, typeDef.Kind)
+	}
+
+	c.typeNames[name] = typeName
+
+	return typeName, nil
+}
+
+func (c *converter) convertObjectType(typeDef *FullType) (*rest.ObjectType, error) {
+	objectType := &rest.ObjectType{
+		Description: toDescription(typeDef.Description),
+		Fields:      map[string]rest.ObjectField{},
+	}
+
+	if typeDef.Kind == KindInputObject {
+		for _, field := range typeDef.InputFields {
+			fieldType, err := c.convertTypeRef(&field.Type, true)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", typeDef.Name, field.Name, err)
+			}
+
+			if field.DefaultValue != nil {
+				fieldType = utils.WrapNullableTypeEncoder(fieldType)
+			}
+
+			objectType.Fields[field.Name] = rest.ObjectField{
+				ObjectField: schema.ObjectField{
+					Description: toDescription(field.Description),
+					Type:        fieldType.Encode(),
+				},
+			}
+		}
+
+		return objectType, nil
+	}
+
+	for _, field := range typeDef.Fields {
+		if field.IsDeprecated && c.options.NoDeprecation {
+			continue
+		}
+
+		// the selection set only includes field names, so fields with required arguments can't be selected.
+		if hasRequiredArguments(field.Args) {
+			c.logger.Debug(
+				"skipped the field which has required arguments",
+				slog.String("type", typeDef.Name),
+				slog.String("field", field.Name),
+			)
+
+			continue
+		}
+
+		fieldType, err := c.convertTypeRef(&field.Type, false)
+		if errors.Is(err, errUnsupportedUnionType) {
+			c.logger.Warn(
+				"skipped the field which returns a union type",
+				slog.String("type", typeDef.Name),
+				slog.String("field", field.Name),
+			)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typeDef.Name, field.Name, err)
+		}
+
+		objectType.Fields[field.Name] = rest.ObjectField{
+			ObjectField: schema.ObjectField{
+				Description: toDescription(field.Description),
+				Type:        fieldType.Encode(),
+			},
+		}
+	}
+
+	return objectType, nil
+}
+
+func (c *converter) formatOperationName(name string) string {
+	if c.options.Prefix == "" {
+		return name
+	}
+
+	return utils.StringSliceToCamelCase([]string{c.options.Prefix, name})
+}
+
+func (c *converter) formatTypeName(name string) string {
+	if c.options.Prefix == "" {
+		return name
+	}
+
+	return utils.ToPascalCase(c.options.Prefix) + name
+}
+
+func hasRequiredArguments(args []InputValue) bool {
+	for _, arg := range args {
+		if arg.IsRequired() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toDescription(description string) *string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil
+	}
+
+	return &description
+}
+
+func ioName(input bool) string {
+	if input {
+		return "input"
+	}
+
+	return "output"
+}
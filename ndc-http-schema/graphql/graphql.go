@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// GraphQLToNDCSchema converts a GraphQL introspection result or SDL document to NDC HTTP schema.
+// Fields of the root query and mutation types are converted to functions and procedures
+// which send GraphQL requests to the server URL.
+func GraphQLToNDCSchema(input []byte, options openapi.ConvertOptions) (*rest.NDCHttpSchema, []error) {
+	document, err := parseDocument(input)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	result, err := newConverter(document, options).Build()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return result, nil
+}
+
+func parseDocument(input []byte) (*Schema, error) {
+	input = bytes.TrimPrefix(bytes.TrimSpace(input), []byte("\xef\xbb\xbf"))
+	if !bytes.HasPrefix(input, []byte("{")) {
+		return ParseSDL(string(input))
+	}
+
+	var introspection IntrospectionResult
+	if err := json.Unmarshal(input, &introspection); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case introspection.Schema != nil:
+		return introspection.Schema, nil
+	case introspection.Data != nil && introspection.Data.Schema != nil:
+		return introspection.Data.Schema, nil
+	default:
+		return nil, errors.New("the __schema field of the GraphQL introspection result is required")
+	}
+}
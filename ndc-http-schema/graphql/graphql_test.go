@@ -0,0 +1,120 @@
+package graphql
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/internal/testutil"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	"gotest.tools/v3/assert"
+)
+
+func TestGraphQLToNDCSchema(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Source   string
+		Expected string
+		Schema   string
+		Options  openapi.ConvertOptions
+	}{
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/graphql/testdata/blog/source.graphql -o ./ndc-http-schema/graphql/testdata/blog/expected.json --spec graphql --env-prefix BLOG
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/graphql/testdata/blog/source.graphql -o ./ndc-http-schema/graphql/testdata/blog/schema.json --pure --spec graphql --env-prefix BLOG
+		{
+			Name:     "blog",
+			Source:   "testdata/blog/source.graphql",
+			Expected: "testdata/blog/expected.json",
+			Schema:   "testdata/blog/schema.json",
+			Options: openapi.ConvertOptions{
+				EnvPrefix: "BLOG",
+			},
+		},
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/graphql/testdata/countries/source.json -o ./ndc-http-schema/graphql/testdata/countries/expected.json --spec graphql --prefix geo --no-deprecation
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/graphql/testdata/countries/source.json -o ./ndc-http-schema/graphql/testdata/countries/schema.json --pure --spec graphql --prefix geo --no-deprecation
+		{
+			Name:     "countries",
+			Source:   "testdata/countries/source.json",
+			Expected: "testdata/countries/expected.json",
+			Schema:   "testdata/countries/schema.json",
+			Options: openapi.ConvertOptions{
+				Prefix:        "geo",
+				NoDeprecation: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sourceBytes, err := os.ReadFile(tc.Source)
+			assert.NilError(t, err)
+
+			output, errs := GraphQLToNDCSchema(sourceBytes, tc.Options)
+			if output == nil {
+				t.Fatal(errors.Join(errs...))
+			}
+
+			testutil.AssertJSONFileEqual(t, tc.Expected, output)
+			testutil.AssertJSONFileEqual(t, tc.Schema, output.ToSchemaResponse())
+		})
+	}
+
+	t.Run("failure_empty", func(t *testing.T) {
+		_, errs := GraphQLToNDCSchema([]byte(`type Query { users(id: ID!): SearchResult } union SearchResult = Query`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "there is no API to be converted")
+	})
+
+	t.Run("failure_introspection", func(t *testing.T) {
+		_, errs := GraphQLToNDCSchema([]byte(`{"data": null}`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "__schema field")
+	})
+
+	t.Run("failure_unknown_type", func(t *testing.T) {
+		_, errs := GraphQLToNDCSchema([]byte(`type Query { user: User }`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "Query.user: type User does not exist")
+	})
+}
+
+func TestParseSDL(t *testing.T) {
+	document, err := ParseSDL(`
+		schema { query: RootQuery }
+		type RootQuery {
+			"""
+			  Search items.
+			    Nested line.
+			"""
+			search(
+				term: String! = "a \"quoted\" term",
+				options: SearchOptions = { limit: -10, tags: ["a", "b"] }
+			): [[Item!]]! @deprecated(reason: "use find")
+		}
+	`)
+	assert.NilError(t, err)
+	assert.Equal(t, "RootQuery", document.QueryType.Name)
+	assert.Assert(t, document.MutationType == nil)
+
+	field := document.Types[0].Fields[0]
+	assert.Equal(t, "Search items.\n  Nested line.", field.Description)
+	assert.Equal(t, "[[Item!]]!", field.Type.String())
+	assert.Assert(t, field.IsDeprecated)
+	assert.Equal(t, "use find", field.DeprecationReason)
+	assert.Equal(t, `"a \"quoted\" term"`, *field.Args[0].DefaultValue)
+	assert.Equal(t, `{limit:-10,tags:["a","b"]}`, *field.Args[1].DefaultValue)
+	assert.Assert(t, !field.Args[0].IsRequired())
+
+	_, err = ParseSDL(`type Query { user(id: ID!) User }`)
+	assert.ErrorContains(t, err, "schema.graphql:1:28: Expected :, found Name")
+
+	_, err = ParseSDL(`type Query { user: "User }`)
+	assert.ErrorContains(t, err, "schema.graphql:1:27: Expected Name, found <Invalid>")
+
+	document, err = ParseSDL(`
+		type Query @key(fields: "id") { user: ID }
+		extend type Query { users: [ID!] }
+		enum Role { ADMIN, GUEST @deprecated }
+	`)
+	assert.NilError(t, err)
+	assert.Equal(t, "Query", document.QueryType.Name)
+	assert.Equal(t, 2, len(document.Types[0].Fields))
+	assert.Equal(t, "users", document.Types[0].Fields[1].Name)
+	assert.Assert(t, document.Types[1].EnumValues[1].IsDeprecated)
+}
@@ -0,0 +1,191 @@
+package graphql
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// ParseSDL parses a GraphQL schema definition document to the introspection schema.
+// The document is parsed without validation, so that undeclared directives are allowed.
+// Type references are validated when the schema is converted.
+func ParseSDL(input string) (*Schema, error) {
+	document, err := parser.ParseSchema(&ast.Source{
+		Name:  "schema.graphql",
+		Input: input,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Schema{}
+	types := map[string]int{}
+
+	for _, definitions := range []ast.DefinitionList{document.Definitions, document.Extensions} {
+		for _, def := range definitions {
+			kind, ok := sdlTypeKinds[def.Kind]
+			if !ok {
+				continue
+			}
+
+			index, ok := types[def.Name]
+			if !ok {
+				index = len(result.Types)
+				types[def.Name] = index
+				result.Types = append(result.Types, FullType{
+					Kind: kind,
+					Name: def.Name,
+				})
+			}
+
+			mergeSDLDefinition(&result.Types[index], def)
+		}
+	}
+
+	rootTypes := map[ast.Operation]string{}
+
+	for _, definitions := range []ast.SchemaDefinitionList{document.Schema, document.SchemaExtension} {
+		for _, def := range definitions {
+			for _, operation := range def.OperationTypes {
+				rootTypes[operation.Operation] = operation.Type
+			}
+		}
+	}
+
+	if queryType := getRootTypeName(rootTypes, ast.Query, "Query"); hasType(types, queryType) {
+		result.QueryType = &NamedTypeRef{Name: queryType}
+	}
+
+	if mutationType := getRootTypeName(rootTypes, ast.Mutation, "Mutation"); hasType(types, mutationType) {
+		result.MutationType = &NamedTypeRef{Name: mutationType}
+	}
+
+	return result, nil
+}
+
+var sdlTypeKinds = map[ast.DefinitionKind]TypeKind{
+	ast.Scalar:      KindScalar,
+	ast.Object:      KindObject,
+	ast.Interface:   KindInterface,
+	ast.Union:       KindUnion,
+	ast.Enum:        KindEnum,
+	ast.InputObject: KindInputObject,
+}
+
+func mergeSDLDefinition(typeDef *FullType, def *ast.Definition) {
+	if typeDef.Description == "" {
+		typeDef.Description = def.Description
+	}
+
+	for _, field := range def.Fields {
+		if def.Kind == ast.InputObject {
+			typeDef.InputFields = append(typeDef.InputFields, InputValue{
+				Name:         field.Name,
+				Description:  field.Description,
+				Type:         *convertSDLTypeRef(field.Type),
+				DefaultValue: convertSDLValue(field.DefaultValue),
+			})
+
+			continue
+		}
+
+		item := Field{
+			Name:        field.Name,
+			Description: field.Description,
+			Type:        *convertSDLTypeRef(field.Type),
+		}
+
+		for _, arg := range field.Arguments {
+			item.Args = append(item.Args, InputValue{
+				Name:         arg.Name,
+				Description:  arg.Description,
+				Type:         *convertSDLTypeRef(arg.Type),
+				DefaultValue: convertSDLValue(arg.DefaultValue),
+			})
+		}
+
+		if reason, ok := getDeprecationReason(field.Directives); ok {
+			item.IsDeprecated = true
+			item.DeprecationReason = reason
+		}
+
+		typeDef.Fields = append(typeDef.Fields, item)
+	}
+
+	for _, member := range def.Types {
+		typeDef.PossibleTypes = append(typeDef.PossibleTypes, TypeRef{
+			Kind: KindObject,
+			Name: member,
+		})
+	}
+
+	for _, value := range def.EnumValues {
+		_, deprecated := getDeprecationReason(value.Directives)
+		typeDef.EnumValues = append(typeDef.EnumValues, EnumValue{
+			Name:         value.Name,
+			Description:  value.Description,
+			IsDeprecated: deprecated,
+		})
+	}
+}
+
+func convertSDLTypeRef(typeRef *ast.Type) *TypeRef {
+	var result *TypeRef
+
+	if typeRef.Elem != nil {
+		result = &TypeRef{
+			Kind:   KindList,
+			OfType: convertSDLTypeRef(typeRef.Elem),
+		}
+	} else {
+		result = &TypeRef{Name: typeRef.NamedType}
+	}
+
+	if typeRef.NonNull {
+		result = &TypeRef{
+			Kind:   KindNonNull,
+			OfType: result,
+		}
+	}
+
+	return result
+}
+
+// convertSDLValue returns the GraphQL literal of a constant value.
+func convertSDLValue(value *ast.Value) *string {
+	if value == nil {
+		return nil
+	}
+
+	result := value.String()
+
+	return &result
+}
+
+// getDeprecationReason returns the deprecation reason if the @deprecated directive exists.
+func getDeprecationReason(directives ast.DirectiveList) (string, bool) {
+	directive := directives.ForName("deprecated")
+	if directive == nil {
+		return "", false
+	}
+
+	reason := directive.Arguments.ForName("reason")
+	if reason == nil || reason.Value == nil || reason.Value.Kind != ast.StringValue && reason.Value.Kind != ast.BlockValue {
+		return "", true
+	}
+
+	return reason.Value.Raw, true
+}
+
+func getRootTypeName(rootTypes map[ast.Operation]string, operation ast.Operation, defaultName string) string {
+	if name := rootTypes[operation]; name != "" {
+		return name
+	}
+
+	return defaultName
+}
+
+func hasType(types map[string]int, name string) bool {
+	_, ok := types[name]
+
+	return ok
+}
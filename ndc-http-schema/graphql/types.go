@@ -0,0 +1,107 @@
+package graphql
+
+// TypeKind represents the kind of a GraphQL type in the introspection result.
+type TypeKind string
+
+const (
+	KindScalar      TypeKind = "SCALAR"
+	KindObject      TypeKind = "OBJECT"
+	KindInterface   TypeKind = "INTERFACE"
+	KindUnion       TypeKind = "UNION"
+	KindEnum        TypeKind = "ENUM"
+	KindInputObject TypeKind = "INPUT_OBJECT"
+	KindList        TypeKind = "LIST"
+	KindNonNull     TypeKind = "NON_NULL"
+)
+
+// IntrospectionResult represents the response of the GraphQL introspection query.
+// The schema can be wrapped in the data field or at the root.
+type IntrospectionResult struct {
+	Data   *IntrospectionData `json:"data,omitempty"`
+	Schema *Schema            `json:"__schema,omitempty"`
+}
+
+// IntrospectionData represents the data field of the introspection response.
+type IntrospectionData struct {
+	Schema *Schema `json:"__schema"`
+}
+
+// Schema represents the introspected GraphQL schema.
+type Schema struct {
+	QueryType    *NamedTypeRef `json:"queryType,omitempty"`
+	MutationType *NamedTypeRef `json:"mutationType,omitempty"`
+	Types        []FullType    `json:"types"`
+}
+
+// NamedTypeRef represents a reference to a root operation type.
+type NamedTypeRef struct {
+	Name string `json:"name"`
+}
+
+// FullType represents a named GraphQL type.
+type FullType struct {
+	Kind          TypeKind     `json:"kind"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description,omitempty"`
+	Fields        []Field      `json:"fields,omitempty"`
+	InputFields   []InputValue `json:"inputFields,omitempty"`
+	EnumValues    []EnumValue  `json:"enumValues,omitempty"`
+	PossibleTypes []TypeRef    `json:"possibleTypes,omitempty"`
+}
+
+// Field represents a field of an object or interface type.
+type Field struct {
+	Name              string       `json:"name"`
+	Description       string       `json:"description,omitempty"`
+	Args              []InputValue `json:"args,omitempty"`
+	Type              TypeRef      `json:"type"`
+	IsDeprecated      bool         `json:"isDeprecated,omitempty"`
+	DeprecationReason string       `json:"deprecationReason,omitempty"`
+}
+
+// InputValue represents an argument or a field of an input object type.
+type InputValue struct {
+	Name         string  `json:"name"`
+	Description  string  `json:"description,omitempty"`
+	Type         TypeRef `json:"type"`
+	DefaultValue *string `json:"defaultValue,omitempty"`
+}
+
+// EnumValue represents a value of an enum type.
+type EnumValue struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	IsDeprecated bool   `json:"isDeprecated,omitempty"`
+}
+
+// TypeRef represents a reference to a named type which can be wrapped by list and non-null modifiers.
+type TypeRef struct {
+	Kind   TypeKind `json:"kind"`
+	Name   string   `json:"name,omitempty"`
+	OfType *TypeRef `json:"ofType,omitempty"`
+}
+
+// String returns the type reference in the GraphQL notation, e.g. [ID!]!.
+func (tr TypeRef) String() string {
+	switch tr.Kind {
+	case KindNonNull:
+		if tr.OfType == nil {
+			return ""
+		}
+
+		return tr.OfType.String() + "!"
+	case KindList:
+		if tr.OfType == nil {
+			return ""
+		}
+
+		return "[" + tr.OfType.String() + "]"
+	default:
+		return tr.Name
+	}
+}
+
+// IsRequired checks if the input value must be set, that is non-null without a default value.
+func (iv InputValue) IsRequired() bool {
+	return iv.Type.Kind == KindNonNull && iv.DefaultValue == nil
+}
@@ -0,0 +1,678 @@
+package har
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-http/ndc-http-schema/utils"
+)
+
+var (
+	uuidSegmentRegex     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	numericSegmentRegex  = regexp.MustCompile(`^\d+$`)
+	objectIDSegmentRegex = regexp.MustCompile(`^[0-9a-f]{24}$`)
+	apiKeyHeaderRegex    = regexp.MustCompile(`^x-?api-?key$`)
+)
+
+var skippedHeaderNames = []string{
+	"accept",
+	"accept-encoding",
+	"accept-language",
+	"authorization",
+	"cache-control",
+	"connection",
+	"content-length",
+	"content-type",
+	"cookie",
+	"dnt",
+	"host",
+	"origin",
+	"pragma",
+	"priority",
+	"referer",
+	"te",
+	"upgrade-insecure-requests",
+	"user-agent",
+	"x-requested-with",
+}
+
+var staticFileExtensions = []string{
+	".css", ".js", ".mjs", ".map", ".html", ".htm", ".ico", ".png", ".jpg", ".jpeg", ".gif",
+	".svg", ".webp", ".woff", ".woff2", ".ttf", ".eot",
+}
+
+type paramSamples struct {
+	Name   string
+	Count  int
+	Schema map[string]any
+}
+
+type operationSamples struct {
+	Method              string
+	Path                string
+	Count               int
+	BaseURLs            map[string]int
+	PathParams          []*paramSamples
+	Query               []*paramSamples
+	Headers             []*paramSamples
+	Security            map[string]bool
+	BodyContentType     string
+	BodySchema          map[string]any
+	ResponseStatus      int
+	ResponseContentType string
+	ResponseSchema      map[string]any
+}
+
+type converter struct {
+	logger          *slog.Logger
+	operations      map[string]*operationSamples
+	operationKeys   []string
+	baseURLs        []string
+	baseURLCounts   map[string]int
+	securitySchemes map[string]any
+}
+
+func newConverter(logger *slog.Logger) *converter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &converter{
+		logger:          logger,
+		operations:      make(map[string]*operationSamples),
+		baseURLCounts:   make(map[string]int),
+		securitySchemes: make(map[string]any),
+	}
+}
+
+// Build groups entries by method and templated path and converts them to an OpenAPI 3 document.
+func (c *converter) Build(entries []Entry) (map[string]any, error) {
+	for _, entry := range entries {
+		if err := c.addEntry(entry); err != nil {
+			c.logger.Debug(
+				"skipped the HAR entry",
+				slog.String("method", entry.Request.Method),
+				slog.String("url", entry.Request.URL),
+				slog.String("reason", err.Error()),
+			)
+		}
+	}
+
+	if len(c.operations) == 0 {
+		return nil, errors.New("there is no API to be converted")
+	}
+
+	defaultBaseURL := c.getDefaultBaseURL()
+	paths := map[string]map[string]any{}
+	securityKeys := map[string]bool{}
+	allSecured := true
+
+	for _, key := range c.operationKeys {
+		op := c.operations[key]
+		for scheme := range op.Security {
+			securityKeys[scheme] = true
+		}
+
+		if len(op.Security) == 0 {
+			allSecured = false
+		}
+	}
+
+	// use the global security if all operations are authenticated with the same scheme.
+	var globalSecurity string
+	if allSecured && len(securityKeys) == 1 {
+		for key := range securityKeys {
+			globalSecurity = key
+		}
+	}
+
+	for _, key := range c.operationKeys {
+		op := c.operations[key]
+
+		operation := c.buildOperation(op, globalSecurity)
+		if baseURL := op.getBaseURL(); baseURL != defaultBaseURL {
+			operation["servers"] = []any{
+				map[string]any{"url": baseURL, "x-server-id": getServerID(baseURL)},
+			}
+		}
+
+		if _, ok := paths[op.Path]; !ok {
+			paths[op.Path] = map[string]any{}
+		}
+
+		paths[op.Path][op.Method] = operation
+	}
+
+	document := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "HAR",
+			"version": "1.0.0",
+		},
+		"servers": []any{
+			map[string]any{"url": defaultBaseURL},
+		},
+		"paths": paths,
+	}
+
+	if len(c.securitySchemes) > 0 {
+		document["components"] = map[string]any{
+			"securitySchemes": c.securitySchemes,
+		}
+	}
+
+	if globalSecurity != "" {
+		document["security"] = []any{map[string]any{globalSecurity: []string{}}}
+	}
+
+	return document, nil
+}
+
+func (c *converter) addEntry(entry Entry) error {
+	u, err := url.Parse(entry.Request.URL)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("unsupported scheme " + u.Scheme)
+	}
+
+	if slices.Contains(staticFileExtensions, strings.ToLower(path.Ext(u.Path))) {
+		return errors.New("static file")
+	}
+
+	responseContentType := parseMimeType(entry.Response.Content.MimeType)
+	if !isAPIContentType(responseContentType) {
+		return errors.New("unsupported response content type " + responseContentType)
+	}
+
+	method := strings.ToLower(entry.Request.Method)
+	if method == "" || method == "options" || method == "head" {
+		return errors.New("unsupported method " + method)
+	}
+
+	apiPath, pathValues := templatePath(u.Path)
+	key := method + " " + apiPath
+
+	op, ok := c.operations[key]
+	if !ok {
+		op = &operationSamples{
+			Method:   method,
+			Path:     apiPath,
+			BaseURLs: map[string]int{},
+			Security: map[string]bool{},
+		}
+		c.operations[key] = op
+		c.operationKeys = append(c.operationKeys, key)
+	}
+
+	baseURL := u.Scheme + "://" + u.Host
+	if _, ok := c.baseURLCounts[baseURL]; !ok {
+		c.baseURLs = append(c.baseURLs, baseURL)
+	}
+
+	c.baseURLCounts[baseURL]++
+	op.BaseURLs[baseURL]++
+	op.Count++
+
+	for i, value := range pathValues {
+		if i >= len(op.PathParams) {
+			op.PathParams = append(op.PathParams, &paramSamples{Name: value.Name})
+		}
+
+		op.PathParams[i].add(utils.InferStringScalarSchema(value.Value))
+	}
+
+	c.addQueryParams(op, u.Query())
+	c.addHeaders(op, entry.Request.Headers)
+	c.addRequestBody(op, entry.Request.PostData)
+	c.addResponse(op, entry.Response, responseContentType)
+
+	return nil
+}
+
+func (c *converter) addQueryParams(op *operationSamples, query url.Values) {
+	for _, name := range slices.Sorted(maps.Keys(query)) {
+		values := query[name]
+		schema := utils.InferStringScalarSchema(values[0])
+
+		for _, value := range values[1:] {
+			schema = utils.MergeJSONSchemas(schema, utils.InferStringScalarSchema(value))
+		}
+
+		if len(values) > 1 {
+			schema = map[string]any{"type": "array", "items": schema}
+		}
+
+		op.Query = addParamSample(op.Query, name, schema)
+	}
+}
+
+func (c *converter) addHeaders(op *operationSamples, headers []NameValue) {
+	names := map[string]bool{}
+
+	for _, header := range headers {
+		name := strings.ToLower(header.Name)
+		if name == "" || names[name] {
+			continue
+		}
+
+		names[name] = true
+
+		switch {
+		case name == "authorization":
+			if scheme := c.registerAuthorization(header.Value); scheme != "" {
+				op.Security[scheme] = true
+			}
+		case apiKeyHeaderRegex.MatchString(name):
+			op.Security[c.registerSecurityScheme("apiKey", map[string]any{
+				"type": "apiKey",
+				"in":   "header",
+				"name": header.Name,
+			})] = true
+		case strings.HasPrefix(name, ":") || strings.HasPrefix(name, "sec-") ||
+			strings.HasPrefix(name, "if-") || slices.Contains(skippedHeaderNames, name):
+			// skip pseudo, browser and standard headers.
+		default:
+			op.Headers = addParamSample(op.Headers, name, map[string]any{"type": "string"})
+		}
+	}
+}
+
+func (c *converter) addRequestBody(op *operationSamples, postData *PostData) {
+	if postData == nil {
+		return
+	}
+
+	contentType := parseMimeType(postData.MimeType)
+
+	var schema map[string]any
+
+	switch {
+	case utils.IsContentTypeJSON(contentType):
+		value, ok := decodeJSON(postData.Text)
+		if !ok {
+			return
+		}
+
+		contentType = rest.ContentTypeJSON
+		schema = utils.InferRequiredJSONSchema(value)
+	case contentType == rest.ContentTypeFormURLEncoded || utils.IsContentTypeMultipartForm(contentType):
+		if utils.IsContentTypeMultipartForm(contentType) {
+			contentType = rest.ContentTypeMultipartFormData
+		}
+
+		params := postData.Params
+		if len(params) == 0 && contentType == rest.ContentTypeFormURLEncoded {
+			values, _ := url.ParseQuery(postData.Text)
+			for key, items := range values {
+				for _, item := range items {
+					params = append(params, Param{Name: key, Value: item})
+				}
+			}
+		}
+
+		properties := map[string]any{}
+
+		for _, param := range params {
+			if param.FileName != "" {
+				properties[param.Name] = map[string]any{"type": "string", "format": "binary"}
+			} else {
+				properties[param.Name] = utils.InferStringScalarSchema(param.Value)
+			}
+		}
+
+		schema = map[string]any{"type": "object", "properties": properties}
+	case postData.Text != "":
+		schema = map[string]any{"type": "string"}
+	default:
+		return
+	}
+
+	switch {
+	case op.BodySchema == nil:
+		op.BodyContentType = contentType
+		op.BodySchema = schema
+	case op.BodyContentType == contentType:
+		op.BodySchema = utils.MergeJSONSchemas(op.BodySchema, schema)
+	}
+}
+
+// addResponse infers the result schema from successful responses of the lowest status code.
+func (c *converter) addResponse(op *operationSamples, response Response, contentType string) {
+	status := response.Status
+	if status < 200 || status >= 300 || (op.ResponseStatus != 0 && status > op.ResponseStatus) {
+		return
+	}
+
+	if status < op.ResponseStatus || op.ResponseStatus == 0 {
+		op.ResponseStatus = status
+		op.ResponseContentType = ""
+		op.ResponseSchema = nil
+	}
+
+	text := response.Content.Text
+	if response.Content.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return
+		}
+
+		text = string(decoded)
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	var schema map[string]any
+
+	if utils.IsContentTypeJSON(contentType) {
+		value, ok := decodeJSON(text)
+		if !ok {
+			return
+		}
+
+		contentType = rest.ContentTypeJSON
+		schema = utils.InferRequiredJSONSchema(value)
+	} else {
+		schema = map[string]any{"type": "string"}
+	}
+
+	switch {
+	case op.ResponseSchema == nil:
+		op.ResponseContentType = contentType
+		op.ResponseSchema = schema
+	case op.ResponseContentType == contentType:
+		op.ResponseSchema = utils.MergeJSONSchemas(op.ResponseSchema, schema)
+	}
+}
+
+func (c *converter) buildOperation(op *operationSamples, globalSecurity string) map[string]any {
+	parameters := []any{}
+
+	for _, param := range op.PathParams {
+		parameters = append(parameters, map[string]any{
+			"name":     param.Name,
+			"in":       rest.InPath,
+			"required": true,
+			"schema":   param.Schema,
+		})
+	}
+
+	for _, param := range op.Query {
+		parameters = append(parameters, map[string]any{
+			"name":     param.Name,
+			"in":       rest.InQuery,
+			"required": param.Count == op.Count,
+			"schema":   param.Schema,
+		})
+	}
+
+	for _, param := range op.Headers {
+		parameters = append(parameters, map[string]any{
+			"name":     param.Name,
+			"in":       rest.InHeader,
+			"required": param.Count == op.Count,
+			"schema":   param.Schema,
+		})
+	}
+
+	operation := map[string]any{
+		"summary":   strings.ToUpper(op.Method) + " " + op.Path,
+		"responses": op.buildResponses(),
+	}
+
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if op.BodySchema != nil {
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				op.BodyContentType: map[string]any{"schema": op.BodySchema},
+			},
+		}
+	}
+
+	if globalSecurity == "" && len(op.Security) > 0 {
+		var security []any
+
+		for _, scheme := range slices.Sorted(maps.Keys(op.Security)) {
+			security = append(security, map[string]any{scheme: []string{}})
+		}
+
+		operation["security"] = security
+	}
+
+	return operation
+}
+
+func (op *operationSamples) buildResponses() map[string]any {
+	if op.ResponseStatus == 0 {
+		return map[string]any{
+			"200": map[string]any{"description": "OK"},
+		}
+	}
+
+	response := map[string]any{
+		"description": http.StatusText(op.ResponseStatus),
+	}
+
+	if op.ResponseSchema != nil {
+		response["content"] = map[string]any{
+			op.ResponseContentType: map[string]any{"schema": op.ResponseSchema},
+		}
+	}
+
+	return map[string]any{
+		strconv.Itoa(op.ResponseStatus): response,
+	}
+}
+
+// getBaseURL returns the most used base URL of the operation.
+func (op *operationSamples) getBaseURL() string {
+	var result string
+
+	for baseURL, count := range op.BaseURLs {
+		if count > op.BaseURLs[result] || (count == op.BaseURLs[result] && baseURL < result) {
+			result = baseURL
+		}
+	}
+
+	return result
+}
+
+func (c *converter) getDefaultBaseURL() string {
+	var result string
+
+	for _, baseURL := range c.baseURLs {
+		if c.baseURLCounts[baseURL] > c.baseURLCounts[result] {
+			result = baseURL
+		}
+	}
+
+	return result
+}
+
+func (c *converter) registerAuthorization(value string) string {
+	scheme, _, _ := strings.Cut(value, " ")
+
+	switch strings.ToLower(scheme) {
+	case "bearer":
+		return c.registerSecurityScheme("bearerAuth", map[string]any{
+			"type":   "http",
+			"scheme": "bearer",
+		})
+	case "basic":
+		return c.registerSecurityScheme("basicAuth", map[string]any{
+			"type":   "http",
+			"scheme": "basic",
+		})
+	default:
+		return ""
+	}
+}
+
+func (c *converter) registerSecurityScheme(key string, scheme map[string]any) string {
+	if _, ok := c.securitySchemes[key]; !ok {
+		c.securitySchemes[key] = scheme
+	}
+
+	return key
+}
+
+func (ps *paramSamples) add(schema map[string]any) {
+	ps.Count++
+
+	if ps.Schema == nil {
+		ps.Schema = schema
+	} else {
+		ps.Schema = utils.MergeJSONSchemas(ps.Schema, schema)
+	}
+
+	if len(ps.Schema) == 0 {
+		ps.Schema = map[string]any{"type": "string"}
+	}
+}
+
+func addParamSample(params []*paramSamples, name string, schema map[string]any) []*paramSamples {
+	for _, param := range params {
+		if param.Name == name {
+			param.add(schema)
+
+			return params
+		}
+	}
+
+	param := &paramSamples{Name: name}
+	param.add(schema)
+
+	return append(params, param)
+}
+
+type pathValue struct {
+	Name  string
+	Value string
+}
+
+// templatePath replaces identifier segments such as numeric IDs and UUIDs with path parameters.
+// The parameter name is derived from the previous segment, e.g. /users/1 becomes /users/{userId}.
+func templatePath(rawPath string) (string, []pathValue) {
+	var segments []string
+
+	var values []pathValue
+
+	names := map[string]bool{}
+	previous := ""
+
+	for segment := range strings.SplitSeq(rawPath, "/") {
+		if segment == "" {
+			continue
+		}
+
+		if !isIdentifierSegment(segment) {
+			segments = append(segments, segment)
+			previous = segment
+
+			continue
+		}
+
+		name := "id"
+		if previous != "" {
+			name = utils.ToCamelCase(singularize(previous) + "_id")
+		}
+
+		uniqueName := name
+		for i := 2; names[uniqueName]; i++ {
+			uniqueName = name + strconv.Itoa(i)
+		}
+
+		names[uniqueName] = true
+		previous = ""
+
+		segments = append(segments, "{"+uniqueName+"}")
+		values = append(values, pathValue{Name: uniqueName, Value: segment})
+	}
+
+	return "/" + strings.Join(segments, "/"), values
+}
+
+func isIdentifierSegment(segment string) bool {
+	return numericSegmentRegex.MatchString(segment) ||
+		uuidSegmentRegex.MatchString(segment) ||
+		objectIDSegmentRegex.MatchString(segment)
+}
+
+func singularize(word string) string {
+	word = strings.ToLower(word)
+
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
+
+func getServerID(baseURL string) string {
+	host := baseURL
+	if _, remain, ok := strings.Cut(host, "://"); ok {
+		host = remain
+	}
+
+	return utils.ToCamelCase(host)
+}
+
+func parseMimeType(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err != nil {
+		return strings.ToLower(value)
+	}
+
+	return mediaType
+}
+
+// isAPIContentType checks if the response content type is of an API rather than a web page or a static file.
+func isAPIContentType(contentType string) bool {
+	return contentType == "" || contentType == "x-unknown" ||
+		utils.IsContentTypeJSON(contentType) ||
+		utils.IsContentTypeXML(contentType) ||
+		contentType == rest.ContentTypeTextPlain ||
+		contentType == rest.ContentTypeNdJSON
+}
+
+func decodeJSON(input string) (any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(input)))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
@@ -0,0 +1,34 @@
+package har
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// HARToNDCSchema infers NDC HTTP schema from recorded traffic in a HTTP Archive (HAR) document.
+// Entries are grouped into operations of an OpenAPI 3 document which is built with the OpenAPI converter.
+func HARToNDCSchema(input []byte, options openapi.ConvertOptions) (*rest.NDCHttpSchema, []error) {
+	var archive HAR
+	if err := json.Unmarshal(input, &archive); err != nil {
+		return nil, []error{err}
+	}
+
+	if len(archive.Log.Entries) == 0 {
+		return nil, []error{errors.New("there is no API to be converted")}
+	}
+
+	document, err := newConverter(options.Logger).Build(archive.Log.Entries)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	rawDocument, err := json.Marshal(document)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return openapi.OpenAPIv3ToNDCSchema(rawDocument, options)
+}
@@ -0,0 +1,69 @@
+package har
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/internal/testutil"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	"gotest.tools/v3/assert"
+)
+
+func TestHARToNDCSchema(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Source   string
+		Expected string
+		Schema   string
+		Options  openapi.ConvertOptions
+	}{
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/har/testdata/petstore/source.har.json -o ./ndc-http-schema/har/testdata/petstore/expected.json --spec har
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/har/testdata/petstore/source.har.json -o ./ndc-http-schema/har/testdata/petstore/schema.json --pure --spec har
+		{
+			Name:     "petstore",
+			Source:   "testdata/petstore/source.har.json",
+			Expected: "testdata/petstore/expected.json",
+			Schema:   "testdata/petstore/schema.json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sourceBytes, err := os.ReadFile(tc.Source)
+			assert.NilError(t, err)
+
+			output, errs := HARToNDCSchema(sourceBytes, tc.Options)
+			if output == nil {
+				t.Fatal(errors.Join(errs...))
+			}
+
+			testutil.AssertJSONFileEqual(t, tc.Expected, output)
+			testutil.AssertJSONFileEqual(t, tc.Schema, output.ToSchemaResponse())
+		})
+	}
+
+	t.Run("failure_no_api", func(t *testing.T) {
+		_, errs := HARToNDCSchema([]byte(`{"log": {"entries": [{
+			"request": {"method": "GET", "url": "https://example.com/app.js"},
+			"response": {"status": 200, "content": {"mimeType": "application/javascript"}}
+		}]}}`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "there is no API to be converted")
+	})
+}
+
+func TestTemplatePath(t *testing.T) {
+	for input, expected := range map[string]string{
+		"/v1/pets":        "/v1/pets",
+		"/v1/pets/1":      "/v1/pets/{petId}",
+		"/categories/2/x": "/categories/{categoryId}/x",
+		"/addresses/3":    "/addresses/{addressId}",
+		"/1/2":            "/{id}/{id2}",
+		"/users/6b0a4bd3-8f0e-4c49-9b2c-0e3d5d1a3c11/orders/507f1f77bcf86cd799439011": "/users/{userId}/orders/{orderId}",
+	} {
+		t.Run(input, func(t *testing.T) {
+			result, _ := templatePath(input)
+			assert.Equal(t, expected, result)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package har
+
+// HAR represents a HTTP Archive document.
+type HAR struct {
+	Log Log `json:"log"`
+}
+
+// Log represents the root log object of the HAR document.
+type Log struct {
+	Version string  `json:"version,omitempty"`
+	Entries []Entry `json:"entries"`
+}
+
+// Entry represents a recorded request and response pair.
+type Entry struct {
+	StartedDateTime string   `json:"startedDateTime,omitempty"`
+	Request         Request  `json:"request"`
+	Response        Response `json:"response"`
+}
+
+// Request represents the recorded HTTP request.
+type Request struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	Headers     []NameValue `json:"headers,omitempty"`
+	QueryString []NameValue `json:"queryString,omitempty"`
+	PostData    *PostData   `json:"postData,omitempty"`
+}
+
+// Response represents the recorded HTTP response.
+type Response struct {
+	Status  int         `json:"status"`
+	Headers []NameValue `json:"headers,omitempty"`
+	Content Content     `json:"content"`
+}
+
+// NameValue represents a header or query parameter.
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PostData represents the request body.
+type PostData struct {
+	MimeType string  `json:"mimeType"`
+	Text     string  `json:"text,omitempty"`
+	Params   []Param `json:"params,omitempty"`
+}
+
+// Param represents a field of the form body.
+type Param struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// Content represents the response body.
+type Content struct {
+	Size     int64  `json:"size,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
@@ -0,0 +1,28 @@
+// Package testutil contains shared helpers of converter tests.
+package testutil
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// AssertJSONFileEqual asserts that the JSON encoding of the output equals the content of the JSON file.
+func AssertJSONFileEqual(t *testing.T, filePath string, output any) {
+	t.Helper()
+
+	expectedBytes, err := os.ReadFile(filePath)
+	assert.NilError(t, err)
+
+	var expected any
+	assert.NilError(t, json.Unmarshal(expectedBytes, &expected))
+
+	outputBytes, err := json.Marshal(output)
+	assert.NilError(t, err)
+
+	var result any
+	assert.NilError(t, json.Unmarshal(outputBytes, &result))
+	assert.DeepEqual(t, expected, result)
+}
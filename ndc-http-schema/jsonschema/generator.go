@@ -37,6 +37,13 @@ func jsonSchemaConvertConfig() error {
 		return err
 	}
 
+	if err := r.AddGoComments(
+		"github.com/hasura/ndc-http/ndc-http-schema/openapi",
+		"../openapi/internal",
+	); err != nil {
+		return err
+	}
+
 	reflectSchema := r.Reflect(&configuration.ConvertConfig{})
 
 	schemaBytes, err := json.MarshalIndent(reflectSchema, "", "  ")
@@ -61,6 +68,13 @@ func jsonSchemaConnectorConfiguration() error {
 		return err
 	}
 
+	if err := r.AddGoComments(
+		"github.com/hasura/ndc-http/ndc-http-schema/openapi",
+		"../openapi/internal",
+	); err != nil {
+		return err
+	}
+
 	reflectSchema := r.Reflect(&configuration.Configuration{})
 
 	schemaBytes, err := json.MarshalIndent(reflectSchema, "", "  ")
@@ -85,12 +99,17 @@ func jsonSchemaNDCHttpSchema() error {
 	}
 
 	flowSchema := r.Reflect(&schema.OAuthFlow{})
+	tokenEndpointSchema := r.Reflect(&schema.TokenEndpointRequest{})
 	reflectSchema := r.Reflect(&schema.NDCHttpSchema{})
 
 	for k, def := range flowSchema.Definitions {
 		reflectSchema.Definitions[k] = def
 	}
 
+	for k, def := range tokenEndpointSchema.Definitions {
+		reflectSchema.Definitions[k] = def
+	}
+
 	schemaBytes, err := json.MarshalIndent(reflectSchema, "", "  ")
 	if err != nil {
 		return err
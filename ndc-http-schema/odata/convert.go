@@ -0,0 +1,981 @@
+package odata
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-http/ndc-http-schema/utils"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
+	sdkUtils "github.com/hasura/ndc-sdk-go/v2/utils"
+)
+
+var errUnsupportedOperation = errors.New("unsupported operation")
+
+// primitive EDM types and their equivalent NDC scalars.
+// Geography and geometry types are converted to JSON.
+var edmScalars = map[string]rest.ScalarName{
+	"Binary":         rest.ScalarBytes,
+	"Boolean":        rest.ScalarBoolean,
+	"Byte":           rest.ScalarInt32,
+	"Date":           rest.ScalarDate,
+	"DateTimeOffset": rest.ScalarTimestampTZ,
+	"Decimal":        rest.ScalarBigDecimal,
+	"Double":         rest.ScalarFloat64,
+	"Duration":       rest.ScalarString,
+	"Guid":           rest.ScalarUUID,
+	"Int16":          rest.ScalarInt32,
+	"Int32":          rest.ScalarInt32,
+	"Int64":          rest.ScalarInt64,
+	"SByte":          rest.ScalarInt32,
+	"Single":         rest.ScalarFloat32,
+	"String":         rest.ScalarString,
+	"TimeOfDay":      rest.ScalarString,
+}
+
+var scalarRepresentations = map[rest.ScalarName]schema.TypeRepresentation{
+	rest.ScalarBigDecimal:  schema.NewTypeRepresentationBigDecimal().Encode(),
+	rest.ScalarBoolean:     schema.NewTypeRepresentationBoolean().Encode(),
+	rest.ScalarBytes:       schema.NewTypeRepresentationBytes().Encode(),
+	rest.ScalarDate:        schema.NewTypeRepresentationDate().Encode(),
+	rest.ScalarFloat32:     schema.NewTypeRepresentationFloat32().Encode(),
+	rest.ScalarFloat64:     schema.NewTypeRepresentationFloat64().Encode(),
+	rest.ScalarInt32:       schema.NewTypeRepresentationInt32().Encode(),
+	rest.ScalarInt64:       schema.NewTypeRepresentationInt64().Encode(),
+	rest.ScalarJSON:        schema.NewTypeRepresentationJSON().Encode(),
+	rest.ScalarString:      schema.NewTypeRepresentationString().Encode(),
+	rest.ScalarTimestampTZ: schema.NewTypeRepresentationTimestampTZ().Encode(),
+	rest.ScalarUUID:        schema.NewTypeRepresentationUUID().Encode(),
+}
+
+// prefixes of quoted literals of primitive types in URLs, e.g. 'Seattle' or duration'P1D'.
+// Literals of other primitive types aren't quoted.
+var quotedLiteralPrefixes = map[string]string{
+	"Binary":   "binary",
+	"Duration": "duration",
+	"String":   "",
+}
+
+// queryOption represents a system query option which is mapped from an argument.
+type queryOption struct {
+	name        string
+	description string
+	scalar      rest.ScalarName
+	array       bool
+}
+
+var entityQueryOptions = []queryOption{
+	{
+		name:        "select",
+		description: "Properties to be returned",
+		scalar:      rest.ScalarString,
+		array:       true,
+	},
+	{
+		name:        "expand",
+		description: "Related entities to be included inline, e.g. Orders($select=ID)",
+		scalar:      rest.ScalarString,
+		array:       true,
+	},
+}
+
+var collectionQueryOptions = append([]queryOption{
+	{
+		name:        "filter",
+		description: "Boolean expression which filters entities, e.g. Price lt 10",
+		scalar:      rest.ScalarString,
+	},
+	{
+		name:        "orderby",
+		description: "Expressions to sort entities, e.g. Name desc",
+		scalar:      rest.ScalarString,
+		array:       true,
+	},
+	{
+		name:        "skip",
+		description: "Number of entities to be skipped",
+		scalar:      rest.ScalarInt32,
+	},
+	{
+		name:        "top",
+		description: "Maximum number of entities to be returned",
+		scalar:      rest.ScalarInt32,
+	},
+}, entityQueryOptions...)
+
+type converter struct {
+	document *Edmx
+	options  openapi.ConvertOptions
+	logger   *slog.Logger
+	schema   *rest.NDCHttpSchema
+	// namespaces of schemas indexed by namespaces and aliases
+	namespaces map[string]string
+	// model elements indexed by namespace qualified names
+	structuredTypes map[string]*StructuredType
+	entityTypes     map[string]bool
+	enumTypes       map[string]*EnumType
+	typeDefinitions map[string]*TypeDefinition
+	actions         map[string][]*Operation
+	functions       map[string][]*Operation
+	// names of entity sets indexed by qualified names of entity types
+	entitySets map[string][]string
+	// converted NDC type names of model elements
+	typeNames map[string]string
+}
+
+func newConverter(document *Edmx, options openapi.ConvertOptions) *converter {
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	c := &converter{
+		document:        document,
+		options:         options,
+		logger:          logger,
+		schema:          rest.NewNDCHttpSchema(),
+		namespaces:      map[string]string{},
+		structuredTypes: map[string]*StructuredType{},
+		entityTypes:     map[string]bool{},
+		enumTypes:       map[string]*EnumType{},
+		typeDefinitions: map[string]*TypeDefinition{},
+		actions:         map[string][]*Operation{},
+		functions:       map[string][]*Operation{},
+		entitySets:      map[string][]string{},
+		typeNames:       map[string]string{},
+	}
+
+	schemas := document.DataServices.Schemas
+
+	for i := range schemas {
+		c.namespaces[schemas[i].Namespace] = schemas[i].Namespace
+
+		if schemas[i].Alias != "" {
+			c.namespaces[schemas[i].Alias] = schemas[i].Namespace
+		}
+	}
+
+	for i := range schemas {
+		item := &schemas[i]
+
+		for j := range item.EntityTypes {
+			name := item.Namespace + "." + item.EntityTypes[j].Name
+			c.structuredTypes[name] = &item.EntityTypes[j]
+			c.entityTypes[name] = true
+		}
+
+		for j := range item.ComplexTypes {
+			c.structuredTypes[item.Namespace+"."+item.ComplexTypes[j].Name] = &item.ComplexTypes[j]
+		}
+
+		for j := range item.EnumTypes {
+			c.enumTypes[item.Namespace+"."+item.EnumTypes[j].Name] = &item.EnumTypes[j]
+		}
+
+		for j := range item.TypeDefinitions {
+			c.typeDefinitions[item.Namespace+"."+item.TypeDefinitions[j].Name] = &item.TypeDefinitions[j]
+		}
+
+		for j := range item.Actions {
+			name := item.Namespace + "." + item.Actions[j].Name
+			c.actions[name] = append(c.actions[name], &item.Actions[j])
+		}
+
+		for j := range item.Functions {
+			name := item.Namespace + "." + item.Functions[j].Name
+			c.functions[name] = append(c.functions[name], &item.Functions[j])
+		}
+
+		if item.EntityContainer != nil {
+			for _, entitySet := range item.EntityContainer.EntitySets {
+				name := c.qualify(entitySet.EntityType)
+				c.entitySets[name] = append(c.entitySets[name], entitySet.Name)
+			}
+		}
+	}
+
+	return c
+}
+
+// Build converts resources of the entity container to NDC operations.
+// Entity sets, singletons and functions are converted to functions. Actions are converted to procedures.
+func (c *converter) Build() (*rest.NDCHttpSchema, error) {
+	schemas := c.document.DataServices.Schemas
+
+	for i := range schemas {
+		if schemas[i].EntityContainer == nil {
+			continue
+		}
+
+		if err := c.convertEntityContainer(schemas[i].EntityContainer); err != nil {
+			return nil, fmt.Errorf("%s: %w", schemas[i].EntityContainer.Name, err)
+		}
+	}
+
+	for i := range schemas {
+		for j := range schemas[i].Functions {
+			if err := c.convertBoundOperation(schemas[i].Namespace, &schemas[i].Functions[j], true); err != nil {
+				return nil, fmt.Errorf("%s: %w", schemas[i].Functions[j].Name, err)
+			}
+		}
+
+		for j := range schemas[i].Actions {
+			if err := c.convertBoundOperation(schemas[i].Namespace, &schemas[i].Actions[j], false); err != nil {
+				return nil, fmt.Errorf("%s: %w", schemas[i].Actions[j].Name, err)
+			}
+		}
+	}
+
+	if len(c.schema.Functions) == 0 && len(c.schema.Procedures) == 0 {
+		return nil, errors.New("there is no API to be converted")
+	}
+
+	c.schema.Settings.Servers = []rest.ServerConfig{
+		{
+			URL: goenvconf.NewEnvStringVariable(
+				utils.StringSliceToConstantCase([]string{c.options.EnvPrefix, "SERVER_URL"}),
+			),
+		},
+	}
+
+	return c.schema, nil
+}
+
+func (c *converter) convertEntityContainer(container *EntityContainer) error {
+	for _, entitySet := range container.EntitySets {
+		typeName, err := c.convertNamedType(entitySet.EntityType)
+		if err != nil {
+			return fmt.Errorf("%s: %w", entitySet.Name, err)
+		}
+
+		arguments := map[string]rest.ArgumentInfo{}
+		c.addQueryOptions(arguments, collectionQueryOptions)
+
+		c.schema.Functions[c.formatOperationName(entitySet.Name)] = rest.OperationInfo{
+			Request:     c.newRequest("/"+entitySet.Name, "get", true),
+			Arguments:   arguments,
+			Description: toDescription(getDescription(entitySet.Annotations)),
+			ResultType:  schema.NewArrayType(schema.NewNamedType(typeName)).Encode(),
+		}
+	}
+
+	for _, singleton := range container.Singletons {
+		typeName, err := c.convertNamedType(singleton.Type)
+		if err != nil {
+			return fmt.Errorf("%s: %w", singleton.Name, err)
+		}
+
+		arguments := map[string]rest.ArgumentInfo{}
+		c.addQueryOptions(arguments, entityQueryOptions)
+
+		c.schema.Functions[c.formatOperationName(singleton.Name)] = rest.OperationInfo{
+			Request:     c.newRequest("/"+singleton.Name, "get", false),
+			Arguments:   arguments,
+			Description: toDescription(getDescription(singleton.Annotations)),
+			ResultType:  schema.NewNamedType(typeName).Encode(),
+		}
+	}
+
+	for _, functionImport := range container.FunctionImports {
+		function := findUnboundOperation(c.functions[c.qualify(functionImport.Function)])
+		if function == nil {
+			return fmt.Errorf("%s: the function %s does not exist", functionImport.Name, functionImport.Function)
+		}
+
+		name := c.formatOperationName(functionImport.Name)
+
+		operation, err := c.convertOperation(name, "/"+functionImport.Name, function, function.Parameters, map[string]rest.ArgumentInfo{}, true)
+		if errors.Is(err, errUnsupportedOperation) {
+			c.warnUnsupportedOperation(functionImport.Name, err)
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", functionImport.Name, err)
+		}
+
+		if description := getDescription(functionImport.Annotations); description != "" {
+			operation.Description = &description
+		}
+
+		c.schema.Functions[name] = *operation
+	}
+
+	for _, actionImport := range container.ActionImports {
+		action := findUnboundOperation(c.actions[c.qualify(actionImport.Action)])
+		if action == nil {
+			return fmt.Errorf("%s: the action %s does not exist", actionImport.Name, actionImport.Action)
+		}
+
+		name := c.formatOperationName(actionImport.Name)
+
+		operation, err := c.convertOperation(name, "/"+actionImport.Name, action, action.Parameters, map[string]rest.ArgumentInfo{}, false)
+		if errors.Is(err, errUnsupportedOperation) {
+			c.warnUnsupportedOperation(actionImport.Name, err)
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", actionImport.Name, err)
+		}
+
+		if description := getDescription(actionImport.Annotations); description != "" {
+			operation.Description = &description
+		}
+
+		c.schema.Procedures[name] = *operation
+	}
+
+	return nil
+}
+
+// convertBoundOperation converts the bound action or function for each entity set of the binding type.
+// The operation is invoked on a single entity by the key, e.g. /Orders(1)/Namespace.Approve,
+// or on the entity set if the binding parameter is a collection, e.g. /Orders/Namespace.ApproveAll.
+func (c *converter) convertBoundOperation(namespace string, operation *Operation, isFunction bool) error {
+	if !operation.IsBound {
+		return nil
+	}
+
+	if len(operation.Parameters) == 0 {
+		return errors.New("the binding parameter of the bound operation is required")
+	}
+
+	bindingType, isCollection := unwrapCollectionType(operation.Parameters[0].Type)
+	bindingType = c.qualify(bindingType)
+
+	entitySets := c.entitySets[bindingType]
+	if len(entitySets) == 0 {
+		c.logger.Debug(
+			"skipped the bound operation which isn't bound to any entity set",
+			slog.String("operation", operation.Name),
+			slog.String("binding_type", operation.Parameters[0].Type),
+		)
+
+		return nil
+	}
+
+	operations := c.schema.Procedures
+	if isFunction {
+		operations = c.schema.Functions
+	}
+
+	for _, entitySet := range entitySets {
+		name := c.formatOperationName(entitySet, operation.Name)
+		requestPath := "/" + entitySet
+		arguments := map[string]rest.ArgumentInfo{}
+
+		if !isCollection {
+			keyPredicate, err := c.convertKeyPredicate(bindingType, arguments)
+			if err != nil {
+				c.warnUnsupportedOperation(name, err)
+
+				continue
+			}
+
+			requestPath += keyPredicate
+		}
+
+		requestPath += "/" + namespace + "." + operation.Name
+
+		result, err := c.convertOperation(name, requestPath, operation, operation.Parameters[1:], arguments, isFunction)
+		if errors.Is(err, errUnsupportedOperation) {
+			c.warnUnsupportedOperation(name, err)
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if _, ok := operations[name]; ok {
+			c.logger.Warn(
+				"skipped the bound operation which has a duplicated name",
+				slog.String("operation", operation.Name),
+				slog.String("name", name),
+			)
+
+			continue
+		}
+
+		operations[name] = *result
+	}
+
+	return nil
+}
+
+// convertOperation converts the action or function to the NDC operation.
+// Functions are invoked by GET requests with parameters in the path, e.g. /GetNearestAirport(lat=1,lon=2).
+// Actions are invoked by POST requests with parameters in the JSON body.
+func (c *converter) convertOperation(
+	name string,
+	requestPath string,
+	operation *Operation,
+	parameters []Parameter,
+	arguments map[string]rest.ArgumentInfo,
+	isFunction bool,
+) (*rest.OperationInfo, error) {
+	method := "post"
+
+	if isFunction {
+		method = "get"
+		segments := make([]string, len(parameters))
+
+		for i, param := range parameters {
+			// parameters in the path can't be omitted.
+			literal, err := c.convertPathArgument(param.Name, param.Type, getDescription(param.Annotations), arguments)
+			if err != nil {
+				return nil, err
+			}
+
+			segments[i] = param.Name + "=" + literal
+		}
+
+		requestPath += "(" + strings.Join(segments, ",") + ")"
+	} else if len(parameters) > 0 {
+		if err := c.convertActionBody(name, requestPath, parameters, arguments); err != nil {
+			return nil, err
+		}
+	}
+
+	var resultType schema.TypeEncoder
+
+	value := false
+
+	if operation.ReturnType == nil {
+		if isFunction {
+			return nil, errors.New("the return type of the function is required")
+		}
+
+		// actions without the return type respond no content.
+		resultType = schema.NewNullableType(schema.NewNamedType(c.addScalar(rest.ScalarJSON)))
+	} else {
+		var err error
+
+		resultType, err = c.convertTypeRef(operation.ReturnType.Type, operation.ReturnType.IsNullable())
+		if err != nil {
+			return nil, fmt.Errorf("return type: %w", err)
+		}
+
+		elementType, isCollection := unwrapCollectionType(operation.ReturnType.Type)
+		isEntity := c.entityTypes[c.qualify(elementType)]
+
+		// collections and primitive values are wrapped in the value property of the response.
+		// Entities and complex values are the response itself.
+		value = isCollection || !c.isStructuredType(elementType)
+
+		if isFunction && isEntity {
+			if isCollection {
+				c.addQueryOptions(arguments, collectionQueryOptions)
+			} else {
+				c.addQueryOptions(arguments, entityQueryOptions)
+			}
+		}
+	}
+
+	return &rest.OperationInfo{
+		Request:     c.newRequest(requestPath, method, value),
+		Arguments:   arguments,
+		Description: toDescription(getDescription(operation.Annotations)),
+		ResultType:  resultType.Encode(),
+	}, nil
+}
+
+// convertActionBody converts parameters of the action to the object type of the JSON body.
+func (c *converter) convertActionBody(
+	name string,
+	requestPath string,
+	parameters []Parameter,
+	arguments map[string]rest.ArgumentInfo,
+) error {
+	typeName := utils.StringSliceToPascalCase([]string{name, "Body"})
+	objectType := rest.ObjectType{
+		Fields: map[string]rest.ObjectField{},
+	}
+
+	for _, param := range parameters {
+		paramType, err := c.convertTypeRef(param.Type, param.IsNullable())
+		if err != nil {
+			return fmt.Errorf("%s: %w", param.Name, err)
+		}
+
+		objectType.Fields[param.Name] = rest.ObjectField{
+			ObjectField: schema.ObjectField{
+				Description: toDescription(getDescription(param.Annotations)),
+				Type:        paramType.Encode(),
+			},
+		}
+	}
+
+	c.schema.ObjectTypes[typeName] = objectType
+
+	description := "Request body of POST " + requestPath
+	arguments[rest.BodyKey] = rest.ArgumentInfo{
+		ArgumentInfo: schema.ArgumentInfo{
+			Description: &description,
+			Type:        schema.NewNamedType(typeName).Encode(),
+		},
+		HTTP: &rest.RequestParameter{
+			In: rest.InBody,
+			Schema: &rest.TypeSchema{
+				Type: []string{"object"},
+			},
+		},
+	}
+
+	return nil
+}
+
+// convertKeyPredicate converts key properties of the entity type to path arguments.
+// The key predicate of a single key is the value, e.g. (1) or ('ALFKI').
+// Composite keys are key-value pairs, e.g. (OrderID=1,ProductID=2).
+func (c *converter) convertKeyPredicate(entityType string, arguments map[string]rest.ArgumentInfo) (string, error) {
+	properties, err := c.getKeyProperties(entityType)
+	if err != nil {
+		return "", err
+	}
+
+	segments := make([]string, len(properties))
+
+	for i, property := range properties {
+		literal, err := c.convertPathArgument(property.Name, property.Type, getDescription(property.Annotations), arguments)
+		if err != nil {
+			return "", err
+		}
+
+		if len(properties) == 1 {
+			return "(" + literal + ")", nil
+		}
+
+		segments[i] = property.Name + "=" + literal
+	}
+
+	return "(" + strings.Join(segments, ",") + ")", nil
+}
+
+// convertPathArgument adds the path argument and returns the placeholder of the literal in the request path.
+func (c *converter) convertPathArgument(
+	name string,
+	typeRef string,
+	description string,
+	arguments map[string]rest.ArgumentInfo,
+) (string, error) {
+	literal, err := c.formatLiteral(name, typeRef)
+	if err != nil {
+		return "", err
+	}
+
+	typeName, err := c.convertNamedType(typeRef)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+
+	arguments[name] = rest.ArgumentInfo{
+		ArgumentInfo: schema.ArgumentInfo{
+			Description: toDescription(description),
+			Type:        schema.NewNamedType(typeName).Encode(),
+		},
+		HTTP: &rest.RequestParameter{
+			In:     rest.InPath,
+			Schema: c.getTypeSchema(typeName),
+		},
+	}
+
+	return literal, nil
+}
+
+// formatLiteral returns the placeholder of the parameter in the URL literal form of the type.
+func (c *converter) formatLiteral(name string, typeRef string) (string, error) {
+	if _, isCollection := unwrapCollectionType(typeRef); isCollection {
+		return "", fmt.Errorf("%w: the collection parameter %s", errUnsupportedOperation, name)
+	}
+
+	placeholder := "{" + name + "}"
+	qualifier, simpleName := splitQualifiedName(typeRef)
+
+	if qualifier == edmNamespace {
+		if _, ok := edmScalars[simpleName]; !ok {
+			return "", fmt.Errorf("%w: the parameter %s of type %s", errUnsupportedOperation, name, typeRef)
+		}
+
+		if prefix, ok := quotedLiteralPrefixes[simpleName]; ok {
+			return prefix + "'" + placeholder + "'", nil
+		}
+
+		return placeholder, nil
+	}
+
+	qualifiedName := c.qualify(typeRef)
+
+	if typeDefinition, ok := c.typeDefinitions[qualifiedName]; ok {
+		return c.formatLiteral(name, typeDefinition.UnderlyingType)
+	}
+
+	if _, ok := c.enumTypes[qualifiedName]; ok {
+		return qualifiedName + "'" + placeholder + "'", nil
+	}
+
+	return "", fmt.Errorf("%w: the parameter %s of type %s", errUnsupportedOperation, name, typeRef)
+}
+
+// convertTypeRef converts the type reference to NDC type.
+// The nullable facet of collections applies to elements.
+func (c *converter) convertTypeRef(typeRef string, nullable bool) (schema.TypeEncoder, error) {
+	elementType, isCollection := unwrapCollectionType(typeRef)
+
+	typeName, err := c.convertNamedType(elementType)
+	if err != nil {
+		return nil, err
+	}
+
+	var result schema.TypeEncoder = schema.NewNamedType(typeName)
+	if nullable {
+		result = schema.NewNullableType(result)
+	}
+
+	if isCollection {
+		result = schema.NewArrayType(result)
+	}
+
+	return result, nil
+}
+
+func (c *converter) convertNamedType(typeRef string) (string, error) {
+	qualifier, simpleName := splitQualifiedName(typeRef)
+	if qualifier == edmNamespace {
+		scalarName, ok := edmScalars[simpleName]
+		if !ok {
+			// geography, geometry, stream and abstract types are passed through as arbitrary JSON.
+			scalarName = rest.ScalarJSON
+		}
+
+		return c.addScalar(scalarName), nil
+	}
+
+	qualifiedName := c.qualify(typeRef)
+	if typeName, ok := c.typeNames[qualifiedName]; ok {
+		return typeName, nil
+	}
+
+	if typeDefinition, ok := c.typeDefinitions[qualifiedName]; ok {
+		typeName, err := c.convertNamedType(typeDefinition.UnderlyingType)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", typeRef, err)
+		}
+
+		c.typeNames[qualifiedName] = typeName
+
+		return typeName, nil
+	}
+
+	typeName := c.formatTypeName(simpleName)
+
+	if enumType, ok := c.enumTypes[qualifiedName]; ok {
+		scalar := schema.NewScalarType()
+
+		if enumType.IsFlags {
+			// values of flags are comma-separated members, e.g. Red,Blue.
+			scalar.Representation = schema.NewTypeRepresentationString().Encode()
+		} else {
+			values := make([]string, len(enumType.Members))
+			for i, member := range enumType.Members {
+				values[i] = member.Name
+			}
+
+			scalar.Representation = schema.NewTypeRepresentationEnum(values).Encode()
+		}
+
+		c.schema.AddScalar(typeName, *scalar)
+		c.typeNames[qualifiedName] = typeName
+
+		return typeName, nil
+	}
+
+	structuredType, ok := c.structuredTypes[qualifiedName]
+	if !ok {
+		return "", fmt.Errorf("the type %s does not exist", typeRef)
+	}
+
+	// register the name before converting properties to support recursive types.
+	c.typeNames[qualifiedName] = typeName
+
+	objectType, err := c.convertStructuredType(structuredType)
+	if err != nil {
+		delete(c.typeNames, qualifiedName)
+
+		return "", fmt.Errorf("%s: %w", typeRef, err)
+	}
+
+	if typeName != simpleName {
+		objectType.Alias = simpleName
+	}
+
+	c.schema.ObjectTypes[typeName] = *objectType
+
+	return typeName, nil
+}
+
+// convertStructuredType converts the entity or complex type to the object type.
+// Properties of base types are inherited. Navigation properties are nullable
+// because related entities are returned only if they are expanded.
+func (c *converter) convertStructuredType(structuredType *StructuredType) (*rest.ObjectType, error) {
+	objectType := &rest.ObjectType{
+		Description: toDescription(getDescription(structuredType.Annotations)),
+		Fields:      map[string]rest.ObjectField{},
+	}
+
+	err := c.walkStructuredTypes(structuredType, func(current *StructuredType) error {
+		for _, property := range current.Properties {
+			propertyType, err := c.convertTypeRef(property.Type, property.IsNullable())
+			if err != nil {
+				return fmt.Errorf("%s: %w", property.Name, err)
+			}
+
+			objectType.Fields[property.Name] = rest.ObjectField{
+				ObjectField: schema.ObjectField{
+					Description: toDescription(getDescription(property.Annotations)),
+					Type:        propertyType.Encode(),
+				},
+			}
+		}
+
+		for _, navigation := range current.NavigationProperties {
+			navigationType, err := c.convertTypeRef(navigation.Type, false)
+			if err != nil {
+				return fmt.Errorf("%s: %w", navigation.Name, err)
+			}
+
+			objectType.Fields[navigation.Name] = rest.ObjectField{
+				ObjectField: schema.ObjectField{
+					Description: toDescription(getDescription(navigation.Annotations)),
+					Type:        schema.NewNullableType(navigationType).Encode(),
+				},
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objectType, nil
+}
+
+// walkStructuredTypes calls the callback with the structured type and its base types.
+func (c *converter) walkStructuredTypes(structuredType *StructuredType, callback func(*StructuredType) error) error {
+	visited := map[*StructuredType]bool{}
+
+	for current := structuredType; current != nil && !visited[current]; {
+		visited[current] = true
+
+		if err := callback(current); err != nil {
+			return err
+		}
+
+		if current.BaseType == "" {
+			break
+		}
+
+		base, ok := c.structuredTypes[c.qualify(current.BaseType)]
+		if !ok {
+			return fmt.Errorf("the base type %s does not exist", current.BaseType)
+		}
+
+		current = base
+	}
+
+	return nil
+}
+
+// getKeyProperties returns key properties of the entity type which may be declared in base types.
+func (c *converter) getKeyProperties(entityType string) ([]Property, error) {
+	var keys []PropertyRef
+
+	properties := map[string]Property{}
+
+	err := c.walkStructuredTypes(c.structuredTypes[entityType], func(current *StructuredType) error {
+		if keys == nil && current.Key != nil {
+			keys = current.Key.PropertyRefs
+		}
+
+		for _, property := range current.Properties {
+			properties[property.Name] = property
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: the entity type %s doesn't have the key", errUnsupportedOperation, entityType)
+	}
+
+	results := make([]Property, len(keys))
+
+	for i, key := range keys {
+		property, ok := properties[key.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: the key property %s of %s does not exist", errUnsupportedOperation, key.Name, entityType)
+		}
+
+		results[i] = property
+	}
+
+	return results, nil
+}
+
+func (c *converter) addQueryOptions(arguments map[string]rest.ArgumentInfo, options []queryOption) {
+	for _, option := range options {
+		// parameters of the operation take precedence.
+		if _, ok := arguments[option.name]; ok {
+			continue
+		}
+
+		var argType schema.TypeEncoder = schema.NewNamedType(c.addScalar(option.scalar))
+
+		typeSchema := c.getTypeSchema(string(option.scalar))
+		param := &rest.RequestParameter{
+			Name: "$" + option.name,
+			In:   rest.InQuery,
+		}
+
+		if option.array {
+			argType = schema.NewArrayType(argType)
+			typeSchema = &rest.TypeSchema{
+				Type:  []string{"array"},
+				Items: typeSchema,
+			}
+			// items are comma-separated, e.g. $select=Name,Price
+			param.Explode = sdkUtils.ToPtr(false)
+		}
+
+		param.Schema = typeSchema
+		description := option.description
+
+		arguments[option.name] = rest.ArgumentInfo{
+			ArgumentInfo: schema.ArgumentInfo{
+				Description: &description,
+				Type:        schema.NewNullableType(argType).Encode(),
+			},
+			HTTP: param,
+		}
+	}
+}
+
+func (c *converter) newRequest(requestPath string, method string, value bool) *rest.Request {
+	request := &rest.Request{
+		URL:    requestPath,
+		Method: method,
+		Response: rest.Response{
+			ContentType: rest.ContentTypeJSON,
+		},
+		OData: &rest.ODataRequest{
+			Value: value,
+		},
+	}
+
+	if method != "get" {
+		request.RequestBody = &rest.RequestBody{
+			ContentType: rest.ContentTypeJSON,
+		}
+	}
+
+	return request
+}
+
+func (c *converter) addScalar(scalarName rest.ScalarName) string {
+	scalar := schema.NewScalarType()
+	scalar.Representation = scalarRepresentations[scalarName]
+	c.schema.AddScalar(string(scalarName), *scalar)
+
+	return string(scalarName)
+}
+
+// getTypeSchema returns the HTTP schema of the scalar type which is used to encode URL parameters.
+func (c *converter) getTypeSchema(typeName string) *rest.TypeSchema {
+	result := &rest.TypeSchema{}
+
+	switch rest.ScalarName(typeName) {
+	case rest.ScalarBoolean:
+		result.Type = []string{"boolean"}
+	case rest.ScalarInt32, rest.ScalarInt64:
+		result.Type = []string{"integer"}
+	case rest.ScalarFloat32, rest.ScalarFloat64:
+		result.Type = []string{"number"}
+	default:
+		result.Type = []string{"string"}
+	}
+
+	return result
+}
+
+// isStructuredType checks if the type is an entity type or a complex type.
+func (c *converter) isStructuredType(typeRef string) bool {
+	_, ok := c.structuredTypes[c.qualify(typeRef)]
+
+	return ok
+}
+
+// qualify replaces the alias of the qualified name with the namespace.
+func (c *converter) qualify(name string) string {
+	qualifier, simpleName := splitQualifiedName(name)
+	if namespace, ok := c.namespaces[qualifier]; ok {
+		return namespace + "." + simpleName
+	}
+
+	return name
+}
+
+func (c *converter) warnUnsupportedOperation(name string, err error) {
+	c.logger.Warn(
+		"skipped the unsupported operation",
+		slog.String("operation", name),
+		slog.String("reason", err.Error()),
+	)
+}
+
+func (c *converter) formatOperationName(names ...string) string {
+	if c.options.Prefix == "" {
+		return utils.StringSliceToCamelCase(names)
+	}
+
+	return utils.StringSliceToCamelCase(append([]string{c.options.Prefix}, names...))
+}
+
+func (c *converter) formatTypeName(name string) string {
+	if c.options.Prefix == "" {
+		return name
+	}
+
+	return utils.ToPascalCase(c.options.Prefix) + name
+}
+
+// findUnboundOperation returns the first unbound overload of the action or function.
+func findUnboundOperation(operations []*Operation) *Operation {
+	for _, operation := range operations {
+		if !operation.IsBound {
+			return operation
+		}
+	}
+
+	return nil
+}
+
+func toDescription(description string) *string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil
+	}
+
+	return &description
+}
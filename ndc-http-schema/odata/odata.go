@@ -0,0 +1,32 @@
+package odata
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// ODataToNDCSchema converts an OData v4 CSDL XML document, e.g. the response of $metadata, to NDC HTTP schema.
+// Entity sets, singletons and functions are converted to functions with system query options.
+// Actions are converted to procedures.
+func ODataToNDCSchema(input []byte, options openapi.ConvertOptions) (*rest.NDCHttpSchema, []error) {
+	var document Edmx
+
+	if err := xml.Unmarshal(bytes.TrimPrefix(input, []byte("\xef\xbb\xbf")), &document); err != nil {
+		return nil, []error{err}
+	}
+
+	if document.DataServices == nil || len(document.DataServices.Schemas) == 0 {
+		return nil, []error{errors.New("the CSDL document has no schema")}
+	}
+
+	result, err := newConverter(&document, options).Build()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return result, nil
+}
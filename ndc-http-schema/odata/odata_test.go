@@ -0,0 +1,91 @@
+package odata
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/internal/testutil"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	"gotest.tools/v3/assert"
+)
+
+func TestODataToNDCSchema(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Source   string
+		Expected string
+		Schema   string
+		Options  openapi.ConvertOptions
+	}{
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/odata/testdata/erp/source.xml -o ./ndc-http-schema/odata/testdata/erp/expected.json --spec odata --env-prefix ERP
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/odata/testdata/erp/source.xml -o ./ndc-http-schema/odata/testdata/erp/schema.json --pure --spec odata --env-prefix ERP
+		{
+			Name:     "erp",
+			Source:   "testdata/erp/source.xml",
+			Expected: "testdata/erp/expected.json",
+			Schema:   "testdata/erp/schema.json",
+			Options: openapi.ConvertOptions{
+				EnvPrefix: "ERP",
+			},
+		},
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/odata/testdata/trippin/source.xml -o ./ndc-http-schema/odata/testdata/trippin/expected.json --spec odata --prefix trippin
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/odata/testdata/trippin/source.xml -o ./ndc-http-schema/odata/testdata/trippin/schema.json --pure --spec odata --prefix trippin
+		{
+			Name:     "trippin",
+			Source:   "testdata/trippin/source.xml",
+			Expected: "testdata/trippin/expected.json",
+			Schema:   "testdata/trippin/schema.json",
+			Options: openapi.ConvertOptions{
+				Prefix: "trippin",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sourceBytes, err := os.ReadFile(tc.Source)
+			assert.NilError(t, err)
+
+			output, errs := ODataToNDCSchema(sourceBytes, tc.Options)
+			if output == nil {
+				t.Fatal(errors.Join(errs...))
+			}
+
+			testutil.AssertJSONFileEqual(t, tc.Expected, output)
+			testutil.AssertJSONFileEqual(t, tc.Schema, output.ToSchemaResponse())
+		})
+	}
+
+	t.Run("failure_invalid_xml", func(t *testing.T) {
+		_, errs := ODataToNDCSchema([]byte(`{"$Version": "4.01"}`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "EOF")
+	})
+
+	t.Run("failure_no_schema", func(t *testing.T) {
+		_, errs := ODataToNDCSchema([]byte(`<edmx:Edmx Version="4.0" xmlns:edmx="http://docs.oasis-open.org/odata/ns/edmx" />`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "the CSDL document has no schema")
+	})
+
+	t.Run("failure_empty", func(t *testing.T) {
+		_, errs := ODataToNDCSchema([]byte(`<edmx:Edmx Version="4.0" xmlns:edmx="http://docs.oasis-open.org/odata/ns/edmx">
+			<edmx:DataServices>
+				<Schema Namespace="Empty" xmlns="http://docs.oasis-open.org/odata/ns/edm" />
+			</edmx:DataServices>
+		</edmx:Edmx>`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "there is no API to be converted")
+	})
+
+	t.Run("failure_unknown_type", func(t *testing.T) {
+		_, errs := ODataToNDCSchema([]byte(`<edmx:Edmx Version="4.0" xmlns:edmx="http://docs.oasis-open.org/odata/ns/edmx">
+			<edmx:DataServices>
+				<Schema Namespace="Demo" xmlns="http://docs.oasis-open.org/odata/ns/edm">
+					<EntityContainer Name="Container">
+						<EntitySet Name="Products" EntityType="Demo.Product" />
+					</EntityContainer>
+				</Schema>
+			</edmx:DataServices>
+		</edmx:Edmx>`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "Container: Products: the type Demo.Product does not exist")
+	})
+}
@@ -0,0 +1,220 @@
+package odata
+
+import (
+	"strings"
+)
+
+const (
+	edmNamespace       = "Edm"
+	coreVocabulary     = "Org.OData.Core.V1"
+	coreVocabularyName = "Core"
+	collectionPrefix   = "Collection("
+)
+
+// Edmx represents the root element of an OData v4 CSDL XML document, e.g. the response of $metadata.
+type Edmx struct {
+	Version      string        `xml:"Version,attr"`
+	DataServices *DataServices `xml:"DataServices"`
+}
+
+// DataServices contains schemas of the service.
+type DataServices struct {
+	Schemas []Schema `xml:"Schema"`
+}
+
+// Schema represents a namespace of model elements.
+type Schema struct {
+	Namespace       string           `xml:"Namespace,attr"`
+	Alias           string           `xml:"Alias,attr"`
+	EntityTypes     []StructuredType `xml:"EntityType"`
+	ComplexTypes    []StructuredType `xml:"ComplexType"`
+	EnumTypes       []EnumType       `xml:"EnumType"`
+	TypeDefinitions []TypeDefinition `xml:"TypeDefinition"`
+	Actions         []Operation      `xml:"Action"`
+	Functions       []Operation      `xml:"Function"`
+	EntityContainer *EntityContainer `xml:"EntityContainer"`
+}
+
+// StructuredType represents an entity type or a complex type.
+type StructuredType struct {
+	Name                 string               `xml:"Name,attr"`
+	BaseType             string               `xml:"BaseType,attr"`
+	Abstract             bool                 `xml:"Abstract,attr"`
+	Key                  *Key                 `xml:"Key"`
+	Properties           []Property           `xml:"Property"`
+	NavigationProperties []NavigationProperty `xml:"NavigationProperty"`
+	Annotations          []Annotation         `xml:"Annotation"`
+}
+
+// Key represents the key properties of an entity type.
+type Key struct {
+	PropertyRefs []PropertyRef `xml:"PropertyRef"`
+}
+
+// PropertyRef refers to a key property.
+type PropertyRef struct {
+	Name string `xml:"Name,attr"`
+}
+
+// Property represents a structural property.
+type Property struct {
+	Name        string       `xml:"Name,attr"`
+	Type        string       `xml:"Type,attr"`
+	Nullable    string       `xml:"Nullable,attr"`
+	Annotations []Annotation `xml:"Annotation"`
+}
+
+// IsNullable checks if the property can be null. Properties are nullable by default.
+func (p Property) IsNullable() bool {
+	return p.Nullable != "false"
+}
+
+// NavigationProperty represents a relationship to related entities.
+type NavigationProperty struct {
+	Name           string       `xml:"Name,attr"`
+	Type           string       `xml:"Type,attr"`
+	Nullable       string       `xml:"Nullable,attr"`
+	Partner        string       `xml:"Partner,attr"`
+	ContainsTarget bool         `xml:"ContainsTarget,attr"`
+	Annotations    []Annotation `xml:"Annotation"`
+}
+
+// EnumType represents an enumeration type.
+type EnumType struct {
+	Name           string       `xml:"Name,attr"`
+	UnderlyingType string       `xml:"UnderlyingType,attr"`
+	IsFlags        bool         `xml:"IsFlags,attr"`
+	Members        []EnumMember `xml:"Member"`
+	Annotations    []Annotation `xml:"Annotation"`
+}
+
+// EnumMember represents a member of the enumeration type.
+type EnumMember struct {
+	Name  string `xml:"Name,attr"`
+	Value string `xml:"Value,attr"`
+}
+
+// TypeDefinition represents a named primitive type.
+type TypeDefinition struct {
+	Name           string       `xml:"Name,attr"`
+	UnderlyingType string       `xml:"UnderlyingType,attr"`
+	Annotations    []Annotation `xml:"Annotation"`
+}
+
+// Operation represents an action or a function.
+type Operation struct {
+	Name          string       `xml:"Name,attr"`
+	IsBound       bool         `xml:"IsBound,attr"`
+	IsComposable  bool         `xml:"IsComposable,attr"`
+	EntitySetPath string       `xml:"EntitySetPath,attr"`
+	Parameters    []Parameter  `xml:"Parameter"`
+	ReturnType    *ReturnType  `xml:"ReturnType"`
+	Annotations   []Annotation `xml:"Annotation"`
+}
+
+// Parameter represents a parameter of the operation.
+// The first parameter of the bound operation is the binding parameter.
+type Parameter struct {
+	Name        string       `xml:"Name,attr"`
+	Type        string       `xml:"Type,attr"`
+	Nullable    string       `xml:"Nullable,attr"`
+	Annotations []Annotation `xml:"Annotation"`
+}
+
+// IsNullable checks if the parameter can be null. Parameters are nullable by default.
+func (p Parameter) IsNullable() bool {
+	return p.Nullable != "false"
+}
+
+// ReturnType represents the return type of the operation.
+type ReturnType struct {
+	Type     string `xml:"Type,attr"`
+	Nullable string `xml:"Nullable,attr"`
+}
+
+// IsNullable checks if the result can be null. Results are nullable by default.
+func (rt ReturnType) IsNullable() bool {
+	return rt.Nullable != "false"
+}
+
+// EntityContainer represents the resources which are exposed by the service.
+type EntityContainer struct {
+	Name            string           `xml:"Name,attr"`
+	EntitySets      []EntitySet      `xml:"EntitySet"`
+	Singletons      []Singleton      `xml:"Singleton"`
+	ActionImports   []ActionImport   `xml:"ActionImport"`
+	FunctionImports []FunctionImport `xml:"FunctionImport"`
+}
+
+// EntitySet represents a collection of entities.
+type EntitySet struct {
+	Name        string       `xml:"Name,attr"`
+	EntityType  string       `xml:"EntityType,attr"`
+	Annotations []Annotation `xml:"Annotation"`
+}
+
+// Singleton represents a single entity.
+type Singleton struct {
+	Name        string       `xml:"Name,attr"`
+	Type        string       `xml:"Type,attr"`
+	Annotations []Annotation `xml:"Annotation"`
+}
+
+// ActionImport exposes an unbound action.
+type ActionImport struct {
+	Name        string       `xml:"Name,attr"`
+	Action      string       `xml:"Action,attr"`
+	Annotations []Annotation `xml:"Annotation"`
+}
+
+// FunctionImport exposes an unbound function.
+type FunctionImport struct {
+	Name        string       `xml:"Name,attr"`
+	Function    string       `xml:"Function,attr"`
+	Annotations []Annotation `xml:"Annotation"`
+}
+
+// Annotation represents a vocabulary term applied to a model element.
+type Annotation struct {
+	Term        string `xml:"Term,attr"`
+	StringAttr  string `xml:"String,attr"`
+	StringValue string `xml:"String"`
+}
+
+// getDescription returns the value of the Core.Description annotation.
+func getDescription(annotations []Annotation) string {
+	for _, annotation := range annotations {
+		qualifier, name := splitQualifiedName(annotation.Term)
+		if name != "Description" || (qualifier != coreVocabulary && qualifier != coreVocabularyName) {
+			continue
+		}
+
+		if annotation.StringAttr != "" {
+			return strings.TrimSpace(annotation.StringAttr)
+		}
+
+		return strings.TrimSpace(annotation.StringValue)
+	}
+
+	return ""
+}
+
+// splitQualifiedName splits the qualified name to the namespace and the simple name,
+// e.g. Microsoft.OData.SampleService.Models.TripPin.Person.
+func splitQualifiedName(name string) (string, string) {
+	index := strings.LastIndex(name, ".")
+	if index < 0 {
+		return "", name
+	}
+
+	return name[:index], name[index+1:]
+}
+
+// unwrapCollectionType returns the element type if the type is a collection.
+func unwrapCollectionType(typeRef string) (string, bool) {
+	if !strings.HasPrefix(typeRef, collectionPrefix) || !strings.HasSuffix(typeRef, ")") {
+		return typeRef, false
+	}
+
+	return typeRef[len(collectionPrefix) : len(typeRef)-1], true
+}
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// OperationFilter represents conditions to select operations of the API document.
+// A condition matches if any of its values matches. Included operations must match all non-empty conditions,
+// and operations which match any condition are excluded.
+type OperationFilter struct {
+	// OpenAPI tags of operations.
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Glob patterns of API paths. The * wildcard matches any characters except the slash
+	// and the ** wildcard matches any characters, e.g. /v1/customers/** matches /v1/customers and its sub-paths.
+	Paths []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	// HTTP methods of operations, e.g. get, post.
+	Methods []string `json:"methods,omitempty" yaml:"methods,omitempty"`
+	// Regular expressions of operation IDs.
+	OperationIDs []string `json:"operationIds,omitempty" yaml:"operationIds,omitempty"`
+}
+
+// IsZero checks if the filter doesn't have any condition.
+func (of *OperationFilter) IsZero() bool {
+	return of == nil ||
+		(len(of.Tags) == 0 && len(of.Paths) == 0 && len(of.Methods) == 0 && len(of.OperationIDs) == 0)
+}
+
+type operationMatcher struct {
+	tags         []string
+	paths        []*regexp.Regexp
+	methods      []string
+	operationIDs []*regexp.Regexp
+}
+
+func newOperationMatcher(filter *OperationFilter) (*operationMatcher, error) {
+	if filter.IsZero() {
+		return nil, nil
+	}
+
+	matcher := &operationMatcher{
+		tags: filter.Tags,
+	}
+
+	for _, method := range filter.Methods {
+		matcher.methods = append(matcher.methods, strings.ToLower(method))
+	}
+
+	for _, pattern := range filter.Paths {
+		re, err := globToRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path pattern %s: %w", pattern, err)
+		}
+
+		matcher.paths = append(matcher.paths, re)
+	}
+
+	for _, pattern := range filter.OperationIDs {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation ID pattern %s: %w", pattern, err)
+		}
+
+		matcher.operationIDs = append(matcher.operationIDs, re)
+	}
+
+	return matcher, nil
+}
+
+// MatchAll checks if the operation matches all non-empty conditions.
+func (om *operationMatcher) MatchAll(apiPath string, method string, tags []string, operationID string) bool {
+	return (len(om.methods) == 0 || om.matchMethod(method)) &&
+		(len(om.tags) == 0 || om.matchTags(tags)) &&
+		(len(om.paths) == 0 || om.matchPath(apiPath)) &&
+		(len(om.operationIDs) == 0 || om.matchOperationID(operationID))
+}
+
+// MatchAny checks if the operation matches any condition.
+func (om *operationMatcher) MatchAny(apiPath string, method string, tags []string, operationID string) bool {
+	return om.matchMethod(method) || om.matchTags(tags) || om.matchPath(apiPath) ||
+		om.matchOperationID(operationID)
+}
+
+func (om *operationMatcher) matchMethod(method string) bool {
+	return slices.Contains(om.methods, method)
+}
+
+func (om *operationMatcher) matchTags(tags []string) bool {
+	return slices.ContainsFunc(tags, func(tag string) bool {
+		return slices.Contains(om.tags, tag)
+	})
+}
+
+func (om *operationMatcher) matchPath(apiPath string) bool {
+	return slices.ContainsFunc(om.paths, func(re *regexp.Regexp) bool {
+		return re.MatchString(apiPath)
+	})
+}
+
+func (om *operationMatcher) matchOperationID(operationID string) bool {
+	return operationID != "" && slices.ContainsFunc(om.operationIDs, func(re *regexp.Regexp) bool {
+		return re.MatchString(operationID)
+	})
+}
+
+// operationFilterState evaluates include and exclude filters of operations and counts the result.
+type operationFilterState struct {
+	include  *operationMatcher
+	exclude  *operationMatcher
+	included int
+	excluded int
+}
+
+func newOperationFilterState(options *ConvertOptions) (*operationFilterState, error) {
+	include, err := newOperationMatcher(options.Include)
+	if err != nil {
+		return nil, fmt.Errorf("include: %w", err)
+	}
+
+	exclude, err := newOperationMatcher(options.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("exclude: %w", err)
+	}
+
+	return &operationFilterState{
+		include: include,
+		exclude: exclude,
+	}, nil
+}
+
+// IsAllowed checks if the operation is included and not excluded.
+func (ofs *operationFilterState) IsAllowed(apiPath string, method string, tags []string, operationID string) bool {
+	if ofs == nil || (ofs.include == nil && ofs.exclude == nil) {
+		return true
+	}
+
+	if (ofs.include != nil && !ofs.include.MatchAll(apiPath, method, tags, operationID)) ||
+		(ofs.exclude != nil && ofs.exclude.MatchAny(apiPath, method, tags, operationID)) {
+		ofs.excluded++
+
+		return false
+	}
+
+	ofs.included++
+
+	return true
+}
+
+// Log prints counts of filtered operations if filters are set.
+func (ofs *operationFilterState) Log(logger *slog.Logger) {
+	if ofs == nil || (ofs.include == nil && ofs.exclude == nil) {
+		return
+	}
+
+	logger.Info(
+		"filtered operations",
+		slog.Int("included", ofs.included),
+		slog.Int("excluded", ofs.excluded),
+	)
+}
+
+// globToRegexp converts the glob pattern of API paths to a regular expression.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var builder strings.Builder
+
+	builder.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch char := pattern[i]; char {
+		case '/':
+			// the /** segment also matches the parent path, e.g. /v1/customers/** matches /v1/customers.
+			if strings.HasPrefix(pattern[i:], "/**") && (i+3 == len(pattern) || pattern[i+3] == '/') {
+				builder.WriteString("(?:/.*)?")
+
+				i += 2
+			} else {
+				builder.WriteString("/")
+			}
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				builder.WriteString(".*")
+
+				i++
+			} else {
+				builder.WriteString("[^/]*")
+			}
+		case '?':
+			builder.WriteString("[^/]")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	builder.WriteString("$")
+
+	return regexp.Compile(builder.String())
+}
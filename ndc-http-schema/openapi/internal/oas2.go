@@ -72,12 +72,18 @@ func (oc *OAS2Builder) BuildDocumentModel(
 		}
 	}
 
+	if err := oc.initOperationFilter(); err != nil {
+		return nil, err
+	}
+
 	for iterPath := docModel.Model.Paths.PathItems.First(); iterPath != nil; iterPath = iterPath.Next() {
 		if err := oc.pathToNDCOperations(iterPath); err != nil {
 			return nil, err
 		}
 	}
 
+	oc.operationFilter.Log(oc.Logger)
+
 	if docModel.Model.SecurityDefinitions != nil &&
 		docModel.Model.SecurityDefinitions.Definitions != nil {
 		oc.schema.Settings.SecuritySchemes = make(map[string]rest.SecurityScheme)
@@ -165,10 +171,10 @@ func (oc *OAS2Builder) convertSecuritySchemes(
 		}
 
 		if flowType == rest.ClientCredentialsFlow {
-			clientID := goenvconf.NewEnvStringVariable(
+			clientID := rest.NewSecretStringVariable(
 				utils.StringSliceToConstantCase([]string{oc.EnvPrefix, key, "CLIENT_ID"}),
 			)
-			clientSecret := goenvconf.NewEnvStringVariable(
+			clientSecret := rest.NewSecretStringVariable(
 				utils.StringSliceToConstantCase([]string{oc.EnvPrefix, key, "CLIENT_SECRET"}),
 			)
 			flow.ClientID = &clientID
@@ -192,7 +198,7 @@ func (oc *OAS2Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v2.
 	pathValue := pathItem.Value()
 
 	funcGet, funcName, err := newOAS2OperationBuilder(oc, pathKey, "get").
-		BuildFunction(pathValue.Get, pathValue.Parameters)
+		BuildFunction(oc.filterOperation(pathKey, "get", pathValue.Get), pathValue.Parameters)
 	if err != nil {
 		return err
 	}
@@ -205,7 +211,7 @@ func (oc *OAS2Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v2.
 		oc,
 		pathKey,
 		"post",
-	).BuildProcedure(pathValue.Post, pathValue.Parameters)
+	).BuildProcedure(oc.filterOperation(pathKey, "post", pathValue.Post), pathValue.Parameters)
 	if err != nil {
 		return err
 	}
@@ -214,7 +220,7 @@ func (oc *OAS2Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v2.
 		oc,
 		pathKey,
 		"put",
-	).BuildProcedure(pathValue.Put, pathValue.Parameters)
+	).BuildProcedure(oc.filterOperation(pathKey, "put", pathValue.Put), pathValue.Parameters)
 	if err != nil {
 		return err
 	}
@@ -223,7 +229,7 @@ func (oc *OAS2Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v2.
 		oc,
 		pathKey,
 		"patch",
-	).BuildProcedure(pathValue.Patch, pathValue.Parameters)
+	).BuildProcedure(oc.filterOperation(pathKey, "patch", pathValue.Patch), pathValue.Parameters)
 	if err != nil {
 		return err
 	}
@@ -232,7 +238,7 @@ func (oc *OAS2Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v2.
 		oc,
 		pathKey,
 		"delete",
-	).BuildProcedure(pathValue.Delete, pathValue.Parameters)
+	).BuildProcedure(oc.filterOperation(pathKey, "delete", pathValue.Delete), pathValue.Parameters)
 	if err != nil {
 		return err
 	}
@@ -240,6 +246,15 @@ func (oc *OAS2Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v2.
 	return nil
 }
 
+// filterOperation returns nil if the operation is filtered out by include and exclude filters.
+func (oc *OAS2Builder) filterOperation(pathKey string, method string, operation *v2.Operation) *v2.Operation {
+	if operation == nil || !oc.operationFilter.IsAllowed(pathKey, method, operation.Tags, operation.OperationId) {
+		return nil
+	}
+
+	return operation
+}
+
 func (oc *OAS2Builder) convertComponentSchemas(
 	schemaItem orderedmap.Pair[string, *base.SchemaProxy],
 ) error {
@@ -63,12 +63,18 @@ func (oc *OAS3Builder) BuildDocumentModel(
 		}
 	}
 
+	if err := oc.initOperationFilter(); err != nil {
+		return nil, err
+	}
+
 	for iterPath := docModel.Model.Paths.PathItems.First(); iterPath != nil; iterPath = iterPath.Next() {
 		if err := oc.pathToNDCOperations(iterPath); err != nil {
 			return nil, err
 		}
 	}
 
+	oc.operationFilter.Log(oc.Logger)
+
 	if docModel.Model.Components.SecuritySchemes != nil {
 		oc.schema.Settings.SecuritySchemes = make(map[string]rest.SecurityScheme)
 		for scheme := docModel.Model.Components.SecuritySchemes.First(); scheme != nil; scheme = scheme.Next() {
@@ -214,10 +220,10 @@ func (oc *OAS3Builder) convertSecuritySchemes(
 		}
 
 		if security.Flows.ClientCredentials != nil {
-			clientID := goenvconf.NewEnvStringVariable(
+			clientID := rest.NewSecretStringVariable(
 				utils.StringSliceToConstantCase([]string{oc.EnvPrefix, key, "CLIENT_ID"}),
 			)
-			clientSecret := goenvconf.NewEnvStringVariable(
+			clientSecret := rest.NewSecretStringVariable(
 				utils.StringSliceToConstantCase([]string{oc.EnvPrefix, key, "CLIENT_SECRET"}),
 			)
 			flow := oc.convertV3OAuthFLow(key, security.Flows.ClientCredentials)
@@ -253,13 +259,13 @@ func (oc *OAS3Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v3.
 	pathKey := pathItem.Key()
 	pathValue := pathItem.Value()
 
-	if pathValue.Get != nil {
+	if itemGet := oc.filterOperation(pathKey, "get", pathValue.Get); itemGet != nil {
 		funcGet, funcName, err := newOAS3OperationBuilder(
 			oc,
 			pathKey,
 			"get",
 			pathValue.Parameters,
-		).BuildFunction(pathValue.Get)
+		).BuildFunction(itemGet)
 		if err != nil {
 			return err
 		}
@@ -274,7 +280,7 @@ func (oc *OAS3Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v3.
 		pathKey,
 		"post",
 		pathValue.Parameters,
-	).BuildProcedure(pathValue.Post)
+	).BuildProcedure(oc.filterOperation(pathKey, "post", pathValue.Post))
 	if err != nil {
 		return err
 	}
@@ -284,7 +290,7 @@ func (oc *OAS3Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v3.
 		pathKey,
 		"put",
 		pathValue.Parameters,
-	).BuildProcedure(pathValue.Put)
+	).BuildProcedure(oc.filterOperation(pathKey, "put", pathValue.Put))
 	if err != nil {
 		return err
 	}
@@ -294,7 +300,7 @@ func (oc *OAS3Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v3.
 		pathKey,
 		"patch",
 		pathValue.Parameters,
-	).BuildProcedure(pathValue.Patch)
+	).BuildProcedure(oc.filterOperation(pathKey, "patch", pathValue.Patch))
 	if err != nil {
 		return err
 	}
@@ -304,7 +310,7 @@ func (oc *OAS3Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v3.
 		pathKey,
 		"delete",
 		pathValue.Parameters,
-	).BuildProcedure(pathValue.Delete)
+	).BuildProcedure(oc.filterOperation(pathKey, "delete", pathValue.Delete))
 	if err != nil {
 		return err
 	}
@@ -312,6 +318,15 @@ func (oc *OAS3Builder) pathToNDCOperations(pathItem orderedmap.Pair[string, *v3.
 	return nil
 }
 
+// filterOperation returns nil if the operation is filtered out by include and exclude filters.
+func (oc *OAS3Builder) filterOperation(pathKey string, method string, operation *v3.Operation) *v3.Operation {
+	if operation == nil || !oc.operationFilter.IsAllowed(pathKey, method, operation.Tags, operation.OperationId) {
+		return nil
+	}
+
+	return operation
+}
+
 func (oc *OAS3Builder) convertComponentSchemas(
 	schemaItem orderedmap.Pair[string, *base.SchemaProxy],
 ) error {
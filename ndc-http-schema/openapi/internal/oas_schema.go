@@ -23,6 +23,8 @@ type OASBuilderState struct {
 	// or self-reference types that haven't added into the object_types map yet.
 	// This cache temporarily stores them to avoid infinite recursive references.
 	schemaCache map[string]SchemaInfoCache
+	// evaluates include and exclude filters of operations.
+	operationFilter *operationFilterState
 }
 
 // NewOASBuilderState creates an OASBuilderState instance.
@@ -40,6 +42,18 @@ func NewOASBuilderState(options ConvertOptions) *OASBuilderState {
 	return builder
 }
 
+// initOperationFilter compiles include and exclude filters of operations.
+func (oc *OASBuilderState) initOperationFilter() error {
+	operationFilter, err := newOperationFilterState(oc.ConvertOptions)
+	if err != nil {
+		return err
+	}
+
+	oc.operationFilter = operationFilter
+
+	return nil
+}
+
 type oasSchemaBuilder struct {
 	state    *OASBuilderState
 	apiPath  string
@@ -194,6 +194,8 @@ type ConvertOptions struct {
 	TrimPrefix          string
 	EnvPrefix           string
 	NoDeprecation       bool
+	Include             *OperationFilter
+	Exclude             *OperationFilter
 	Logger              *slog.Logger
 }
 
@@ -38,6 +38,23 @@ func TestOpenAPIv2ToRESTSchema(t *testing.T) {
 			Expected: "testdata/petstore2/expected.json",
 			Schema:   "testdata/petstore2/schema.json",
 		},
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openapi/testdata/petstore2/swagger.json -o ./ndc-http-schema/openapi/testdata/petstore2/expected_filter.json --spec oas2 --include-paths '/user/**,/store/*' --include-methods get,post --exclude-paths '/user/logout*'
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openapi/testdata/petstore2/swagger.json -o ./ndc-http-schema/openapi/testdata/petstore2/expected_filter.schema.json --pure --spec oas2 --include-paths '/user/**,/store/*' --include-methods get,post --exclude-paths '/user/logout*'
+		{
+			Name:     "petstore2_filter",
+			Source:   "testdata/petstore2/swagger.json",
+			Expected: "testdata/petstore2/expected_filter.json",
+			Schema:   "testdata/petstore2/expected_filter.schema.json",
+			Options: ConvertOptions{
+				Include: &OperationFilter{
+					Paths:   []string{"/user/**", "/store/*"},
+					Methods: []string{"get", "post"},
+				},
+				Exclude: &OperationFilter{
+					Paths: []string{"/user/logout*"},
+				},
+			},
+		},
 		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openapi/testdata/prefix2/source.json -o ./ndc-http-schema/openapi/testdata/prefix2/expected_single_word.json --spec oas2 --prefix hasura
 		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openapi/testdata/prefix2/source.json -o ./ndc-http-schema/openapi/testdata/prefix2/expected_single_word.schema.json --pure --spec oas2 --prefix hasura
 		{
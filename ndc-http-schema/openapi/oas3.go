@@ -11,6 +11,9 @@ import (
 
 type ConvertOptions internal.ConvertOptions
 
+// OperationFilter represents conditions to select operations by tags, path patterns, methods and operation IDs.
+type OperationFilter = internal.OperationFilter
+
 // OpenAPIv3ToNDCSchema converts OpenAPI v3 JSON bytes to NDC HTTP schema.
 func OpenAPIv3ToNDCSchema(input []byte, options ConvertOptions) (*rest.NDCHttpSchema, []error) {
 	input = []byte(utils.RemoveYAMLSpecialCharacters(input))
@@ -31,6 +31,25 @@ func TestOpenAPIv3ToRESTSchema(t *testing.T) {
 				EnvPrefix:  "PET_STORE",
 			},
 		},
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openapi/testdata/petstore3/source.json -o ./ndc-http-schema/openapi/testdata/petstore3/expected_filter.json --trim-prefix /v1 --spec openapi3 --env-prefix PET_STORE --include-tags pet,store --exclude-methods delete --exclude-operation-ids '^update'
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openapi/testdata/petstore3/source.json -o ./ndc-http-schema/openapi/testdata/petstore3/expected_filter.schema.json --pure --trim-prefix /v1 --spec openapi3 --env-prefix PET_STORE --include-tags pet,store --exclude-methods delete --exclude-operation-ids '^update'
+		{
+			Name:     "petstore3_filter",
+			Source:   "testdata/petstore3/source.json",
+			Expected: "testdata/petstore3/expected_filter.json",
+			Schema:   "testdata/petstore3/expected_filter.schema.json",
+			Options: ConvertOptions{
+				TrimPrefix: "/v1",
+				EnvPrefix:  "PET_STORE",
+				Include: &OperationFilter{
+					Tags: []string{"pet", "store"},
+				},
+				Exclude: &OperationFilter{
+					Methods:      []string{"delete"},
+					OperationIDs: []string{"^update"},
+				},
+			},
+		},
 		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openapi/testdata/onesignal/source.json -o ./ndc-http-schema/openapi/testdata/onesignal/expected.json --spec openapi3 --no-deprecation
 		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openapi/testdata/onesignal/source.json -o ./ndc-http-schema/openapi/testdata/onesignal/schema.json --pure --spec openapi3 --no-deprecation
 		{
@@ -129,6 +148,18 @@ func TestOpenAPIv3ToRESTSchema(t *testing.T) {
 		_, err := OpenAPIv3ToNDCSchema([]byte(""), ConvertOptions{})
 		assert.ErrorContains(t, errors.Join(err...), "there is nothing in the spec, it's empty")
 	})
+
+	t.Run("failure_invalid_filter", func(t *testing.T) {
+		sourceBytes, err := os.ReadFile("testdata/petstore3/source.json")
+		assert.NilError(t, err)
+
+		_, errs := OpenAPIv3ToNDCSchema(sourceBytes, ConvertOptions{
+			Exclude: &OperationFilter{
+				OperationIDs: []string{"("},
+			},
+		})
+		assert.ErrorContains(t, errors.Join(errs...), "exclude: invalid operation ID pattern (")
+	})
 }
 
 func assertRESTSchemaEqual(
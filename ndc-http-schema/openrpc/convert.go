@@ -0,0 +1,394 @@
+package openrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-http/ndc-http-schema/utils"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
+)
+
+const contentDescriptorRefPrefix = "#/components/contentDescriptors/"
+
+// known verbs of method names. The value is true if the method modifies data and is converted to a procedure.
+var methodVerbs = map[string]bool{
+	"call":        false,
+	"check":       false,
+	"count":       false,
+	"describe":    false,
+	"estimate":    false,
+	"fetch":       false,
+	"find":        false,
+	"get":         false,
+	"has":         false,
+	"is":          false,
+	"list":        false,
+	"lookup":      false,
+	"query":       false,
+	"read":        false,
+	"search":      false,
+	"add":         true,
+	"approve":     true,
+	"cancel":      true,
+	"create":      true,
+	"delete":      true,
+	"deploy":      true,
+	"execute":     true,
+	"insert":      true,
+	"new":         true,
+	"patch":       true,
+	"post":        true,
+	"put":         true,
+	"register":    true,
+	"remove":      true,
+	"send":        true,
+	"set":         true,
+	"sign":        true,
+	"submit":      true,
+	"subscribe":   true,
+	"transfer":    true,
+	"uninstall":   true,
+	"unregister":  true,
+	"unsubscribe": true,
+	"update":      true,
+	"upsert":      true,
+	"write":       true,
+}
+
+type converter struct {
+	document *Document
+	// methods indexed by the generated path of the OpenAPI operation
+	methods map[string]*Method
+}
+
+func newConverter(document *Document) *converter {
+	return &converter{
+		document: document,
+		methods:  make(map[string]*Method),
+	}
+}
+
+// BuildOpenAPIDocument converts methods to POST operations of an OpenAPI 3 document.
+// Params of each method are converted to properties of the request body object.
+func (c *converter) BuildOpenAPIDocument() (map[string]any, error) {
+	paths := map[string]any{}
+
+	for i := range c.document.Methods {
+		method := &c.document.Methods[i]
+		if method.Name == "" {
+			return nil, fmt.Errorf("methods[%d]: the method name is required", i)
+		}
+
+		operation, err := c.convertMethod(method)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", method.Name, err)
+		}
+
+		pathKey := "/" + url.PathEscape(method.Name)
+		paths[pathKey] = map[string]any{
+			"post": operation,
+		}
+		c.methods[pathKey] = method
+	}
+
+	if len(paths) == 0 {
+		return nil, errors.New("there is no API to be converted")
+	}
+
+	document := map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       c.document.Info.Title,
+			"description": c.document.Info.Description,
+			"version":     c.document.Info.Version,
+		},
+		"paths": paths,
+	}
+
+	servers := []any{}
+
+	for _, server := range c.document.Servers {
+		if server.URL == "" {
+			continue
+		}
+
+		rawServer := map[string]any{
+			"url": server.URL,
+		}
+
+		if len(server.Variables) > 0 {
+			rawServer["variables"] = server.Variables
+		}
+
+		servers = append(servers, rawServer)
+	}
+
+	if len(servers) > 0 {
+		document["servers"] = servers
+	}
+
+	// the OpenAPI converter requires the components object.
+	components := map[string]any{}
+	if c.document.Components != nil && len(c.document.Components.Schemas) > 0 {
+		components["schemas"] = c.document.Components.Schemas
+	}
+
+	document["components"] = components
+
+	return document, nil
+}
+
+func (c *converter) convertMethod(method *Method) (map[string]any, error) {
+	operation := map[string]any{
+		"operationId": method.Name,
+	}
+
+	if method.Summary != "" {
+		operation["summary"] = method.Summary
+	}
+
+	if method.Description != "" {
+		operation["description"] = method.Description
+	}
+
+	if method.Deprecated {
+		operation["deprecated"] = true
+	}
+
+	properties := map[string]any{}
+	required := []string{}
+
+	for i, param := range method.Params {
+		descriptor, err := c.resolveContentDescriptor(param)
+		if err != nil {
+			return nil, fmt.Errorf("params[%d]: %w", i, err)
+		}
+
+		method.Params[i] = *descriptor
+		properties[descriptor.Name] = getContentDescriptorSchema(descriptor)
+
+		if descriptor.Required {
+			required = append(required, descriptor.Name)
+		}
+	}
+
+	if len(properties) > 0 {
+		bodySchema := map[string]any{
+			"type":       "object",
+			"properties": properties,
+		}
+
+		if len(required) > 0 {
+			bodySchema["required"] = required
+		}
+
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				rest.ContentTypeJSON: map[string]any{
+					"schema": bodySchema,
+				},
+			},
+		}
+	}
+
+	// methods without result are notifications. The result is arbitrary JSON.
+	resultSchema := any(map[string]any{})
+
+	if method.Result != nil {
+		descriptor, err := c.resolveContentDescriptor(*method.Result)
+		if err != nil {
+			return nil, fmt.Errorf("result: %w", err)
+		}
+
+		method.Result = descriptor
+		resultSchema = getContentDescriptorSchema(descriptor)
+	}
+
+	operation["responses"] = map[string]any{
+		"200": map[string]any{
+			"description": "Successful response",
+			"content": map[string]any{
+				rest.ContentTypeJSON: map[string]any{
+					"schema": resultSchema,
+				},
+			},
+		},
+	}
+
+	return operation, nil
+}
+
+func (c *converter) resolveContentDescriptor(descriptor ContentDescriptor) (*ContentDescriptor, error) {
+	if descriptor.Ref == "" {
+		if descriptor.Name == "" {
+			return nil, errors.New("the name of the content descriptor is required")
+		}
+
+		return &descriptor, nil
+	}
+
+	name, ok := strings.CutPrefix(descriptor.Ref, contentDescriptorRefPrefix)
+	if !ok {
+		return nil, fmt.Errorf("unsupported content descriptor reference %s", descriptor.Ref)
+	}
+
+	if c.document.Components != nil {
+		if result, ok := c.document.Components.ContentDescriptors[name]; ok && result.Ref == "" {
+			return &result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("content descriptor %s does not exist", descriptor.Ref)
+}
+
+// TransformSchema turns operations of the converted OpenAPI document into JSON-RPC methods.
+// The request body argument is spread into arguments which are sent as params.
+func (c *converter) TransformSchema(httpSchema *rest.NDCHttpSchema) error {
+	procedures := httpSchema.Procedures
+	httpSchema.Procedures = map[string]rest.OperationInfo{}
+
+	for name, operation := range procedures {
+		if operation.Request == nil {
+			continue
+		}
+
+		method, ok := c.methods[operation.Request.URL]
+		if !ok {
+			return fmt.Errorf("%s: the JSON-RPC method of %s does not exist", name, operation.Request.URL)
+		}
+
+		paramStructure := rest.JSONRPCParamsByPosition
+		if method.ParamStructure == string(rest.JSONRPCParamsByName) {
+			paramStructure = rest.JSONRPCParamsByName
+		}
+
+		arguments, params, err := c.convertArguments(httpSchema, operation.Arguments, method, paramStructure)
+		if err != nil {
+			return fmt.Errorf("%s: %w", method.Name, err)
+		}
+
+		request := operation.Request.Clone()
+		// the server URL is the JSON-RPC endpoint.
+		request.URL = "/"
+		request.RequestBody = &rest.RequestBody{
+			ContentType: rest.ContentTypeJSON,
+		}
+		request.JSONRPC = &rest.JSONRPCRequest{
+			Method:         method.Name,
+			ParamStructure: paramStructure,
+			Params:         params,
+		}
+
+		operation.Request = request
+		operation.Arguments = arguments
+
+		if isProcedureMethod(method.Name) {
+			httpSchema.Procedures[name] = operation
+		} else {
+			httpSchema.Functions[name] = operation
+		}
+	}
+
+	return nil
+}
+
+// convertArguments converts params to arguments and returns them with argument names in order of params.
+// Names of params by position are only used as argument names, so they are normalized to camelCase.
+func (c *converter) convertArguments(
+	httpSchema *rest.NDCHttpSchema,
+	arguments map[string]rest.ArgumentInfo,
+	method *Method,
+	paramStructure rest.JSONRPCParamStructure,
+) (map[string]rest.ArgumentInfo, []string, error) {
+	results := map[string]rest.ArgumentInfo{}
+	params := []string{}
+
+	bodyArgument, ok := arguments[rest.BodyKey]
+	if !ok {
+		return results, params, nil
+	}
+
+	bodyType := schema.GetUnderlyingNamedType(bodyArgument.Type)
+	if bodyType == nil {
+		return nil, nil, errors.New("the type of params must be a named object type")
+	}
+
+	objectType, ok := httpSchema.ObjectTypes[bodyType.Name]
+	if !ok {
+		return nil, nil, fmt.Errorf("the object type %s of params does not exist", bodyType.Name)
+	}
+
+	// the params object is only used by this method.
+	delete(httpSchema.ObjectTypes, bodyType.Name)
+
+	for _, param := range method.Params {
+		field, ok := objectType.Fields[param.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("the param %s does not exist in the object type %s", param.Name, bodyType.Name)
+		}
+
+		argumentName := param.Name
+		if paramStructure == rest.JSONRPCParamsByPosition {
+			argumentName = utils.ToCamelCase(param.Name)
+		}
+
+		if _, ok := results[argumentName]; ok || argumentName == "" {
+			return nil, nil, fmt.Errorf("the argument name %s of the param %s is empty or duplicated", argumentName, param.Name)
+		}
+
+		description := field.Description
+		if paramDescription := param.GetDescription(); paramDescription != "" {
+			description = &paramDescription
+		}
+
+		results[argumentName] = rest.ArgumentInfo{
+			ArgumentInfo: schema.ArgumentInfo{
+				Description: description,
+				Type:        field.Type,
+			},
+		}
+		params = append(params, argumentName)
+	}
+
+	return results, params, nil
+}
+
+func getContentDescriptorSchema(descriptor *ContentDescriptor) any {
+	if len(descriptor.Schema) == 0 {
+		return map[string]any{}
+	}
+
+	return json.RawMessage(descriptor.Schema)
+}
+
+// isProcedureMethod checks if the method modifies data by the leading verb of name segments,
+// e.g. eth_sendRawTransaction or user.create. Methods without known verbs are read-only.
+func isProcedureMethod(name string) bool {
+	segments := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, segment := range segments {
+		end := len(segment)
+
+		for i, r := range segment {
+			if i > 0 && unicode.IsUpper(r) {
+				end = i
+
+				break
+			}
+		}
+
+		if isProcedure, ok := methodVerbs[strings.ToLower(segment[:end])]; ok {
+			return isProcedure
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package openrpc
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// OpenRPCToNDCSchema converts an OpenRPC document to NDC HTTP schema.
+// Methods are converted to operations of an OpenAPI 3 document which is built with the OpenAPI converter,
+// then arguments are sent as params of JSON-RPC 2.0 requests to the server URL.
+func OpenRPCToNDCSchema(input []byte, options openapi.ConvertOptions) (*rest.NDCHttpSchema, []error) {
+	var document Document
+	if err := json.Unmarshal(input, &document); err != nil {
+		return nil, []error{err}
+	}
+
+	if len(document.Methods) == 0 {
+		return nil, []error{errors.New("there is no API to be converted")}
+	}
+
+	c := newConverter(&document)
+
+	rawDocument, err := c.BuildOpenAPIDocument()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	openAPIBytes, err := json.Marshal(rawDocument)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	// generated paths are internal details of methods.
+	options.TrimPrefix = ""
+
+	result, errs := openapi.OpenAPIv3ToNDCSchema(openAPIBytes, options)
+	if result == nil {
+		return nil, errs
+	}
+
+	if err := c.TransformSchema(result); err != nil {
+		return nil, append(errs, err)
+	}
+
+	return result, errs
+}
@@ -0,0 +1,91 @@
+package openrpc
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/internal/testutil"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	"gotest.tools/v3/assert"
+)
+
+func TestOpenRPCToNDCSchema(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Source   string
+		Expected string
+		Schema   string
+		Options  openapi.ConvertOptions
+	}{
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openrpc/testdata/ethereum/source.json -o ./ndc-http-schema/openrpc/testdata/ethereum/expected.json --spec openrpc --env-prefix ETH
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openrpc/testdata/ethereum/source.json -o ./ndc-http-schema/openrpc/testdata/ethereum/schema.json --pure --spec openrpc --env-prefix ETH
+		{
+			Name:     "ethereum",
+			Source:   "testdata/ethereum/source.json",
+			Expected: "testdata/ethereum/expected.json",
+			Schema:   "testdata/ethereum/schema.json",
+			Options: openapi.ConvertOptions{
+				EnvPrefix: "ETH",
+			},
+		},
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openrpc/testdata/ethereum/source.json -o ./ndc-http-schema/openrpc/testdata/ethereum/expected_prefix.json --spec openrpc --prefix chain --no-deprecation
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/openrpc/testdata/ethereum/source.json -o ./ndc-http-schema/openrpc/testdata/ethereum/expected_prefix.schema.json --pure --spec openrpc --prefix chain --no-deprecation
+		{
+			Name:     "ethereum_prefix",
+			Source:   "testdata/ethereum/source.json",
+			Expected: "testdata/ethereum/expected_prefix.json",
+			Schema:   "testdata/ethereum/expected_prefix.schema.json",
+			Options: openapi.ConvertOptions{
+				Prefix:        "chain",
+				NoDeprecation: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sourceBytes, err := os.ReadFile(tc.Source)
+			assert.NilError(t, err)
+
+			output, errs := OpenRPCToNDCSchema(sourceBytes, tc.Options)
+			if output == nil {
+				t.Fatal(errors.Join(errs...))
+			}
+
+			testutil.AssertJSONFileEqual(t, tc.Expected, output)
+			testutil.AssertJSONFileEqual(t, tc.Schema, output.ToSchemaResponse())
+		})
+	}
+
+	t.Run("failure_empty", func(t *testing.T) {
+		_, errs := OpenRPCToNDCSchema([]byte(`{"openrpc": "1.2.6", "methods": []}`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "there is no API to be converted")
+	})
+
+	t.Run("failure_content_descriptor_ref", func(t *testing.T) {
+		_, errs := OpenRPCToNDCSchema(
+			[]byte(`{"openrpc": "1.2.6", "methods": [{"name": "foo", "params": [{"$ref": "#/components/contentDescriptors/Bar"}]}]}`),
+			openapi.ConvertOptions{},
+		)
+		assert.ErrorContains(t, errors.Join(errs...), "foo: params[0]: content descriptor #/components/contentDescriptors/Bar does not exist")
+	})
+}
+
+func TestIsProcedureMethod(t *testing.T) {
+	testCases := map[string]bool{
+		"eth_getBalance":         false,
+		"eth_sendRawTransaction": true,
+		"eth_newFilter":          true,
+		"eth_call":               false,
+		"net_version":            false,
+		"user.create":            true,
+		"create_user":            true,
+		"get_post":               false,
+		"getSettings":            false,
+	}
+
+	for name, expected := range testCases {
+		assert.Equal(t, expected, isProcedureMethod(name), name)
+	}
+}
@@ -0,0 +1,76 @@
+package openrpc
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Document represents an OpenRPC document.
+type Document struct {
+	OpenRPC    string      `json:"openrpc"`
+	Info       Info        `json:"info"`
+	Servers    []Server    `json:"servers,omitempty"`
+	Methods    []Method    `json:"methods"`
+	Components *Components `json:"components,omitempty"`
+}
+
+// Info represents the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server represents a server which serves the API.
+type Server struct {
+	Name        string                    `json:"name,omitempty"`
+	URL         string                    `json:"url"`
+	Summary     string                    `json:"summary,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	Variables   map[string]ServerVariable `json:"variables,omitempty"`
+}
+
+// ServerVariable represents a variable for server URL template substitution.
+type ServerVariable struct {
+	Default     string   `json:"default"`
+	Enum        []string `json:"enum,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Method represents a method of the API.
+type Method struct {
+	Name           string              `json:"name"`
+	Summary        string              `json:"summary,omitempty"`
+	Description    string              `json:"description,omitempty"`
+	ParamStructure string              `json:"paramStructure,omitempty"`
+	Params         []ContentDescriptor `json:"params"`
+	Result         *ContentDescriptor  `json:"result,omitempty"`
+	Deprecated     bool                `json:"deprecated,omitempty"`
+}
+
+// ContentDescriptor describes a param or the result of a method.
+// The content descriptor can be a reference to the components.
+type ContentDescriptor struct {
+	Ref         string          `json:"$ref,omitempty"`
+	Name        string          `json:"name"`
+	Summary     string          `json:"summary,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Required    bool            `json:"required,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	Deprecated  bool            `json:"deprecated,omitempty"`
+}
+
+// GetDescription returns the description or summary of the content descriptor.
+func (cd ContentDescriptor) GetDescription() string {
+	if description := strings.TrimSpace(cd.Description); description != "" {
+		return description
+	}
+
+	return strings.TrimSpace(cd.Summary)
+}
+
+// Components holds reusable objects of the document.
+type Components struct {
+	Schemas            map[string]json.RawMessage   `json:"schemas,omitempty"`
+	ContentDescriptors map[string]ContentDescriptor `json:"contentDescriptors,omitempty"`
+}
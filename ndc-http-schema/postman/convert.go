@@ -0,0 +1,790 @@
+package postman
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-http/ndc-http-schema/utils"
+)
+
+var (
+	variableRegex      = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+	nonParamNameRegex  = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+	skippedHeaderNames = []string{
+		"accept",
+		"authorization",
+		"content-length",
+		"content-type",
+		"cookie",
+		"host",
+		"user-agent",
+	}
+)
+
+type serverInfo struct {
+	ID  string
+	URL string
+}
+
+type converter struct {
+	collection      *Collection
+	logger          *slog.Logger
+	variables       map[string]string
+	paths           map[string]map[string]any
+	servers         []serverInfo
+	securitySchemes map[string]any
+	schemeKeys      map[string]string
+}
+
+func newConverter(collection *Collection, logger *slog.Logger) *converter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	variables := make(map[string]string)
+
+	for _, variable := range collection.Variable {
+		if variable.Key == "" || variable.Disabled {
+			continue
+		}
+
+		variables[variable.Key] = variable.StringValue()
+	}
+
+	return &converter{
+		collection:      collection,
+		logger:          logger,
+		variables:       variables,
+		paths:           make(map[string]map[string]any),
+		securitySchemes: make(map[string]any),
+		schemeKeys:      make(map[string]string),
+	}
+}
+
+// Build converts the collection to an OpenAPI 3 document.
+func (c *converter) Build() (map[string]any, error) {
+	document := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       c.collection.Info.Name,
+			"description": string(c.collection.Info.Description),
+			"version":     c.getVersion(),
+		},
+	}
+
+	if c.collection.Auth != nil {
+		if requirement := c.convertAuth(c.collection.Auth); requirement != nil {
+			document["security"] = []any{requirement}
+		}
+	}
+
+	c.convertItems(c.collection.Item, nil, c.collection.Auth)
+
+	if len(c.paths) == 0 {
+		return nil, errors.New("there is no API to be converted")
+	}
+
+	document["paths"] = c.paths
+
+	if len(c.servers) > 0 {
+		document["servers"] = []any{
+			map[string]any{"url": c.servers[0].URL},
+		}
+	}
+
+	if len(c.securitySchemes) > 0 {
+		document["components"] = map[string]any{
+			"securitySchemes": c.securitySchemes,
+		}
+	}
+
+	return document, nil
+}
+
+func (c *converter) getVersion() string {
+	if version, ok := c.collection.Info.Version.(string); ok && version != "" {
+		return version
+	}
+
+	return "1.0.0"
+}
+
+// convertItems converts items recursively. Folder names are used as prefixes of operation names.
+func (c *converter) convertItems(items []Item, folders []string, auth *Auth) {
+	for _, item := range items {
+		itemAuth := auth
+		if item.Auth != nil && item.Auth.Type != "inherit" {
+			itemAuth = item.Auth
+		}
+
+		if item.Request == nil {
+			if item.Item != nil {
+				c.convertItems(item.Item, append(slices.Clone(folders), item.Name), itemAuth)
+			}
+
+			continue
+		}
+
+		if err := c.convertRequest(item, folders, itemAuth); err != nil {
+			c.logger.Warn(
+				"failed to convert the postman request",
+				slog.String("name", item.Name),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+func (c *converter) convertRequest(item Item, folders []string, auth *Auth) error {
+	request := item.Request
+	if request.Auth != nil && request.Auth.Type != "inherit" {
+		auth = request.Auth
+	}
+
+	method := strings.ToLower(request.Method)
+	if method == "" {
+		method = "get"
+	}
+
+	baseURL, rawPath, query := c.splitURL(request.URL)
+
+	apiPath, parameters := c.convertPath(rawPath, request.URL.Variable)
+	if _, ok := c.paths[apiPath][method]; ok {
+		return fmt.Errorf("duplicated operation %s %s", strings.ToUpper(method), apiPath)
+	}
+
+	parameters = append(parameters, c.convertQueryParameters(query)...)
+	parameters = append(parameters, c.convertHeaderParameters(request.Header, auth)...)
+
+	description := string(request.Description)
+	if description == "" {
+		description = string(item.Description)
+	}
+
+	operation := map[string]any{
+		"operationId": utils.StringSliceToCamelCase(append(slices.Clone(folders), item.Name)),
+		"summary":     item.Name,
+		"responses":   c.convertResponses(item.Response),
+	}
+
+	if description != "" {
+		operation["description"] = description
+	}
+
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if requestBody := c.convertBody(request.Body, request.Header); requestBody != nil {
+		operation["requestBody"] = requestBody
+	}
+
+	if auth != c.collection.Auth {
+		if auth == nil || auth.Type == "noauth" {
+			// an empty requirement makes the security optional.
+			operation["security"] = []any{map[string]any{}}
+		} else if requirement := c.convertAuth(auth); requirement != nil {
+			operation["security"] = []any{requirement}
+		}
+	}
+
+	if server := c.registerServer(baseURL); server != nil {
+		operation["servers"] = []any{
+			map[string]any{"url": server.URL, "x-server-id": server.ID},
+		}
+	}
+
+	if _, ok := c.paths[apiPath]; !ok {
+		c.paths[apiPath] = make(map[string]any)
+	}
+
+	c.paths[apiPath][method] = operation
+
+	return nil
+}
+
+// splitURL splits the request URL to the base URL with resolved variables, path and query parameters.
+func (c *converter) splitURL(u URL) (string, string, KeyValues) {
+	protocol := u.Protocol
+	host := strings.Join(u.Host, ".")
+	rawPath := strings.Join(u.Path, "/")
+	query := u.Query
+
+	if host == "" && u.Raw != "" {
+		raw := u.Raw
+
+		raw, rawQuery, hasQuery := strings.Cut(raw, "?")
+		if hasQuery && len(query) == 0 {
+			for pair := range strings.SplitSeq(rawQuery, "&") {
+				key, value, _ := strings.Cut(pair, "=")
+				if key != "" {
+					query = append(query, KeyValue{Key: key, Value: value})
+				}
+			}
+		}
+
+		if scheme, remain, ok := strings.Cut(raw, "://"); ok {
+			protocol = scheme
+			raw = remain
+		}
+
+		host, rawPath, _ = strings.Cut(raw, "/")
+		host, u.Port, _ = strings.Cut(host, ":")
+	}
+
+	host = c.resolveVariables(host)
+
+	var baseURL string
+
+	if strings.Contains(host, "://") {
+		// the host variable usually includes the scheme, e.g. {{baseUrl}}.
+		baseURL = host
+	} else {
+		if protocol == "" {
+			protocol = "http"
+		}
+
+		baseURL = protocol + "://" + host
+		if u.Port != "" {
+			baseURL += ":" + c.resolveVariables(u.Port)
+		}
+	}
+
+	return strings.TrimRight(baseURL, "/"), rawPath, query
+}
+
+// registerServer adds the base URL to the server list.
+// Returns the server if it isn't the default one, which should be set to the operation.
+func (c *converter) registerServer(baseURL string) *serverInfo {
+	for i, server := range c.servers {
+		if server.URL != baseURL {
+			continue
+		}
+
+		if i == 0 {
+			return nil
+		}
+
+		return &c.servers[i]
+	}
+
+	server := serverInfo{
+		URL: baseURL,
+		ID:  c.getServerID(baseURL),
+	}
+
+	c.servers = append(c.servers, server)
+
+	if len(c.servers) == 1 {
+		return nil
+	}
+
+	return &server
+}
+
+func (c *converter) getServerID(baseURL string) string {
+	for _, key := range slices.Sorted(maps.Keys(c.variables)) {
+		if strings.TrimRight(c.variables[key], "/") == baseURL {
+			return utils.ToCamelCase(key)
+		}
+	}
+
+	host := baseURL
+	if _, remain, ok := strings.Cut(host, "://"); ok {
+		host = remain
+	}
+
+	return utils.ToCamelCase(host)
+}
+
+func (c *converter) convertPath(rawPath string, urlVariables []Variable) (string, []any) {
+	var segments []string
+
+	var parameters []any
+
+	addParameter := func(name string, example string) string {
+		paramName := nonParamNameRegex.ReplaceAllString(name, "_")
+		parameter := map[string]any{
+			"name":     paramName,
+			"in":       rest.InPath,
+			"required": true,
+			"schema":   map[string]any{"type": "string"},
+		}
+
+		for _, variable := range urlVariables {
+			if variable.Key == name && variable.Description != "" {
+				parameter["description"] = string(variable.Description)
+			}
+		}
+
+		if example != "" {
+			parameter["example"] = example
+		}
+
+		parameters = append(parameters, parameter)
+
+		return "{" + paramName + "}"
+	}
+
+	for segment := range strings.SplitSeq(rawPath, "/") {
+		if segment == "" {
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(segment, ":"); ok && name != "" {
+			var example string
+
+			for _, variable := range urlVariables {
+				if variable.Key == name {
+					example = variable.StringValue()
+				}
+			}
+
+			segments = append(segments, addParameter(name, example))
+
+			continue
+		}
+
+		if matches := variableRegex.FindStringSubmatch(segment); len(matches) > 1 &&
+			matches[0] == segment {
+			if _, ok := c.variables[matches[1]]; !ok {
+				segments = append(segments, addParameter(matches[1], ""))
+
+				continue
+			}
+		}
+
+		segments = append(segments, c.resolveVariables(segment))
+	}
+
+	return "/" + strings.Join(segments, "/"), parameters
+}
+
+func (c *converter) convertQueryParameters(query KeyValues) []any {
+	var results []any
+
+	names := map[string]bool{}
+
+	for _, param := range query {
+		if param.Disabled || param.Key == "" || names[param.Key] {
+			continue
+		}
+
+		names[param.Key] = true
+		parameter := map[string]any{
+			"name":   param.Key,
+			"in":     rest.InQuery,
+			"schema": map[string]any{"type": "string"},
+		}
+
+		if param.Description != "" {
+			parameter["description"] = string(param.Description)
+		}
+
+		results = append(results, parameter)
+	}
+
+	return results
+}
+
+func (c *converter) convertHeaderParameters(headers KeyValues, auth *Auth) []any {
+	var results []any
+
+	names := map[string]bool{}
+
+	var apiKeyHeader string
+	if auth != nil && auth.Type == "apikey" {
+		apiKeyHeader = strings.ToLower(getAuthAttribute(auth.APIKey, "key"))
+	}
+
+	for _, header := range headers {
+		name := strings.ToLower(header.Key)
+		if header.Disabled || name == "" || names[name] || name == apiKeyHeader ||
+			slices.Contains(skippedHeaderNames, name) {
+			continue
+		}
+
+		names[name] = true
+		parameter := map[string]any{
+			"name":   header.Key,
+			"in":     rest.InHeader,
+			"schema": map[string]any{"type": "string"},
+		}
+
+		if header.Description != "" {
+			parameter["description"] = string(header.Description)
+		}
+
+		results = append(results, parameter)
+	}
+
+	return results
+}
+
+func (c *converter) convertBody(body *Body, headers KeyValues) map[string]any {
+	if body == nil || body.Disabled {
+		return nil
+	}
+
+	var contentType string
+
+	var bodySchema map[string]any
+
+	switch body.Mode {
+	case "raw":
+		if strings.TrimSpace(body.Raw) == "" {
+			return nil
+		}
+
+		language := ""
+		if body.Options != nil && body.Options.Raw != nil {
+			language = body.Options.Raw.Language
+		}
+
+		if value, ok := decodeJSONExample(body.Raw); ok && (language == "" || language == "json") {
+			contentType = rest.ContentTypeJSON
+			bodySchema = utils.InferJSONSchema(value)
+		} else {
+			contentType = rest.ContentTypeTextPlain
+			bodySchema = map[string]any{"type": "string"}
+		}
+	case "urlencoded":
+		contentType = rest.ContentTypeFormURLEncoded
+		bodySchema = convertFormFields(body.URLEncoded)
+	case "formdata":
+		contentType = rest.ContentTypeMultipartFormData
+		bodySchema = convertFormFields(body.FormData)
+	case "file":
+		contentType = rest.ContentTypeOctetStream
+		bodySchema = map[string]any{"type": "string", "format": "binary"}
+	case "graphql":
+		if body.GraphQL == nil {
+			return nil
+		}
+
+		variablesSchema := map[string]any{"type": "object"}
+		if value, ok := decodeJSONExample(body.GraphQL.Variables); ok {
+			variablesSchema = utils.InferJSONSchema(value)
+		}
+
+		contentType = rest.ContentTypeJSON
+		bodySchema = map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query":     map[string]any{"type": "string", "example": body.GraphQL.Query},
+				"variables": variablesSchema,
+			},
+			"required": []string{"query"},
+		}
+	default:
+		return nil
+	}
+
+	// prefer the content type in request headers if the body isn't JSON.
+	if contentType == rest.ContentTypeTextPlain {
+		for _, header := range headers {
+			if !header.Disabled && strings.EqualFold(header.Key, rest.ContentTypeHeader) &&
+				utils.IsContentTypeText(header.Value) {
+				contentType = header.Value
+			}
+		}
+	}
+
+	return map[string]any{
+		"required": true,
+		"content": map[string]any{
+			contentType: map[string]any{
+				"schema": bodySchema,
+			},
+		},
+	}
+}
+
+// convertResponses infers the result schema from the saved example responses of the first success status.
+func (c *converter) convertResponses(examples []Response) map[string]any {
+	statusCode := 0
+
+	var responseExamples []Response
+
+	for _, example := range examples {
+		code := example.Code
+		if code == 0 {
+			code = http.StatusOK
+		}
+
+		if code < 200 || code >= 300 || (statusCode != 0 && code > statusCode) {
+			continue
+		}
+
+		if code < statusCode || statusCode == 0 {
+			statusCode = code
+			responseExamples = nil
+		}
+
+		responseExamples = append(responseExamples, example)
+	}
+
+	if statusCode == 0 {
+		return map[string]any{
+			"200": map[string]any{"description": "OK"},
+		}
+	}
+
+	response := map[string]any{
+		"description": http.StatusText(statusCode),
+	}
+
+	var contentType string
+
+	var responseSchema map[string]any
+
+	for _, example := range responseExamples {
+		if strings.TrimSpace(example.Body) == "" {
+			continue
+		}
+
+		exampleContentType := getExampleContentType(example)
+		if exampleContentType == rest.ContentTypeJSON {
+			value, ok := decodeJSONExample(example.Body)
+			if !ok {
+				continue
+			}
+
+			if responseSchema == nil || contentType != exampleContentType {
+				responseSchema = utils.InferJSONSchema(value)
+			} else {
+				responseSchema = utils.MergeJSONSchemas(responseSchema, utils.InferJSONSchema(value))
+			}
+
+			contentType = exampleContentType
+
+			continue
+		}
+
+		if contentType == "" {
+			contentType = exampleContentType
+			responseSchema = map[string]any{"type": "string"}
+		}
+	}
+
+	if contentType != "" {
+		response["content"] = map[string]any{
+			contentType: map[string]any{
+				"schema": responseSchema,
+			},
+		}
+	}
+
+	return map[string]any{
+		strconv.Itoa(statusCode): response,
+	}
+}
+
+// convertAuth registers the security scheme of the auth config and returns the security requirement.
+func (c *converter) convertAuth(auth *Auth) map[string]any {
+	var key string
+
+	var scheme map[string]any
+
+	scopes := []string{}
+
+	switch auth.Type {
+	case "noauth", "inherit", "":
+		return nil
+	case "apikey":
+		location := getAuthAttribute(auth.APIKey, "in")
+		if location == "" {
+			location = string(rest.APIKeyInHeader)
+		}
+
+		key = "apiKey"
+		scheme = map[string]any{
+			"type": "apiKey",
+			"name": getAuthAttribute(auth.APIKey, "key"),
+			"in":   location,
+		}
+	case "bearer":
+		key = "bearerAuth"
+		scheme = map[string]any{
+			"type":   "http",
+			"scheme": "bearer",
+		}
+	case "basic":
+		key = "basicAuth"
+		scheme = map[string]any{
+			"type":   "http",
+			"scheme": "basic",
+		}
+	case "oauth2":
+		key = "oauth2"
+		scheme, scopes = c.convertOAuth2(auth.OAuth2)
+	default:
+		c.logger.Warn("unsupported postman auth type " + auth.Type)
+
+		return nil
+	}
+
+	fingerprint, _ := json.Marshal(scheme)
+	if existingKey, ok := c.schemeKeys[string(fingerprint)]; ok {
+		key = existingKey
+	} else {
+		uniqueKey := key
+		for i := 2; c.securitySchemes[uniqueKey] != nil; i++ {
+			uniqueKey = key + strconv.Itoa(i)
+		}
+
+		key = uniqueKey
+		c.securitySchemes[key] = scheme
+		c.schemeKeys[string(fingerprint)] = key
+	}
+
+	return map[string]any{key: scopes}
+}
+
+func (c *converter) convertOAuth2(attributes []AuthAttribute) (map[string]any, []string) {
+	scopes := []string{}
+	scopeDescriptions := map[string]any{}
+
+	for scope := range strings.FieldsSeq(getAuthAttribute(attributes, "scope")) {
+		scopes = append(scopes, scope)
+		scopeDescriptions[scope] = ""
+	}
+
+	tokenURL := c.resolveVariables(getAuthAttribute(attributes, "accessTokenUrl"))
+	authURL := c.resolveVariables(getAuthAttribute(attributes, "authUrl"))
+
+	var flowName string
+
+	flow := map[string]any{
+		"scopes": scopeDescriptions,
+	}
+
+	switch getAuthAttribute(attributes, "grant_type") {
+	case "client_credentials":
+		flowName = "clientCredentials"
+		flow["tokenUrl"] = tokenURL
+	case "password", "password_credentials":
+		flowName = "password"
+		flow["tokenUrl"] = tokenURL
+	case "implicit":
+		flowName = "implicit"
+		flow["authorizationUrl"] = authURL
+	default:
+		flowName = "authorizationCode"
+		flow["authorizationUrl"] = authURL
+		flow["tokenUrl"] = tokenURL
+	}
+
+	return map[string]any{
+		"type": "oauth2",
+		"flows": map[string]any{
+			flowName: flow,
+		},
+	}, scopes
+}
+
+// resolveVariables replaces collection variables in the input string.
+func (c *converter) resolveVariables(input string) string {
+	return variableRegex.ReplaceAllStringFunc(input, func(s string) string {
+		name := variableRegex.FindStringSubmatch(s)[1]
+		if value, ok := c.variables[name]; ok {
+			return value
+		}
+
+		return s
+	})
+}
+
+func convertFormFields(fields KeyValues) map[string]any {
+	properties := map[string]any{}
+
+	for _, field := range fields {
+		if field.Disabled || field.Key == "" {
+			continue
+		}
+
+		property := map[string]any{"type": "string"}
+		if field.Type == "file" {
+			property["format"] = "binary"
+		}
+
+		if field.Description != "" {
+			property["description"] = string(field.Description)
+		}
+
+		properties[field.Key] = property
+	}
+
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+func getExampleContentType(example Response) string {
+	for _, header := range example.Header {
+		if strings.EqualFold(header.Key, rest.ContentTypeHeader) {
+			contentType, _, _ := strings.Cut(header.Value, ";")
+
+			contentType = strings.TrimSpace(contentType)
+			if utils.IsContentTypeJSON(contentType) {
+				return rest.ContentTypeJSON
+			}
+
+			return contentType
+		}
+	}
+
+	switch example.PreviewLanguage {
+	case "json":
+		return rest.ContentTypeJSON
+	case "xml":
+		return rest.ContentTypeXML
+	case "html":
+		return "text/html"
+	default:
+		return rest.ContentTypeTextPlain
+	}
+}
+
+// decodeJSONExample decodes the JSON example. Unknown variables such as {{id}} are replaced by null.
+func decodeJSONExample(input string) (any, bool) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, false
+	}
+
+	value, err := decodeJSON([]byte(input))
+	if err == nil {
+		return value, true
+	}
+
+	value, err = decodeJSON([]byte(variableRegex.ReplaceAllString(input, "null")))
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+func decodeJSON(input []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,34 @@
+package postman
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// PostmanToNDCSchema converts a Postman Collection v2.1 document to NDC HTTP schema.
+// The collection is converted to an OpenAPI 3 document which is built with the OpenAPI converter.
+func PostmanToNDCSchema(input []byte, options openapi.ConvertOptions) (*rest.NDCHttpSchema, []error) {
+	var collection Collection
+	if err := json.Unmarshal(input, &collection); err != nil {
+		return nil, []error{err}
+	}
+
+	if len(collection.Item) == 0 {
+		return nil, []error{errors.New("there is no API to be converted")}
+	}
+
+	document, err := newConverter(&collection, options.Logger).Build()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	rawDocument, err := json.Marshal(document)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return openapi.OpenAPIv3ToNDCSchema(rawDocument, options)
+}
@@ -0,0 +1,78 @@
+package postman
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/internal/testutil"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	"gotest.tools/v3/assert"
+)
+
+func TestPostmanToNDCSchema(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Source   string
+		Expected string
+		Schema   string
+		Options  openapi.ConvertOptions
+	}{
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/postman/testdata/petstore/source.json -o ./ndc-http-schema/postman/testdata/petstore/expected.json --spec postman --env-prefix PET_STORE
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/postman/testdata/petstore/source.json -o ./ndc-http-schema/postman/testdata/petstore/schema.json --pure --spec postman --env-prefix PET_STORE
+		{
+			Name:     "petstore",
+			Source:   "testdata/petstore/source.json",
+			Expected: "testdata/petstore/expected.json",
+			Schema:   "testdata/petstore/schema.json",
+			Options: openapi.ConvertOptions{
+				EnvPrefix: "PET_STORE",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sourceBytes, err := os.ReadFile(tc.Source)
+			assert.NilError(t, err)
+
+			output, errs := PostmanToNDCSchema(sourceBytes, tc.Options)
+			if output == nil {
+				t.Fatal(errors.Join(errs...))
+			}
+
+			testutil.AssertJSONFileEqual(t, tc.Expected, output)
+			testutil.AssertJSONFileEqual(t, tc.Schema, output.ToSchemaResponse())
+		})
+	}
+
+	t.Run("failure_empty", func(t *testing.T) {
+		_, errs := PostmanToNDCSchema([]byte(`{"info": {"name": "empty"}, "item": []}`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "there is no API to be converted")
+	})
+
+	t.Run("failure_invalid", func(t *testing.T) {
+		_, errs := PostmanToNDCSchema([]byte(`openapi: 3.0.0`), openapi.ConvertOptions{})
+		assert.Assert(t, len(errs) > 0)
+	})
+}
+
+func TestConverterRawURL(t *testing.T) {
+	collection := &Collection{
+		Variable: []Variable{
+			{Key: "host", Value: "api.example.com"},
+		},
+	}
+
+	c := newConverter(collection, nil)
+	baseURL, rawPath, query := c.splitURL(URL{
+		Raw: "https://{{host}}:8443/users/:id/{{tenant}}?active=true&page",
+	})
+	assert.Equal(t, "https://api.example.com:8443", baseURL)
+	assert.Equal(t, "users/:id/{{tenant}}", rawPath)
+	assert.DeepEqual(t, KeyValues{{Key: "active", Value: "true"}, {Key: "page"}}, query)
+
+	apiPath, parameters := c.convertPath(rawPath, nil)
+	assert.Equal(t, "/users/{id}/{tenant}", apiPath)
+	assert.Equal(t, 2, len(parameters))
+}
@@ -0,0 +1,305 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Collection represents a Postman Collection v2.1 document.
+type Collection struct {
+	Info     CollectionInfo `json:"info"`
+	Item     []Item         `json:"item"`
+	Auth     *Auth          `json:"auth,omitempty"`
+	Variable []Variable     `json:"variable,omitempty"`
+}
+
+// CollectionInfo represents the information of a Postman collection.
+type CollectionInfo struct {
+	Name        string      `json:"name"`
+	Description Description `json:"description,omitempty"`
+	Version     any         `json:"version,omitempty"`
+	Schema      string      `json:"schema,omitempty"`
+}
+
+// Item represents a request or a folder of the collection.
+type Item struct {
+	Name        string      `json:"name"`
+	Description Description `json:"description,omitempty"`
+	Item        []Item      `json:"item,omitempty"`
+	Request     *Request    `json:"request,omitempty"`
+	Response    []Response  `json:"response,omitempty"`
+	Auth        *Auth       `json:"auth,omitempty"`
+}
+
+// IsFolder checks if the item is a folder of items.
+func (item Item) IsFolder() bool {
+	return item.Request == nil && item.Item != nil
+}
+
+// Request represents a Postman request.
+type Request struct {
+	URL         URL         `json:"url"`
+	Method      string      `json:"method,omitempty"`
+	Header      KeyValues   `json:"header,omitempty"`
+	Body        *Body       `json:"body,omitempty"`
+	Auth        *Auth       `json:"auth,omitempty"`
+	Description Description `json:"description,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A request can be an URL string.
+func (r *Request) UnmarshalJSON(b []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(b, &rawURL); err == nil {
+		*r = Request{
+			URL: URL{Raw: rawURL},
+		}
+
+		return nil
+	}
+
+	type plain Request
+
+	var result plain
+	if err := json.Unmarshal(b, &result); err != nil {
+		return err
+	}
+
+	*r = Request(result)
+
+	return nil
+}
+
+// URL represents the URL of a Postman request.
+type URL struct {
+	Raw      string     `json:"raw,omitempty"`
+	Protocol string     `json:"protocol,omitempty"`
+	Host     stringList `json:"host,omitempty"`
+	Port     string     `json:"port,omitempty"`
+	Path     stringList `json:"path,omitempty"`
+	Query    KeyValues  `json:"query,omitempty"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. An URL can be a raw string.
+func (u *URL) UnmarshalJSON(b []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(b, &rawURL); err == nil {
+		*u = URL{Raw: rawURL}
+
+		return nil
+	}
+
+	type plain URL
+
+	var result plain
+	if err := json.Unmarshal(b, &result); err != nil {
+		return err
+	}
+
+	*u = URL(result)
+
+	return nil
+}
+
+// Variable represents a Postman variable.
+type Variable struct {
+	Key         string      `json:"key"`
+	Value       any         `json:"value,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Description Description `json:"description,omitempty"`
+	Disabled    bool        `json:"disabled,omitempty"`
+}
+
+// StringValue returns the value of the variable as a string.
+func (v Variable) StringValue() string {
+	if v.Value == nil {
+		return ""
+	}
+
+	if str, ok := v.Value.(string); ok {
+		return str
+	}
+
+	return fmt.Sprint(v.Value)
+}
+
+// KeyValue represents a header, query parameter or form field.
+type KeyValue struct {
+	Key         string      `json:"key"`
+	Value       string      `json:"value,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Disabled    bool        `json:"disabled,omitempty"`
+	Description Description `json:"description,omitempty"`
+}
+
+// KeyValues represents a list of key-value pairs. Headers can also be a raw string.
+type KeyValues []KeyValue
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (kv *KeyValues) UnmarshalJSON(b []byte) error {
+	var rawString string
+	if err := json.Unmarshal(b, &rawString); err == nil {
+		var results KeyValues
+
+		for line := range strings.SplitSeq(rawString, "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if !ok || strings.TrimSpace(key) == "" {
+				continue
+			}
+
+			results = append(results, KeyValue{
+				Key:   strings.TrimSpace(key),
+				Value: strings.TrimSpace(value),
+			})
+		}
+
+		*kv = results
+
+		return nil
+	}
+
+	var results []KeyValue
+	if err := json.Unmarshal(b, &results); err != nil {
+		return err
+	}
+
+	*kv = results
+
+	return nil
+}
+
+// Body represents the body of a Postman request.
+type Body struct {
+	Mode       string       `json:"mode"`
+	Raw        string       `json:"raw,omitempty"`
+	URLEncoded KeyValues    `json:"urlencoded,omitempty"`
+	FormData   KeyValues    `json:"formdata,omitempty"`
+	GraphQL    *GraphQLBody `json:"graphql,omitempty"`
+	Options    *BodyOptions `json:"options,omitempty"`
+	Disabled   bool         `json:"disabled,omitempty"`
+}
+
+// GraphQLBody represents the GraphQL body of a Postman request.
+type GraphQLBody struct {
+	Query     string `json:"query,omitempty"`
+	Variables string `json:"variables,omitempty"`
+}
+
+// BodyOptions represents extra options of the request body.
+type BodyOptions struct {
+	Raw *struct {
+		Language string `json:"language,omitempty"`
+	} `json:"raw,omitempty"`
+}
+
+// Response represents a saved example response of a request.
+type Response struct {
+	Name            string    `json:"name,omitempty"`
+	Code            int       `json:"code,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	Header          KeyValues `json:"header,omitempty"`
+	Body            string    `json:"body,omitempty"`
+	PreviewLanguage string    `json:"_postman_previewlanguage,omitempty"`
+}
+
+// Auth represents the authentication of a collection, folder or request.
+type Auth struct {
+	Type   string          `json:"type"`
+	APIKey []AuthAttribute `json:"apikey,omitempty"`
+	Bearer []AuthAttribute `json:"bearer,omitempty"`
+	Basic  []AuthAttribute `json:"basic,omitempty"`
+	OAuth2 []AuthAttribute `json:"oauth2,omitempty"`
+}
+
+// AuthAttribute represents an attribute of the authentication.
+type AuthAttribute struct {
+	Key   string `json:"key"`
+	Value any    `json:"value,omitempty"`
+	Type  string `json:"type,omitempty"`
+}
+
+func getAuthAttribute(attributes []AuthAttribute, key string) string {
+	for _, attr := range attributes {
+		if attr.Key != key || attr.Value == nil {
+			continue
+		}
+
+		if str, ok := attr.Value.(string); ok {
+			return str
+		}
+
+		return fmt.Sprint(attr.Value)
+	}
+
+	return ""
+}
+
+// Description can be a string or an object with the content.
+type Description string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Description) UnmarshalJSON(b []byte) error {
+	var content string
+	if err := json.Unmarshal(b, &content); err == nil {
+		*d = Description(content)
+
+		return nil
+	}
+
+	var object struct {
+		Content string `json:"content"`
+	}
+
+	if err := json.Unmarshal(b, &object); err != nil {
+		return err
+	}
+
+	*d = Description(object.Content)
+
+	return nil
+}
+
+// stringList is a list of strings that can be decoded from a dot-separated string or a list of segments.
+type stringList []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (sl *stringList) UnmarshalJSON(b []byte) error {
+	var rawString string
+	if err := json.Unmarshal(b, &rawString); err == nil {
+		*sl = stringList{rawString}
+
+		return nil
+	}
+
+	var rawItems []json.RawMessage
+	if err := json.Unmarshal(b, &rawItems); err != nil {
+		return err
+	}
+
+	results := make(stringList, 0, len(rawItems))
+
+	for _, rawItem := range rawItems {
+		var item string
+		if err := json.Unmarshal(rawItem, &item); err == nil {
+			results = append(results, item)
+
+			continue
+		}
+
+		// path segments can be objects, e.g. {"type": "string", "value": "users"}
+		var object struct {
+			Value string `json:"value"`
+		}
+
+		if err := json.Unmarshal(rawItem, &object); err != nil {
+			return err
+		}
+
+		results = append(results, object.Value)
+	}
+
+	*sl = results
+
+	return nil
+}
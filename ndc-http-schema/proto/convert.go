@@ -0,0 +1,786 @@
+package proto
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-http/ndc-http-schema/utils"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
+)
+
+var errUnsupportedOperation = errors.New("unsupported operation")
+
+// scalar value types and their equivalent NDC scalars in the proto3 JSON mapping.
+// 64-bit integers are encoded as decimal strings.
+var protoScalars = map[string]rest.ScalarName{
+	"bool":     rest.ScalarBoolean,
+	"bytes":    rest.ScalarBytes,
+	"double":   rest.ScalarFloat64,
+	"fixed32":  rest.ScalarInt64,
+	"fixed64":  rest.ScalarBigInteger,
+	"float":    rest.ScalarFloat32,
+	"int32":    rest.ScalarInt32,
+	"int64":    rest.ScalarBigInteger,
+	"sfixed32": rest.ScalarInt32,
+	"sfixed64": rest.ScalarBigInteger,
+	"sint32":   rest.ScalarInt32,
+	"sint64":   rest.ScalarBigInteger,
+	"string":   rest.ScalarString,
+	"uint32":   rest.ScalarInt64,
+	"uint64":   rest.ScalarBigInteger,
+}
+
+// well-known types which have special representations in the proto3 JSON mapping.
+// Timestamps are RFC 3339 strings, durations are seconds with the s suffix, e.g. 1.5s,
+// field masks are comma-separated paths and wrappers are their wrapped values.
+var wellKnownTypes = map[string]rest.ScalarName{
+	"Any":         rest.ScalarJSON,
+	"BoolValue":   rest.ScalarBoolean,
+	"BytesValue":  rest.ScalarBytes,
+	"DoubleValue": rest.ScalarFloat64,
+	"Duration":    rest.ScalarString,
+	"Empty":       rest.ScalarJSON,
+	"FieldMask":   rest.ScalarString,
+	"FloatValue":  rest.ScalarFloat32,
+	"Int32Value":  rest.ScalarInt32,
+	"Int64Value":  rest.ScalarBigInteger,
+	"ListValue":   rest.ScalarJSON,
+	"StringValue": rest.ScalarString,
+	"Struct":      rest.ScalarJSON,
+	"Timestamp":   rest.ScalarTimestampTZ,
+	"UInt32Value": rest.ScalarInt64,
+	"UInt64Value": rest.ScalarBigInteger,
+	"Value":       rest.ScalarJSON,
+}
+
+var scalarRepresentations = map[rest.ScalarName]schema.TypeRepresentation{
+	rest.ScalarBigInteger:  schema.NewTypeRepresentationBigInteger().Encode(),
+	rest.ScalarBoolean:     schema.NewTypeRepresentationBoolean().Encode(),
+	rest.ScalarBytes:       schema.NewTypeRepresentationBytes().Encode(),
+	rest.ScalarFloat32:     schema.NewTypeRepresentationFloat32().Encode(),
+	rest.ScalarFloat64:     schema.NewTypeRepresentationFloat64().Encode(),
+	rest.ScalarInt32:       schema.NewTypeRepresentationInt32().Encode(),
+	rest.ScalarInt64:       schema.NewTypeRepresentationInt64().Encode(),
+	rest.ScalarJSON:        schema.NewTypeRepresentationJSON().Encode(),
+	rest.ScalarString:      schema.NewTypeRepresentationString().Encode(),
+	rest.ScalarTimestampTZ: schema.NewTypeRepresentationTimestampTZ().Encode(),
+}
+
+// pathVariable represents a variable of the path template which is bound to a field of the request message,
+// e.g. {book.name=shelves/*/books/*}.
+type pathVariable struct {
+	fieldPath []string
+	argument  string
+}
+
+type converter struct {
+	file    *File
+	options openapi.ConvertOptions
+	logger  *slog.Logger
+	schema  *rest.NDCHttpSchema
+	// definitions indexed by fully-qualified names without the leading dot.
+	messages map[string]*Message
+	enums    map[string]*Enum
+	// converted NDC type names of definitions
+	typeNames map[string]string
+}
+
+func newConverter(file *File, options openapi.ConvertOptions) *converter {
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	c := &converter{
+		file:      file,
+		options:   options,
+		logger:    logger,
+		schema:    rest.NewNDCHttpSchema(),
+		messages:  map[string]*Message{},
+		enums:     map[string]*Enum{},
+		typeNames: map[string]string{},
+	}
+
+	c.indexDefinitions(file.Messages, file.Enums)
+
+	return c
+}
+
+func (c *converter) indexDefinitions(messages []*Message, enums []*Enum) {
+	for _, enum := range enums {
+		c.enums[enum.FullName] = enum
+	}
+
+	for _, message := range messages {
+		c.messages[message.FullName] = message
+		c.indexDefinitions(message.Messages, message.Enums)
+	}
+}
+
+// Build converts rpcs which are annotated with HTTP rules to NDC operations.
+// GET rules are converted to functions and others are converted to procedures.
+func (c *converter) Build() (*rest.NDCHttpSchema, error) {
+	for _, service := range c.file.Services {
+		for _, method := range service.Methods {
+			if err := c.convertMethod(service, method); err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", service.Name, method.Name, err)
+			}
+		}
+	}
+
+	if len(c.schema.Functions) == 0 && len(c.schema.Procedures) == 0 {
+		return nil, errors.New("there is no API to be converted")
+	}
+
+	c.schema.Settings.Servers = []rest.ServerConfig{
+		{
+			URL: goenvconf.NewEnvStringVariable(
+				utils.StringSliceToConstantCase([]string{c.options.EnvPrefix, "SERVER_URL"}),
+			),
+		},
+	}
+
+	return c.schema, nil
+}
+
+func (c *converter) convertMethod(service *Service, method *Method) error {
+	if c.options.NoDeprecation && isDeprecated(method.Options) {
+		return nil
+	}
+
+	rule := method.GetHTTPRule()
+	if rule == nil {
+		c.logger.Debug(
+			"skipped the rpc without the HTTP rule",
+			slog.String("service", service.Name),
+			slog.String("rpc", method.Name),
+		)
+
+		return nil
+	}
+
+	if method.ClientStreaming || method.ServerStreaming {
+		c.warnUnsupportedOperation(method.Name, fmt.Errorf("%w: streaming rpc", errUnsupportedOperation))
+
+		return nil
+	}
+
+	rules := append([]*HTTPRule{rule}, rule.AdditionalBindings...)
+
+	for i, item := range rules {
+		name := method.Name
+		if i > 0 {
+			name += "Binding" + strconv.Itoa(i)
+		}
+
+		operation, err := c.convertHTTPRule(method, item)
+		if errors.Is(err, errUnsupportedOperation) {
+			c.warnUnsupportedOperation(name, err)
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		operations := c.schema.Procedures
+		if item.Method == "get" {
+			operations = c.schema.Functions
+		}
+
+		operationName := c.formatOperationName(name)
+		if c.hasOperation(operationName) {
+			// rpcs of different services may have the same name.
+			operationName = c.formatOperationName(service.Name, name)
+		}
+
+		if c.hasOperation(operationName) {
+			c.logger.Warn(
+				"skipped the rpc which has a duplicated name",
+				slog.String("rpc", method.Name),
+				slog.String("name", operationName),
+			)
+
+			continue
+		}
+
+		operations[operationName] = *operation
+	}
+
+	return nil
+}
+
+// convertHTTPRule converts the HTTP rule of the rpc to the NDC operation.
+// Fields of the request message which are bound to path variables are converted to path arguments.
+// The body argument is the request message if the body is *, or the field of the request message.
+// Remaining scalar fields are converted to query arguments if they aren't included in the body.
+func (c *converter) convertHTTPRule(method *Method, rule *HTTPRule) (*rest.OperationInfo, error) {
+	if rule.CustomKind != "" {
+		return nil, fmt.Errorf("%w: the custom method %s", errUnsupportedOperation, rule.CustomKind)
+	}
+
+	if rule.Method == "" || rule.Path == "" {
+		return nil, fmt.Errorf("%w: the HTTP rule doesn't have the method and path", errUnsupportedOperation)
+	}
+
+	input := c.findMessage(method.InputType, c.file.Package)
+
+	requestPath, variables, err := parsePathTemplate(rule.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments := map[string]rest.ArgumentInfo{}
+	boundFields := map[string]bool{}
+
+	for _, variable := range variables {
+		if err := c.convertPathArgument(input, variable, arguments); err != nil {
+			return nil, err
+		}
+
+		if len(variable.fieldPath) == 1 {
+			boundFields[variable.fieldPath[0]] = true
+		}
+	}
+
+	request := &rest.Request{
+		URL:    requestPath,
+		Method: rule.Method,
+		Response: rest.Response{
+			ContentType: rest.ContentTypeJSON,
+		},
+	}
+
+	if rule.Body != "" {
+		request.RequestBody = &rest.RequestBody{
+			ContentType: rest.ContentTypeJSON,
+		}
+
+		if err := c.convertBody(method, input, rule, arguments); err != nil {
+			return nil, err
+		}
+
+		boundFields[rule.Body] = true
+	}
+
+	if input != nil && rule.Body != "*" {
+		c.convertQueryArguments(input, boundFields, arguments)
+	}
+
+	resultType, err := c.convertResultType(method, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rest.OperationInfo{
+		Request:     request,
+		Arguments:   arguments,
+		Description: toDescription(method.Description),
+		ResultType:  resultType.Encode(),
+	}, nil
+}
+
+// convertPathArgument converts the field which is bound to the path variable to the required argument.
+func (c *converter) convertPathArgument(
+	input *Message,
+	variable pathVariable,
+	arguments map[string]rest.ArgumentInfo,
+) error {
+	fieldPath := strings.Join(variable.fieldPath, ".")
+
+	field, scope, err := c.findFieldPath(input, variable.fieldPath)
+	if err != nil {
+		return err
+	}
+
+	if field.IsRepeated() || field.IsMap() || !c.isScalarType(field.Type, scope) {
+		return fmt.Errorf("%w: the path variable %s isn't a scalar field", errUnsupportedOperation, fieldPath)
+	}
+
+	typeName, err := c.convertNamedType(field.Type, scope)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fieldPath, err)
+	}
+
+	arguments[variable.argument] = rest.ArgumentInfo{
+		ArgumentInfo: schema.ArgumentInfo{
+			Description: toDescription(field.Description),
+			Type:        schema.NewNamedType(typeName).Encode(),
+		},
+		HTTP: &rest.RequestParameter{
+			In:     rest.InPath,
+			Schema: c.getTypeSchema(typeName),
+		},
+	}
+
+	return nil
+}
+
+// convertBody converts the request message or the field of the request message to the body argument.
+func (c *converter) convertBody(
+	method *Method,
+	input *Message,
+	rule *HTTPRule,
+	arguments map[string]rest.ArgumentInfo,
+) error {
+	var bodyType schema.TypeEncoder
+
+	if rule.Body == "*" {
+		typeName, err := c.convertNamedType(method.InputType, c.file.Package)
+		if err != nil {
+			return fmt.Errorf("body: %w", err)
+		}
+
+		bodyType = schema.NewNamedType(typeName)
+	} else {
+		field, scope, err := c.findFieldPath(input, []string{rule.Body})
+		if err != nil {
+			return err
+		}
+
+		fieldType, err := c.convertFieldType(field, scope)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rule.Body, err)
+		}
+
+		// the body field is required even though the field is optional in the message.
+		if nullableType, ok := fieldType.(*schema.NullableType); ok {
+			fieldType = nullableType.UnderlyingType.Interface()
+		}
+
+		bodyType = fieldType
+	}
+
+	description := "Request body of " + strings.ToUpper(rule.Method) + " " + rule.Path
+	arguments[rest.BodyKey] = rest.ArgumentInfo{
+		ArgumentInfo: schema.ArgumentInfo{
+			Description: &description,
+			Type:        bodyType.Encode(),
+		},
+		HTTP: &rest.RequestParameter{
+			In: rest.InBody,
+			Schema: &rest.TypeSchema{
+				Type: []string{"object"},
+			},
+		},
+	}
+
+	return nil
+}
+
+// convertQueryArguments converts top-level fields of the request message which aren't bound
+// to the path or the body to query arguments. Message and map fields are skipped.
+func (c *converter) convertQueryArguments(
+	input *Message,
+	boundFields map[string]bool,
+	arguments map[string]rest.ArgumentInfo,
+) {
+	for _, field := range input.Fields {
+		if boundFields[field.Name] || (c.options.NoDeprecation && isDeprecated(field.Options)) {
+			continue
+		}
+
+		name := field.JSONName()
+		if _, ok := arguments[name]; ok {
+			continue
+		}
+
+		if field.IsMap() || !c.isScalarType(field.Type, input.FullName) {
+			c.logger.Debug(
+				"skipped the message field which can't be a query parameter",
+				slog.String("message", input.FullName),
+				slog.String("field", field.Name),
+			)
+
+			continue
+		}
+
+		typeName, err := c.convertNamedType(field.Type, input.FullName)
+		if err != nil {
+			continue
+		}
+
+		var argType schema.TypeEncoder = schema.NewNamedType(typeName)
+
+		typeSchema := c.getTypeSchema(typeName)
+
+		if field.IsRepeated() {
+			argType = schema.NewArrayType(argType)
+			typeSchema = &rest.TypeSchema{
+				Type:  []string{"array"},
+				Items: typeSchema,
+			}
+		}
+
+		arguments[name] = rest.ArgumentInfo{
+			ArgumentInfo: schema.ArgumentInfo{
+				Description: toDescription(field.Description),
+				Type:        schema.NewNullableType(argType).Encode(),
+			},
+			HTTP: &rest.RequestParameter{
+				In:     rest.InQuery,
+				Schema: typeSchema,
+			},
+		}
+	}
+}
+
+// convertResultType converts the response message or the field of the response message
+// if the response body is specified.
+func (c *converter) convertResultType(method *Method, rule *HTTPRule) (schema.TypeEncoder, error) {
+	if rule.ResponseBody != "" {
+		field, scope, err := c.findFieldPath(c.findMessage(method.OutputType, c.file.Package), []string{rule.ResponseBody})
+		if err != nil {
+			return nil, fmt.Errorf("response body: %w", err)
+		}
+
+		return c.convertFieldType(field, scope)
+	}
+
+	typeName, err := c.convertNamedType(method.OutputType, c.file.Package)
+	if err != nil {
+		return nil, fmt.Errorf("result: %w", err)
+	}
+
+	var resultType schema.TypeEncoder = schema.NewNamedType(typeName)
+
+	if typeName == string(rest.ScalarJSON) {
+		// empty messages may respond no content.
+		resultType = schema.NewNullableType(resultType)
+	}
+
+	return resultType, nil
+}
+
+// convertNamedType converts the type reference in the scope to the NDC type name.
+// Types which are imported from other files are converted to JSON.
+func (c *converter) convertNamedType(typeRef string, scope string) (string, error) {
+	if scalarName, ok := protoScalars[typeRef]; ok {
+		return c.addScalar(scalarName), nil
+	}
+
+	fullName, ok := c.resolveType(typeRef, scope)
+	if !ok {
+		c.logger.Warn(
+			"the type isn't defined in the source file and is converted to JSON",
+			slog.String("type", typeRef),
+		)
+
+		return c.addScalar(rest.ScalarJSON), nil
+	}
+
+	if scalarName, ok := getWellKnownType(fullName); ok {
+		return c.addScalar(scalarName), nil
+	}
+
+	if typeName, ok := c.typeNames[fullName]; ok {
+		return typeName, nil
+	}
+
+	if enum, ok := c.enums[fullName]; ok {
+		typeName := c.formatTypeName(fullName)
+		values := make([]string, len(enum.Values))
+
+		for i, value := range enum.Values {
+			values[i] = value.Name
+		}
+
+		scalar := schema.NewScalarType()
+		scalar.Representation = schema.NewTypeRepresentationEnum(values).Encode()
+		c.schema.AddScalar(typeName, *scalar)
+		c.typeNames[fullName] = typeName
+
+		return typeName, nil
+	}
+
+	message := c.messages[fullName]
+	if len(message.Fields) == 0 {
+		// NDC object types must have fields.
+		typeName := c.addScalar(rest.ScalarJSON)
+		c.typeNames[fullName] = typeName
+
+		return typeName, nil
+	}
+
+	typeName := c.formatTypeName(fullName)
+	// register the name before converting fields to support recursive types.
+	c.typeNames[fullName] = typeName
+
+	objectType := rest.ObjectType{
+		Description: toDescription(message.Description),
+		Fields:      map[string]rest.ObjectField{},
+	}
+
+	if typeName != message.Name {
+		objectType.Alias = message.Name
+	}
+
+	for _, field := range message.Fields {
+		if c.options.NoDeprecation && isDeprecated(field.Options) {
+			continue
+		}
+
+		fieldType, err := c.convertFieldType(field, fullName)
+		if err != nil {
+			delete(c.typeNames, fullName)
+
+			return "", fmt.Errorf("%s.%s: %w", message.Name, field.Name, err)
+		}
+
+		objectType.Fields[field.JSONName()] = rest.ObjectField{
+			ObjectField: schema.ObjectField{
+				Description: toDescription(field.Description),
+				Type:        fieldType.Encode(),
+			},
+		}
+	}
+
+	c.schema.ObjectTypes[typeName] = objectType
+
+	return typeName, nil
+}
+
+// convertFieldType converts the type of the field. Fields are nullable because default values are omitted
+// in the proto3 JSON mapping, except required fields of proto2. Map fields are converted to JSON objects.
+func (c *converter) convertFieldType(field *Field, scope string) (schema.TypeEncoder, error) {
+	if field.IsMap() {
+		return schema.NewNullableType(schema.NewNamedType(c.addScalar(rest.ScalarJSON))), nil
+	}
+
+	typeName, err := c.convertNamedType(field.Type, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var result schema.TypeEncoder = schema.NewNamedType(typeName)
+
+	if field.IsRepeated() {
+		result = schema.NewArrayType(result)
+	}
+
+	if field.Label != "required" {
+		result = schema.NewNullableType(result)
+	}
+
+	return result, nil
+}
+
+// resolveType resolves the type reference to the fully-qualified name with the scoping rules of protobuf.
+// The reference is searched from the innermost scope to the outermost scope, e.g. Book in the scope
+// library.v1.ListBooksResponse is resolved to library.v1.ListBooksResponse.Book, library.v1.Book or Book.
+func (c *converter) resolveType(typeRef string, scope string) (string, bool) {
+	if after, ok := strings.CutPrefix(typeRef, "."); ok {
+		return after, c.hasType(after)
+	}
+
+	for {
+		fullName := joinFullName(scope, typeRef)
+		if c.hasType(fullName) {
+			return fullName, true
+		}
+
+		if scope == "" {
+			return typeRef, false
+		}
+
+		index := strings.LastIndex(scope, ".")
+		if index < 0 {
+			scope = ""
+		} else {
+			scope = scope[:index]
+		}
+	}
+}
+
+func (c *converter) hasType(fullName string) bool {
+	if _, ok := c.messages[fullName]; ok {
+		return true
+	}
+
+	if _, ok := c.enums[fullName]; ok {
+		return true
+	}
+
+	_, ok := getWellKnownType(fullName)
+
+	return ok
+}
+
+// isScalarType checks if the type is converted to a scalar which can be encoded in the URL.
+func (c *converter) isScalarType(typeRef string, scope string) bool {
+	if _, ok := protoScalars[typeRef]; ok {
+		return true
+	}
+
+	fullName, ok := c.resolveType(typeRef, scope)
+	if !ok {
+		return false
+	}
+
+	if scalarName, ok := getWellKnownType(fullName); ok {
+		return scalarName != rest.ScalarJSON
+	}
+
+	_, ok = c.enums[fullName]
+
+	return ok
+}
+
+// findMessage returns the message of the type reference. Returns nil if the message isn't defined in the source file.
+func (c *converter) findMessage(typeRef string, scope string) *Message {
+	fullName, ok := c.resolveType(typeRef, scope)
+	if !ok {
+		return nil
+	}
+
+	return c.messages[fullName]
+}
+
+// findFieldPath returns the field of the dot-separated field path and the scope of the field type.
+func (c *converter) findFieldPath(message *Message, fieldPath []string) (*Field, string, error) {
+	for i, name := range fieldPath {
+		if message == nil {
+			return nil, "", fmt.Errorf("%w: the field %s does not exist", errUnsupportedOperation, strings.Join(fieldPath[:i+1], "."))
+		}
+
+		index := slices.IndexFunc(message.Fields, func(field *Field) bool {
+			return field.Name == name
+		})
+		if index < 0 {
+			return nil, "", fmt.Errorf("the field %s does not exist in %s", name, message.FullName)
+		}
+
+		field := message.Fields[index]
+		if i == len(fieldPath)-1 {
+			return field, message.FullName, nil
+		}
+
+		message = c.findMessage(field.Type, message.FullName)
+	}
+
+	return nil, "", errors.New("the field path is empty")
+}
+
+func (c *converter) hasOperation(name string) bool {
+	_, isFunction := c.schema.Functions[name]
+	_, isProcedure := c.schema.Procedures[name]
+
+	return isFunction || isProcedure
+}
+
+func (c *converter) addScalar(scalarName rest.ScalarName) string {
+	scalar := schema.NewScalarType()
+	scalar.Representation = scalarRepresentations[scalarName]
+	c.schema.AddScalar(string(scalarName), *scalar)
+
+	return string(scalarName)
+}
+
+// getTypeSchema returns the HTTP schema of the scalar type which is used to encode URL parameters.
+func (c *converter) getTypeSchema(typeName string) *rest.TypeSchema {
+	result := &rest.TypeSchema{}
+
+	switch rest.ScalarName(typeName) {
+	case rest.ScalarBoolean:
+		result.Type = []string{"boolean"}
+	case rest.ScalarInt32, rest.ScalarInt64:
+		result.Type = []string{"integer"}
+	case rest.ScalarFloat32, rest.ScalarFloat64:
+		result.Type = []string{"number"}
+	default:
+		result.Type = []string{"string"}
+	}
+
+	return result
+}
+
+func (c *converter) warnUnsupportedOperation(name string, err error) {
+	c.logger.Warn(
+		"skipped the unsupported operation",
+		slog.String("operation", name),
+		slog.String("reason", err.Error()),
+	)
+}
+
+func (c *converter) formatOperationName(names ...string) string {
+	if c.options.Prefix == "" {
+		return utils.StringSliceToCamelCase(names)
+	}
+
+	return utils.StringSliceToCamelCase(append([]string{c.options.Prefix}, names...))
+}
+
+// formatTypeName returns the type name of the definition. Names of nested definitions
+// are prefixed with names of their parent messages, e.g. Book.Kind to BookKind.
+func (c *converter) formatTypeName(fullName string) string {
+	name := strings.ReplaceAll(strings.TrimPrefix(fullName, c.file.Package+"."), ".", "")
+	if c.options.Prefix == "" {
+		return name
+	}
+
+	return utils.ToPascalCase(c.options.Prefix) + name
+}
+
+// parsePathTemplate converts the path template of the HTTP rule to the request path with path variables,
+// e.g. /v1/{name=shelves/*/books/*}:cancel to /v1/{name}:cancel.
+func parsePathTemplate(template string) (string, []pathVariable, error) {
+	var sb strings.Builder
+
+	var variables []pathVariable
+
+	for i := 0; i < len(template); i++ {
+		switch template[i] {
+		case '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return "", nil, fmt.Errorf("invalid path template %s: unclosed variable", template)
+			}
+
+			fieldPath, _, _ := strings.Cut(template[i+1:i+end], "=")
+			segments := strings.Split(strings.TrimSpace(fieldPath), ".")
+			variable := pathVariable{
+				fieldPath: segments,
+				argument:  toLowerCamelCase(strings.Join(segments, "_")),
+			}
+
+			variables = append(variables, variable)
+			sb.WriteString("{" + variable.argument + "}")
+
+			i += end
+		case '*':
+			return "", nil, fmt.Errorf("%w: the wildcard path segment without the variable in %s", errUnsupportedOperation, template)
+		default:
+			sb.WriteByte(template[i])
+		}
+	}
+
+	return sb.String(), variables, nil
+}
+
+func getWellKnownType(fullName string) (rest.ScalarName, bool) {
+	name, ok := strings.CutPrefix(fullName, wellKnownNamespace+".")
+	if !ok {
+		return "", false
+	}
+
+	scalarName, ok := wellKnownTypes[name]
+
+	return scalarName, ok
+}
+
+func toDescription(description string) *string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil
+	}
+
+	return &description
+}
@@ -0,0 +1,315 @@
+package proto
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
+	"github.com/bufbuild/protocompile/sourceinfo"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// field numbers of descriptors which are used to build paths of source locations.
+const (
+	fileMessageTypePath   = 4
+	fileEnumTypePath      = 5
+	fileServicePath       = 6
+	messageFieldPath      = 2
+	messageNestedTypePath = 3
+	messageEnumTypePath   = 4
+	enumValuePath         = 2
+	serviceMethodPath     = 2
+)
+
+const (
+	defaultProtoFileName = "source.proto"
+	defaultProtoSyntax   = "proto2"
+)
+
+// protoParser builds the file from the descriptor which is parsed by protocompile.
+// Imported files are not resolved, so type references are kept as written in the source.
+type protoParser struct {
+	result   parser.Result
+	comments map[string]string
+}
+
+// ParseProto parses a .proto source file in the proto2, proto3 or editions syntax.
+func ParseProto(input string) (*File, error) {
+	handler := reporter.NewHandler(nil)
+
+	node, err := parser.Parse(defaultProtoFileName, strings.NewReader(input), handler)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parser.ResultFromAST(node, true, handler)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &protoParser{
+		result:   result,
+		comments: map[string]string{},
+	}
+
+	for _, location := range sourceinfo.GenerateSourceInfo(node, nil).GetLocation() {
+		if comment := location.GetLeadingComments(); comment != "" {
+			p.comments[fmt.Sprint(location.GetPath())] = formatComment(comment)
+		}
+	}
+
+	return p.parseFile(result.FileDescriptorProto())
+}
+
+func (p *protoParser) parseFile(fd *descriptorpb.FileDescriptorProto) (*File, error) {
+	file := &File{
+		Syntax:  cmp.Or(fd.GetSyntax(), defaultProtoSyntax),
+		Package: fd.GetPackage(),
+		Imports: fd.GetDependency(),
+		Options: p.parseOptions(fd.GetOptions().GetUninterpretedOption()),
+	}
+
+	for i, md := range fd.GetMessageType() {
+		message, err := p.parseMessage(md, fd.GetPackage(), []int32{fileMessageTypePath, int32(i)})
+		if err != nil {
+			return nil, err
+		}
+
+		file.Messages = append(file.Messages, message)
+	}
+
+	for i, ed := range fd.GetEnumType() {
+		file.Enums = append(file.Enums, p.parseEnum(ed, fd.GetPackage(), []int32{fileEnumTypePath, int32(i)}))
+	}
+
+	for i, sd := range fd.GetService() {
+		file.Services = append(file.Services, p.parseService(sd, []int32{fileServicePath, int32(i)}))
+	}
+
+	return file, nil
+}
+
+func (p *protoParser) parseMessage(md *descriptorpb.DescriptorProto, scope string, path []int32) (*Message, error) {
+	message := &Message{
+		Name:        md.GetName(),
+		FullName:    joinFullName(scope, md.GetName()),
+		Description: p.getComment(path),
+		Options:     p.parseOptions(md.GetOptions().GetUninterpretedOption()),
+	}
+
+	mapEntries := map[string]*descriptorpb.DescriptorProto{}
+
+	for i, nested := range md.GetNestedType() {
+		if nested.GetOptions().GetMapEntry() {
+			mapEntries[nested.GetName()] = nested
+
+			continue
+		}
+
+		nestedMessage, err := p.parseMessage(nested, message.FullName, appendPath(path, messageNestedTypePath, i))
+		if err != nil {
+			return nil, err
+		}
+
+		message.Messages = append(message.Messages, nestedMessage)
+	}
+
+	for i, ed := range md.GetEnumType() {
+		message.Enums = append(message.Enums, p.parseEnum(ed, message.FullName, appendPath(path, messageEnumTypePath, i)))
+	}
+
+	for i, fd := range md.GetField() {
+		if fd.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+			return nil, p.errorAt(p.result.FieldNode(fd), "groups are not supported")
+		}
+
+		field := &Field{
+			Name:        fd.GetName(),
+			Number:      strconv.Itoa(int(fd.GetNumber())),
+			Label:       getFieldLabel(fd),
+			Type:        getFieldType(fd),
+			Description: p.getComment(appendPath(path, messageFieldPath, i)),
+			Options:     p.parseOptions(fd.GetOptions().GetUninterpretedOption()),
+		}
+
+		if entry, ok := mapEntries[fd.GetTypeName()]; ok && len(entry.GetField()) == 2 {
+			field.Label = ""
+			field.KeyType = getFieldType(entry.GetField()[0])
+			field.Type = getFieldType(entry.GetField()[1])
+		}
+
+		if fd.OneofIndex != nil && !fd.GetProto3Optional() {
+			field.OneOf = md.GetOneofDecl()[fd.GetOneofIndex()].GetName()
+		}
+
+		message.Fields = append(message.Fields, field)
+	}
+
+	return message, nil
+}
+
+func (p *protoParser) parseEnum(ed *descriptorpb.EnumDescriptorProto, scope string, path []int32) *Enum {
+	enum := &Enum{
+		Name:        ed.GetName(),
+		FullName:    joinFullName(scope, ed.GetName()),
+		Description: p.getComment(path),
+		Options:     p.parseOptions(ed.GetOptions().GetUninterpretedOption()),
+	}
+
+	for i, value := range ed.GetValue() {
+		enum.Values = append(enum.Values, EnumValue{
+			Name:        value.GetName(),
+			Number:      strconv.Itoa(int(value.GetNumber())),
+			Description: p.getComment(appendPath(path, enumValuePath, i)),
+		})
+	}
+
+	return enum
+}
+
+func (p *protoParser) parseService(sd *descriptorpb.ServiceDescriptorProto, path []int32) *Service {
+	service := &Service{
+		Name:        sd.GetName(),
+		Description: p.getComment(path),
+		Options:     p.parseOptions(sd.GetOptions().GetUninterpretedOption()),
+	}
+
+	for i, md := range sd.GetMethod() {
+		service.Methods = append(service.Methods, &Method{
+			Name:            md.GetName(),
+			Description:     p.getComment(appendPath(path, serviceMethodPath, i)),
+			InputType:       md.GetInputType(),
+			OutputType:      md.GetOutputType(),
+			ClientStreaming: md.GetClientStreaming(),
+			ServerStreaming: md.GetServerStreaming(),
+			Options:         p.parseOptions(md.GetOptions().GetUninterpretedOption()),
+		})
+	}
+
+	return service
+}
+
+// parseOptions converts uninterpreted options to options with values of their source nodes.
+func (p *protoParser) parseOptions(options []*descriptorpb.UninterpretedOption) []Option {
+	var results []Option
+
+	for _, option := range options {
+		node, ok := p.result.OptionNode(option).(*ast.OptionNode)
+		if !ok {
+			continue
+		}
+
+		names := make([]string, len(node.Name.Parts))
+		for i, part := range node.Name.Parts {
+			names[i] = part.Value()
+		}
+
+		results = append(results, Option{
+			Name:  strings.Join(names, "."),
+			Value: newOptionValue(node.Val),
+		})
+	}
+
+	return results
+}
+
+func (p *protoParser) getComment(path []int32) string {
+	return p.comments[fmt.Sprint(path)]
+}
+
+func (p *protoParser) errorAt(node ast.Node, format string, args ...any) error {
+	return fmt.Errorf("%s: %s", p.result.AST().NodeInfo(node).Start(), fmt.Sprintf(format, args...))
+}
+
+// newOptionValue converts a constant or a message value in the text format.
+// Elements of array values in the message value are represented as repeated fields.
+func newOptionValue(node ast.ValueNode) *OptionValue {
+	switch value := node.Value().(type) {
+	case []*ast.MessageFieldNode:
+		result := &OptionValue{}
+
+		for _, field := range value {
+			name := field.Name.Value()
+
+			if elements, ok := field.Val.Value().([]ast.ValueNode); ok {
+				for _, element := range elements {
+					result.Fields = append(result.Fields, OptionField{Name: name, Value: newOptionValue(element)})
+				}
+
+				continue
+			}
+
+			result.Fields = append(result.Fields, OptionField{Name: name, Value: newOptionValue(field.Val)})
+		}
+
+		return result
+	case []ast.ValueNode:
+		result := &OptionValue{}
+
+		for _, element := range value {
+			result.Fields = append(result.Fields, OptionField{Value: newOptionValue(element)})
+		}
+
+		return result
+	case string:
+		return &OptionValue{Scalar: value}
+	case ast.Identifier:
+		return &OptionValue{Scalar: string(value)}
+	case float64:
+		return &OptionValue{Scalar: strconv.FormatFloat(value, 'g', -1, 64)}
+	default:
+		return &OptionValue{Scalar: fmt.Sprint(value)}
+	}
+}
+
+func getFieldLabel(fd *descriptorpb.FieldDescriptorProto) string {
+	if fd.Label == nil {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimPrefix(fd.GetLabel().String(), "LABEL_"))
+}
+
+// getFieldType returns the scalar type name or the message or enum type name as written in the source.
+func getFieldType(fd *descriptorpb.FieldDescriptorProto) string {
+	if fd.Type == nil {
+		return fd.GetTypeName()
+	}
+
+	return strings.ToLower(strings.TrimPrefix(fd.GetType().String(), "TYPE_"))
+}
+
+// formatComment removes leading spaces and asterisks of lines in the comment.
+func formatComment(comment string) string {
+	lines := strings.Split(comment, "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(strings.TrimSpace(line), "*")
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func appendPath(path []int32, elements ...int) []int32 {
+	result := make([]int32, len(path), len(path)+len(elements))
+	copy(result, path)
+
+	for _, element := range elements {
+		result = append(result, int32(element))
+	}
+
+	return result
+}
+
+func joinFullName(scope string, name string) string {
+	if scope == "" {
+		return name
+	}
+
+	return scope + "." + name
+}
@@ -0,0 +1,29 @@
+package proto
+
+import (
+	"errors"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// ProtoToNDCSchema converts a .proto source file to NDC HTTP schema with the gRPC transcoding rules.
+// Rpcs which are annotated with google.api.http rules are converted to operations of the REST endpoints.
+// Messages are converted with the proto3 JSON mapping, e.g. 64-bit integers are strings and enums are value names.
+func ProtoToNDCSchema(input []byte, options openapi.ConvertOptions) (*rest.NDCHttpSchema, []error) {
+	file, err := ParseProto(string(input))
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	if len(file.Services) == 0 {
+		return nil, []error{errors.New("the proto file has no service")}
+	}
+
+	result, err := newConverter(file, options).Build()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,154 @@
+package proto
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/internal/testutil"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	"gotest.tools/v3/assert"
+)
+
+func TestProtoToNDCSchema(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Source   string
+		Expected string
+		Schema   string
+		Options  openapi.ConvertOptions
+	}{
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/proto/testdata/library/source.proto -o ./ndc-http-schema/proto/testdata/library/expected.json --spec proto --env-prefix LIBRARY --no-deprecation
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/proto/testdata/library/source.proto -o ./ndc-http-schema/proto/testdata/library/schema.json --pure --spec proto --env-prefix LIBRARY --no-deprecation
+		{
+			Name:     "library",
+			Source:   "testdata/library/source.proto",
+			Expected: "testdata/library/expected.json",
+			Schema:   "testdata/library/schema.json",
+			Options: openapi.ConvertOptions{
+				EnvPrefix:     "LIBRARY",
+				NoDeprecation: true,
+			},
+		},
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/proto/testdata/echo/source.proto -o ./ndc-http-schema/proto/testdata/echo/expected.json --spec proto --prefix echo
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/proto/testdata/echo/source.proto -o ./ndc-http-schema/proto/testdata/echo/schema.json --pure --spec proto --prefix echo
+		{
+			Name:     "echo",
+			Source:   "testdata/echo/source.proto",
+			Expected: "testdata/echo/expected.json",
+			Schema:   "testdata/echo/schema.json",
+			Options: openapi.ConvertOptions{
+				Prefix: "echo",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sourceBytes, err := os.ReadFile(tc.Source)
+			assert.NilError(t, err)
+
+			output, errs := ProtoToNDCSchema(sourceBytes, tc.Options)
+			if output == nil {
+				t.Fatal(errors.Join(errs...))
+			}
+
+			testutil.AssertJSONFileEqual(t, tc.Expected, output)
+			testutil.AssertJSONFileEqual(t, tc.Schema, output.ToSchemaResponse())
+		})
+	}
+
+	t.Run("failure_syntax", func(t *testing.T) {
+		_, errs := ProtoToNDCSchema([]byte(`syntax = "proto3"; message Book { string name = 1 }`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "source.proto:1:51: syntax error: expecting ';'")
+	})
+
+	t.Run("failure_no_service", func(t *testing.T) {
+		_, errs := ProtoToNDCSchema([]byte(`syntax = "proto3"; message Book { string name = 1; }`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "the proto file has no service")
+	})
+
+	t.Run("failure_empty", func(t *testing.T) {
+		_, errs := ProtoToNDCSchema([]byte(`syntax = "proto3";
+			service Library { rpc GetBook(Book) returns (Book); }
+			message Book { string name = 1; }`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "there is no API to be converted")
+	})
+
+	t.Run("failure_unknown_field", func(t *testing.T) {
+		_, errs := ProtoToNDCSchema([]byte(`syntax = "proto3";
+			service Library {
+				rpc GetBook(Book) returns (Book) {
+					option (google.api.http) = { get: "/v1/{id}" };
+				}
+			}
+			message Book { string name = 1; }`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "Library.GetBook: the field id does not exist in Book")
+	})
+}
+
+func TestParseProto(t *testing.T) {
+	file, err := ParseProto(`
+		syntax = "proto2";
+		package demo.v1;
+		import public "google/api/annotations.proto";
+
+		service Demo {
+			// Gets the item.
+			// The item must exist.
+			rpc GetItem(.demo.v1.Item) returns (stream Item) {
+				option (google.api.http) = {
+					get: "/v1/items/{id}" // the primary binding
+					additional_bindings: [{ post: "/v1/items:get", body: "*" }]
+					additional_bindings < put: '/v1/' "items" >
+				};
+			}
+		}
+
+		message Item {
+			required int64 id = 1 [default = -1, (validate.rules).int64 = { gt: 0 }];
+			map<string, Item> children = 2;
+			oneof kind {
+				string label = 3;
+			}
+			extensions 100 to max;
+			extend Item { optional string note = 100; }
+			enum Status { option allow_alias = true; ACTIVE = 0; ENABLED = 0; reserved 5; }
+		}
+	`)
+	assert.NilError(t, err)
+	assert.Equal(t, file.Syntax, "proto2")
+	assert.Equal(t, file.Package, "demo.v1")
+	assert.DeepEqual(t, file.Imports, []string{"google/api/annotations.proto"})
+
+	method := file.Services[0].Methods[0]
+	assert.Equal(t, method.Description, "Gets the item.\nThe item must exist.")
+	assert.Equal(t, method.InputType, ".demo.v1.Item")
+	assert.Assert(t, !method.ClientStreaming)
+	assert.Assert(t, method.ServerStreaming)
+	assert.DeepEqual(t, method.GetHTTPRule(), &HTTPRule{
+		Method: "get",
+		Path:   "/v1/items/{id}",
+		AdditionalBindings: []*HTTPRule{
+			{Method: "post", Path: "/v1/items:get", Body: "*"},
+			{Method: "put", Path: "/v1/items"},
+		},
+	})
+
+	item := file.Messages[0]
+	assert.Equal(t, item.FullName, "demo.v1.Item")
+	assert.Equal(t, len(item.Fields), 3)
+	assert.Equal(t, item.Fields[0].Label, "required")
+	assert.Equal(t, item.Fields[0].Options[0].Value.Scalar, "-1")
+	assert.Equal(t, item.Fields[1].KeyType, "string")
+	assert.Equal(t, item.Fields[1].Type, "Item")
+	assert.Equal(t, item.Fields[2].OneOf, "kind")
+	assert.Equal(t, item.Enums[0].FullName, "demo.v1.Item.Status")
+	assert.Equal(t, len(item.Enums[0].Values), 2)
+
+	_, err = ParseProto(`syntax = "proto2"; message Item { optional group Result = 1 { optional string url = 2; } }`)
+	assert.ErrorContains(t, err, "groups are not supported")
+
+	_, err = ParseProto(`syntax = "proto3"; message Item { string id = 1; string name = 1; }`)
+	assert.ErrorContains(t, err, "fields id and name both have the same tag 1")
+}
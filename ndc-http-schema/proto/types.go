@@ -0,0 +1,276 @@
+package proto
+
+import (
+	"strings"
+)
+
+const (
+	httpRuleOption     = "(google.api.http)"
+	deprecatedOption   = "deprecated"
+	jsonNameOption     = "json_name"
+	wellKnownNamespace = "google.protobuf"
+)
+
+// File represents a parsed .proto source file.
+type File struct {
+	Syntax   string
+	Package  string
+	Imports  []string
+	Options  []Option
+	Messages []*Message
+	Enums    []*Enum
+	Services []*Service
+}
+
+// Message represents a message definition. Nested definitions are scoped by the message.
+type Message struct {
+	Name        string
+	FullName    string
+	Description string
+	Fields      []*Field
+	Messages    []*Message
+	Enums       []*Enum
+	Options     []Option
+}
+
+// Field represents a field of the message. Fields of oneof groups are flattened.
+type Field struct {
+	Name        string
+	Number      string
+	Label       string
+	Type        string
+	Description string
+	// the key type of the map field. The value type is Type.
+	KeyType string
+	OneOf   string
+	Options []Option
+}
+
+// IsRepeated checks if the field is a repeated field.
+func (f Field) IsRepeated() bool {
+	return f.Label == "repeated"
+}
+
+// IsMap checks if the field is a map field.
+func (f Field) IsMap() bool {
+	return f.KeyType != ""
+}
+
+// JSONName returns the name of the field in the proto3 JSON mapping,
+// which is the json_name option or the lowerCamelCase name.
+func (f Field) JSONName() string {
+	if value, ok := findOption(f.Options, jsonNameOption); ok && value.Scalar != "" {
+		return value.Scalar
+	}
+
+	return toLowerCamelCase(f.Name)
+}
+
+// Enum represents an enum definition.
+type Enum struct {
+	Name        string
+	FullName    string
+	Description string
+	Values      []EnumValue
+	Options     []Option
+}
+
+// EnumValue represents a value of the enum.
+type EnumValue struct {
+	Name        string
+	Number      string
+	Description string
+}
+
+// Service represents a service definition.
+type Service struct {
+	Name        string
+	Description string
+	Methods     []*Method
+	Options     []Option
+}
+
+// Method represents a rpc of the service.
+type Method struct {
+	Name            string
+	Description     string
+	InputType       string
+	OutputType      string
+	ClientStreaming bool
+	ServerStreaming bool
+	Options         []Option
+}
+
+// Option represents an option statement, e.g. option (google.api.http) = { get: "/v1/books" }.
+// The name of extension options is enclosed in parentheses and may be followed by field names,
+// e.g. (google.api.http).get.
+type Option struct {
+	Name  string
+	Value *OptionValue
+}
+
+// OptionValue represents a constant or a message value in the text format.
+type OptionValue struct {
+	Scalar string
+	Fields []OptionField
+}
+
+// OptionField represents a field of the message value.
+// Repeated fields are represented as multiple fields with the same name.
+type OptionField struct {
+	Name  string
+	Value *OptionValue
+}
+
+// Get returns the first value of the field.
+func (ov *OptionValue) Get(name string) (*OptionValue, bool) {
+	if ov == nil {
+		return nil, false
+	}
+
+	for _, field := range ov.Fields {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// GetAll returns all values of the field.
+func (ov *OptionValue) GetAll(name string) []*OptionValue {
+	if ov == nil {
+		return nil
+	}
+
+	var results []*OptionValue
+
+	for _, field := range ov.Fields {
+		if field.Name == name {
+			results = append(results, field.Value)
+		}
+	}
+
+	return results
+}
+
+// HTTPRule represents the google.api.http annotation which maps the rpc to a REST endpoint.
+type HTTPRule struct {
+	Method       string
+	Path         string
+	Body         string
+	ResponseBody string
+	// the kind of the custom method, e.g. HEAD.
+	CustomKind         string
+	AdditionalBindings []*HTTPRule
+}
+
+var httpRuleMethods = []string{"get", "put", "post", "delete", "patch"}
+
+// GetHTTPRule returns the HTTP rule of the method if it's annotated.
+// The annotation can be declared as a message value or separate fields, e.g. option (google.api.http).get = "/v1/books".
+func (m Method) GetHTTPRule() *HTTPRule {
+	value := &OptionValue{}
+
+	for _, option := range m.Options {
+		switch {
+		case option.Name == httpRuleOption:
+			value.Fields = append(value.Fields, option.Value.Fields...)
+		case strings.HasPrefix(option.Name, httpRuleOption+"."):
+			value.Fields = append(value.Fields, OptionField{
+				Name:  strings.TrimPrefix(option.Name, httpRuleOption+"."),
+				Value: option.Value,
+			})
+		}
+	}
+
+	if len(value.Fields) == 0 {
+		return nil
+	}
+
+	return newHTTPRule(value)
+}
+
+func newHTTPRule(value *OptionValue) *HTTPRule {
+	rule := &HTTPRule{}
+
+	for _, method := range httpRuleMethods {
+		if path, ok := value.Get(method); ok {
+			rule.Method = method
+			rule.Path = path.Scalar
+
+			break
+		}
+	}
+
+	if custom, ok := value.Get("custom"); ok {
+		kind, _ := custom.Get("kind")
+		path, _ := custom.Get("path")
+		rule.CustomKind = kind.getScalar()
+		rule.Path = path.getScalar()
+	}
+
+	if body, ok := value.Get("body"); ok {
+		rule.Body = body.Scalar
+	}
+
+	if responseBody, ok := value.Get("response_body"); ok {
+		rule.ResponseBody = responseBody.Scalar
+	}
+
+	for _, binding := range value.GetAll("additional_bindings") {
+		rule.AdditionalBindings = append(rule.AdditionalBindings, newHTTPRule(binding))
+	}
+
+	return rule
+}
+
+func (ov *OptionValue) getScalar() string {
+	if ov == nil {
+		return ""
+	}
+
+	return ov.Scalar
+}
+
+// isDeprecated checks if the deprecated option is true.
+func isDeprecated(options []Option) bool {
+	value, ok := findOption(options, deprecatedOption)
+
+	return ok && value.Scalar == "true"
+}
+
+func findOption(options []Option, name string) (*OptionValue, bool) {
+	for _, option := range options {
+		if option.Name == name {
+			return option.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// toLowerCamelCase converts the field name to lowerCamelCase as protoc does,
+// e.g. display_name to displayName. Underscores are removed and the next letter is capitalized.
+func toLowerCamelCase(name string) string {
+	var sb strings.Builder
+
+	upperNext := false
+
+	for _, char := range name {
+		switch {
+		case char == '_':
+			upperNext = true
+		case upperNext && char >= 'a' && char <= 'z':
+			sb.WriteRune(char - 'a' + 'A')
+
+			upperNext = false
+		default:
+			sb.WriteRune(char)
+
+			upperNext = false
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,567 @@
+package raml
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var uriParamRegex = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// OAuth 2.0 grants of RAML and their equivalent flows of OpenAPI 3.
+var oauth2Flows = map[string]string{
+	"authorization_code": "authorizationCode",
+	"client_credentials": "clientCredentials",
+	"implicit":           "implicit",
+	"password":           "password",
+}
+
+type converter struct {
+	document        *Document
+	logger          *slog.Logger
+	paths           map[string]map[string]any
+	securitySchemes map[string]any
+}
+
+func newConverter(document *Document, logger *slog.Logger) *converter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &converter{
+		document:        document,
+		logger:          logger,
+		paths:           make(map[string]map[string]any),
+		securitySchemes: make(map[string]any),
+	}
+}
+
+// Build converts the RAML document to an OpenAPI 3 document.
+func (c *converter) Build() (map[string]any, error) {
+	version := c.document.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+
+	document := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       c.document.Title,
+			"description": c.document.Description,
+			"version":     version,
+		},
+	}
+
+	for _, name := range sortedKeys(c.document.SecuritySchemes) {
+		c.convertSecurityScheme(name, c.document.SecuritySchemes[name])
+	}
+
+	if security := c.convertSecuredBy(c.document.SecuredBy); security != nil {
+		document["security"] = security
+	}
+
+	schemas := map[string]any{}
+	for _, name := range sortedKeys(c.document.Types) {
+		schemas[name] = c.convertTypeDeclaration(c.document.Types[name], typeString)
+	}
+
+	if err := c.convertResources(c.document.Resources, "", map[string]any{}); err != nil {
+		return nil, err
+	}
+
+	if len(c.paths) == 0 {
+		return nil, errors.New("there is no API to be converted")
+	}
+
+	document["paths"] = c.paths
+
+	if server := c.convertBaseURI(); server != nil {
+		document["servers"] = []any{server}
+	}
+
+	document["components"] = map[string]any{
+		"schemas":         schemas,
+		"securitySchemes": c.securitySchemes,
+	}
+
+	return document, nil
+}
+
+// convertBaseURI converts the base URI to the server. The version parameter is replaced with the API version.
+// Other parameters are server variables with default values.
+func (c *converter) convertBaseURI() map[string]any {
+	baseURI := strings.ReplaceAll(c.document.BaseURI, "{version}", c.document.Version)
+	if baseURI == "" {
+		return nil
+	}
+
+	if !strings.Contains(baseURI, "://") {
+		protocol := "https"
+		if len(c.document.Protocols) > 0 {
+			protocol = strings.ToLower(c.document.Protocols[0])
+		}
+
+		baseURI = protocol + "://" + baseURI
+	}
+
+	server := map[string]any{
+		"url": baseURI,
+	}
+
+	variables := map[string]any{}
+
+	for _, match := range uriParamRegex.FindAllStringSubmatch(baseURI, -1) {
+		declaration, _ := c.document.BaseURIParameters[match[1]].(map[string]any)
+
+		defaultValue := getString(declaration, "default")
+		if defaultValue == "" {
+			if enum := getStrings(declaration, "enum"); len(enum) > 0 {
+				defaultValue = enum[0]
+			}
+		}
+
+		variables[match[1]] = map[string]any{
+			"default": defaultValue,
+		}
+	}
+
+	if len(variables) > 0 {
+		server["variables"] = variables
+	}
+
+	return server
+}
+
+// convertResources converts resources and nested resources recursively.
+// URI parameters of parent resources are inherited.
+func (c *converter) convertResources(resources map[string]any, parentPath string, parentURIParams map[string]any) error {
+	for _, key := range sortedKeys(resources) {
+		resource, _ := resources[key].(map[string]any)
+		if resource == nil {
+			resource = map[string]any{}
+		}
+
+		resourcePath := parentPath + key
+		uriParams := maps.Clone(parentURIParams)
+		maps.Copy(uriParams, getMap(resource, "uriParameters"))
+
+		params := newResourceParams(resourcePath)
+
+		resolved, err := c.resolveResourceType(resource, params)
+		if err != nil {
+			return fmt.Errorf("%s: %w", resourcePath, err)
+		}
+
+		// URI parameters may be declared in the resource type.
+		maps.Copy(uriParams, getMap(resolved, "uriParameters"))
+
+		for _, methodName := range httpMethods {
+			rawMethod, ok := resolved[methodName]
+			if !ok {
+				continue
+			}
+
+			method, _ := rawMethod.(map[string]any)
+			if method == nil {
+				method = map[string]any{}
+			}
+
+			methodParams := params.with(map[string]string{"methodName": methodName})
+			method = substituteParams(method, methodParams).(map[string]any)
+
+			method, err := c.applyTraits(method, getList(resolved, "is"), methodParams)
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", strings.ToUpper(methodName), resourcePath, err)
+			}
+
+			if _, ok := method["securedBy"]; !ok {
+				if securedBy, ok := resolved["securedBy"]; ok {
+					method["securedBy"] = securedBy
+				}
+			}
+
+			if _, ok := c.paths[resourcePath]; !ok {
+				c.paths[resourcePath] = map[string]any{}
+			}
+
+			c.paths[resourcePath][methodName] = c.convertMethod(resourcePath, method, resolved, uriParams)
+		}
+
+		if err := c.convertResources(getResources(resource), resourcePath, uriParams); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) convertMethod(
+	resourcePath string,
+	method map[string]any,
+	resource map[string]any,
+	uriParams map[string]any,
+) map[string]any {
+	operation := map[string]any{
+		"responses": c.convertResponses(getMap(method, "responses")),
+	}
+
+	description := getString(method, "description")
+	if description == "" {
+		description = getString(resource, "description")
+	}
+
+	if description != "" {
+		operation["description"] = description
+	}
+
+	if displayName := getString(method, "displayName"); displayName != "" {
+		operation["summary"] = displayName
+	}
+
+	parameters := c.convertURIParameters(resourcePath, uriParams)
+	parameters = append(parameters, c.convertParameters(c.getQueryParameters(method), "query")...)
+	parameters = append(parameters, c.convertParameters(getMap(method, "headers"), "header")...)
+
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if body, ok := method["body"]; ok && body != nil {
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content":  c.convertBody(body),
+		}
+	}
+
+	if _, ok := method["securedBy"]; ok {
+		if security := c.convertSecuredBy(getList(method, "securedBy")); security != nil {
+			operation["security"] = security
+		}
+	}
+
+	return operation
+}
+
+// convertURIParameters converts URI parameters of the resource path. Undeclared parameters are strings.
+func (c *converter) convertURIParameters(resourcePath string, uriParams map[string]any) []any {
+	var results []any
+
+	for _, match := range uriParamRegex.FindAllStringSubmatch(resourcePath, -1) {
+		name := match[1]
+		declaration := uriParams[name]
+
+		parameter := map[string]any{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   c.convertTypeDeclaration(declaration, typeString),
+		}
+
+		if facets, ok := declaration.(map[string]any); ok {
+			if description := getString(facets, "description"); description != "" {
+				parameter["description"] = description
+			}
+		}
+
+		results = append(results, parameter)
+	}
+
+	return results
+}
+
+// getQueryParameters returns query parameters of the method,
+// or properties of the query string if the query string is declared as an object type.
+func (c *converter) getQueryParameters(method map[string]any) map[string]any {
+	queryParams := getMap(method, "queryParameters")
+
+	queryString, ok := method["queryString"].(map[string]any)
+	if !ok {
+		return queryParams
+	}
+
+	properties := getMap(queryString, "properties")
+	if len(properties) == 0 {
+		c.logger.Warn("the query string which isn't an object type with properties is not supported")
+
+		return queryParams
+	}
+
+	result := maps.Clone(queryParams)
+	maps.Copy(result, properties)
+
+	return result
+}
+
+// convertParameters converts query parameters or headers. Parameters are required by default.
+func (c *converter) convertParameters(params map[string]any, location string) []any {
+	results := []any{}
+
+	for _, key := range sortedKeys(params) {
+		declaration := params[key]
+		name, optional := strings.CutSuffix(key, "?")
+		required := !optional
+
+		parameter := map[string]any{
+			"name":   name,
+			"in":     location,
+			"schema": c.convertTypeDeclaration(declaration, typeString),
+		}
+
+		if facets, ok := declaration.(map[string]any); ok {
+			if value, ok := facets["required"].(bool); ok {
+				required = value
+			}
+
+			if description := getString(facets, "description"); description != "" {
+				parameter["description"] = description
+			}
+		}
+
+		parameter["required"] = required
+		results = append(results, parameter)
+	}
+
+	return results
+}
+
+// convertBody converts the body to contents of media types.
+// The body is a map of media types, or the type declaration of default media types.
+func (c *converter) convertBody(body any) map[string]any {
+	content := map[string]any{}
+
+	if bodies, ok := body.(map[string]any); ok && isMediaTypeMap(bodies) {
+		for _, mediaType := range sortedKeys(bodies) {
+			content[mediaType] = map[string]any{
+				"schema": c.convertTypeDeclaration(bodies[mediaType], typeAny),
+			}
+		}
+
+		return content
+	}
+
+	for _, mediaType := range c.document.MediaTypes {
+		content[mediaType] = map[string]any{
+			"schema": c.convertTypeDeclaration(body, typeAny),
+		}
+	}
+
+	return content
+}
+
+func (c *converter) convertResponses(responses map[string]any) map[string]any {
+	results := map[string]any{}
+
+	for _, key := range sortedKeys(responses) {
+		statusCode, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+
+		response, _ := responses[key].(map[string]any)
+
+		description := getString(response, "description")
+		if description == "" {
+			description = http.StatusText(statusCode)
+		}
+
+		result := map[string]any{
+			"description": description,
+		}
+
+		if body, ok := response["body"]; ok && body != nil {
+			result["content"] = c.convertBody(body)
+		}
+
+		results[key] = result
+	}
+
+	if len(results) == 0 {
+		results["200"] = map[string]any{"description": "OK"}
+	}
+
+	return results
+}
+
+// convertSecurityScheme converts the security scheme. Pass Through and custom schemes are converted to
+// API keys if they are described by a single header or query parameter.
+func (c *converter) convertSecurityScheme(name string, rawScheme any) {
+	scheme, _ := rawScheme.(map[string]any)
+	schemeType := getString(scheme, "type")
+
+	switch {
+	case schemeType == "OAuth 2.0":
+		if result := c.convertOAuth2(getMap(scheme, "settings")); result != nil {
+			c.securitySchemes[name] = result
+
+			return
+		}
+	case schemeType == "Basic Authentication":
+		c.securitySchemes[name] = map[string]any{
+			"type":   "http",
+			"scheme": "basic",
+		}
+
+		return
+	case schemeType == "Digest Authentication":
+		c.securitySchemes[name] = map[string]any{
+			"type":   "http",
+			"scheme": "digest",
+		}
+
+		return
+	case schemeType == "Pass Through" || strings.HasPrefix(schemeType, "x-"):
+		describedBy := getMap(scheme, "describedBy")
+		headers := getMap(describedBy, "headers")
+		queryParams := getMap(describedBy, "queryParameters")
+
+		switch {
+		case len(headers) == 1 && len(queryParams) == 0:
+			headerName, _ := strings.CutSuffix(sortedKeys(headers)[0], "?")
+			if strings.EqualFold(headerName, "Authorization") {
+				c.securitySchemes[name] = map[string]any{
+					"type":   "http",
+					"scheme": "bearer",
+				}
+			} else {
+				c.securitySchemes[name] = map[string]any{
+					"type": "apiKey",
+					"in":   "header",
+					"name": headerName,
+				}
+			}
+
+			return
+		case len(headers) == 0 && len(queryParams) == 1:
+			paramName, _ := strings.CutSuffix(sortedKeys(queryParams)[0], "?")
+			c.securitySchemes[name] = map[string]any{
+				"type": "apiKey",
+				"in":   "query",
+				"name": paramName,
+			}
+
+			return
+		}
+	}
+
+	c.logger.Warn(
+		"unsupported security scheme",
+		slog.String("name", name),
+		slog.String("type", schemeType),
+	)
+}
+
+func (c *converter) convertOAuth2(settings map[string]any) map[string]any {
+	scopes := map[string]any{}
+	for _, scope := range getStrings(settings, "scopes") {
+		scopes[scope] = ""
+	}
+
+	authorizationURL := getString(settings, "authorizationUri")
+	tokenURL := getString(settings, "accessTokenUri")
+	flows := map[string]any{}
+
+	for _, grant := range getStrings(settings, "authorizationGrants") {
+		flowName, ok := oauth2Flows[grant]
+		if !ok {
+			continue
+		}
+
+		flow := map[string]any{
+			"scopes": scopes,
+		}
+
+		if flowName == "authorizationCode" || flowName == "implicit" {
+			flow["authorizationUrl"] = authorizationURL
+		}
+
+		if flowName != "implicit" {
+			flow["tokenUrl"] = tokenURL
+		}
+
+		flows[flowName] = flow
+	}
+
+	if len(flows) == 0 {
+		return nil
+	}
+
+	return map[string]any{
+		"type":  "oauth2",
+		"flows": flows,
+	}
+}
+
+// convertSecuredBy converts security schemes which secure the method to security requirements.
+// The null scheme makes the security optional.
+func (c *converter) convertSecuredBy(securedBy []any) []any {
+	if len(securedBy) == 0 {
+		return nil
+	}
+
+	results := []any{}
+
+	for _, item := range securedBy {
+		name, values := parseSecuredBy(item)
+		if name == "" {
+			results = append(results, map[string]any{})
+
+			continue
+		}
+
+		if _, ok := c.securitySchemes[name]; !ok {
+			continue
+		}
+
+		results = append(results, map[string]any{name: values})
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	return results
+}
+
+// parseSecuredBy parses the reference to the security scheme, which is the name or a map of the name
+// and parameters, e.g. { oauth_2_0: { scopes: [ ADMINISTRATOR ] } }. Returns the name and scopes.
+func parseSecuredBy(item any) (string, []string) {
+	switch value := item.(type) {
+	case nil:
+		return "", []string{}
+	case string:
+		if value == "null" {
+			return "", []string{}
+		}
+
+		return value, []string{}
+	case map[string]any:
+		for name, params := range value {
+			scopes := []string{}
+
+			if paramMap, ok := params.(map[string]any); ok {
+				scopes = append(scopes, getStrings(paramMap, "scopes")...)
+			}
+
+			return name, scopes
+		}
+	}
+
+	return "", []string{}
+}
+
+// isMediaTypeMap checks if keys of the body node are media types, e.g. application/json.
+func isMediaTypeMap(body map[string]any) bool {
+	if len(body) == 0 {
+		return false
+	}
+
+	return slices.IndexFunc(sortedKeys(body), func(key string) bool {
+		return !strings.Contains(key, "/")
+	}) < 0
+}
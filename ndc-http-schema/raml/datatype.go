@@ -0,0 +1,453 @@
+package raml
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+const (
+	typeAny    = "any"
+	typeString = "string"
+	typeObject = "object"
+	typeArray  = "array"
+	typeNil    = "nil"
+)
+
+// facets which are copied to the JSON schema as is.
+var schemaFacets = []string{
+	"default", "enum", "pattern", "minLength", "maxLength", "minimum", "maximum",
+	"multipleOf", "minItems", "maxItems", "uniqueItems", "minProperties", "maxProperties",
+}
+
+// facets which declare the structure of the type. A declaration which inherits another type
+// and has any of these facets is combined with allOf.
+var structuralFacets = []string{
+	"properties", "items", "enum", "pattern", "minLength", "maxLength", "minimum", "maximum",
+	"multipleOf", "minItems", "maxItems", "uniqueItems", "format", "additionalProperties",
+}
+
+// number formats of RAML and their equivalent JSON schema types and formats.
+var numberFormats = map[string][2]string{
+	"int":    {"integer", "int32"},
+	"int8":   {"integer", "int32"},
+	"int16":  {"integer", "int32"},
+	"int32":  {"integer", "int32"},
+	"int64":  {"integer", "int64"},
+	"long":   {"integer", "int64"},
+	"float":  {"number", "float"},
+	"double": {"number", "double"},
+}
+
+// convertTypeDeclaration converts the type declaration to the JSON schema of OpenAPI 3.0.
+// The declaration is a type expression, e.g. Person[] | nil, or a map of facets.
+// The default type applies if the declaration doesn't have the type and properties.
+func (c *converter) convertTypeDeclaration(declaration any, defaultType string) map[string]any {
+	switch value := declaration.(type) {
+	case nil:
+		return c.convertTypeExpression(defaultType)
+	case string:
+		return c.convertTypeExpression(value)
+	case map[string]any:
+		return c.convertTypeFacets(value, defaultType)
+	default:
+		return c.convertTypeExpression(fmt.Sprint(value))
+	}
+}
+
+func (c *converter) convertTypeFacets(declaration map[string]any, defaultType string) map[string]any {
+	baseType, ok := declaration["type"]
+	if !ok {
+		baseType = declaration["schema"]
+	}
+
+	if baseType == nil {
+		switch {
+		case declaration["properties"] != nil:
+			baseType = typeObject
+		case declaration["items"] != nil:
+			baseType = typeArray
+		default:
+			baseType = defaultType
+		}
+	}
+
+	var result map[string]any
+
+	switch base := baseType.(type) {
+	case []any:
+		// multiple inheritance
+		allOf := make([]any, 0, len(base)+1)
+		for _, item := range base {
+			allOf = append(allOf, c.convertTypeDeclaration(item, typeAny))
+		}
+
+		allOf = append(allOf, c.convertFacets(declaration, map[string]any{"type": typeObject}))
+		result = map[string]any{"allOf": allOf}
+	case map[string]any:
+		result = c.convertTypeDeclaration(base, defaultType)
+	default:
+		expression := strings.TrimSpace(fmt.Sprint(base))
+
+		if scalar := c.convertBuiltinType(expression); scalar != nil {
+			result = c.convertFacets(declaration, scalar)
+		} else {
+			result = c.convertTypeExpression(expression)
+
+			if hasAnyFacet(declaration, structuralFacets) {
+				// the declaration extends the inherited type.
+				result = map[string]any{
+					"allOf": []any{result, c.convertFacets(declaration, map[string]any{})},
+				}
+			}
+		}
+	}
+
+	if _, ok := result["$ref"]; ok {
+		// siblings of $ref are ignored in OpenAPI 3.0.
+		return result
+	}
+
+	if displayName := getString(declaration, "displayName"); displayName != "" {
+		result["title"] = displayName
+	}
+
+	if description := getString(declaration, "description"); description != "" {
+		result["description"] = description
+	}
+
+	return result
+}
+
+// convertFacets copies facets of the declaration to the JSON schema.
+func (c *converter) convertFacets(declaration map[string]any, result map[string]any) map[string]any {
+	for _, facet := range schemaFacets {
+		if value, ok := declaration[facet]; ok && value != nil {
+			result[facet] = value
+		}
+	}
+
+	if format := getString(declaration, "format"); format != "" {
+		if jsonType, ok := numberFormats[format]; ok {
+			result["type"] = jsonType[0]
+			result["format"] = jsonType[1]
+		}
+	}
+
+	if example, ok := declaration["example"]; ok && example != nil {
+		result["example"] = example
+	}
+
+	if properties := getMap(declaration, "properties"); len(properties) > 0 {
+		c.convertProperties(properties, result)
+	}
+
+	if items, ok := declaration["items"]; ok && items != nil {
+		result["items"] = c.convertTypeDeclaration(items, typeString)
+	}
+
+	if additionalProperties, ok := declaration["additionalProperties"].(bool); ok && result["additionalProperties"] == nil {
+		result["additionalProperties"] = additionalProperties
+	}
+
+	return result
+}
+
+// convertProperties converts properties of the object type. Properties are required by default.
+// Names which end with ? are optional, and names which are enclosed in slashes are pattern properties.
+func (c *converter) convertProperties(properties map[string]any, result map[string]any) {
+	schemaProperties := map[string]any{}
+	required := []any{}
+
+	for _, name := range sortedKeys(properties) {
+		declaration := properties[name]
+
+		if len(name) > 1 && strings.HasPrefix(name, "/") && strings.HasSuffix(name, "/") {
+			result["additionalProperties"] = c.convertTypeDeclaration(declaration, typeString)
+
+			continue
+		}
+
+		isRequired := true
+
+		if propertyName, ok := strings.CutSuffix(name, "?"); ok {
+			name = propertyName
+			isRequired = false
+		}
+
+		if facets, ok := declaration.(map[string]any); ok {
+			if value, ok := facets["required"].(bool); ok {
+				isRequired = value
+			}
+		}
+
+		schemaProperties[name] = c.convertTypeDeclaration(declaration, typeString)
+
+		if isRequired {
+			required = append(required, name)
+		}
+	}
+
+	if result["type"] == nil {
+		result["type"] = typeObject
+	}
+
+	result["properties"] = schemaProperties
+
+	if len(required) > 0 {
+		result["required"] = required
+	}
+}
+
+// convertTypeExpression converts the type expression, e.g. string, Person[], (Cat | Dog)[] or string?.
+func (c *converter) convertTypeExpression(expression string) map[string]any {
+	expression = strings.TrimSpace(expression)
+
+	switch {
+	case strings.HasPrefix(expression, "{"):
+		// inline JSON schema
+		var result map[string]any
+		if err := json.Unmarshal([]byte(expression), &result); err != nil {
+			c.logger.Warn("failed to decode the JSON schema", slog.String("error", err.Error()))
+
+			return map[string]any{}
+		}
+
+		delete(result, "$schema")
+		delete(result, "id")
+		delete(result, "$id")
+
+		return result
+	case strings.HasPrefix(expression, "<"):
+		c.logger.Warn("XML schemas are not supported and converted to any type")
+
+		return map[string]any{}
+	}
+
+	parser := &typeExpressionParser{converter: c, input: expression}
+
+	result, err := parser.parseUnion()
+	if err == nil && parser.pos < len(parser.input) {
+		err = fmt.Errorf("unexpected character %q", parser.input[parser.pos])
+	}
+
+	if err != nil {
+		c.logger.Warn(
+			"invalid type expression is converted to any type",
+			slog.String("expression", expression),
+			slog.String("error", err.Error()),
+		)
+
+		return map[string]any{}
+	}
+
+	return result
+}
+
+// convertNamedType converts the built-in type or references the user-defined type.
+func (c *converter) convertNamedType(name string) map[string]any {
+	if scalar := c.convertBuiltinType(name); scalar != nil {
+		return scalar
+	}
+
+	if _, ok := c.document.Types[name]; ok {
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+
+	reason := "the type isn't declared"
+	if library, _, ok := strings.Cut(name, "."); ok && c.document.Uses[library] != nil {
+		reason = "libraries aren't resolved"
+	}
+
+	c.logger.Warn(
+		"unknown type is converted to any type",
+		slog.String("type", name),
+		slog.String("reason", reason),
+	)
+
+	return map[string]any{}
+}
+
+// convertBuiltinType returns the JSON schema of the built-in type. Returns nil if the type isn't built-in.
+func (c *converter) convertBuiltinType(name string) map[string]any {
+	switch name {
+	case typeAny:
+		return map[string]any{}
+	case typeString, "number", "integer", "boolean", typeObject, typeArray:
+		return map[string]any{"type": name}
+	case "date-only":
+		return map[string]any{"type": typeString, "format": "date"}
+	case "datetime":
+		return map[string]any{"type": typeString, "format": "date-time"}
+	case "datetime-only", "time-only":
+		return map[string]any{"type": typeString}
+	case "file":
+		return map[string]any{"type": typeString, "format": "binary"}
+	case typeNil:
+		return map[string]any{"nullable": true}
+	default:
+		return nil
+	}
+}
+
+func hasAnyFacet(declaration map[string]any, facets []string) bool {
+	for _, facet := range facets {
+		if value, ok := declaration[facet]; ok && value != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// typeExpressionParser parses type expressions with the grammar:
+//
+//	union   = postfix { "|" postfix }
+//	postfix = primary { "[]" } [ "?" ]
+//	primary = name | "(" union ")"
+type typeExpressionParser struct {
+	converter *converter
+	input     string
+	pos       int
+}
+
+func (p *typeExpressionParser) parseUnion() (map[string]any, error) {
+	var members []map[string]any
+
+	nullable := false
+
+	for {
+		member, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+
+		if isNilSchema(member) {
+			nullable = true
+		} else {
+			members = append(members, member)
+		}
+
+		if !p.skip("|") {
+			break
+		}
+	}
+
+	var result map[string]any
+
+	switch {
+	case len(members) == 0:
+		return map[string]any{"nullable": true}, nil
+	case len(members) == 1:
+		result = members[0]
+	default:
+		anyOf := make([]any, len(members))
+		for i, member := range members {
+			anyOf[i] = member
+		}
+
+		result = map[string]any{"anyOf": anyOf}
+	}
+
+	if nullable {
+		result = setNullable(result)
+	}
+
+	return result, nil
+}
+
+func (p *typeExpressionParser) parsePostfix() (map[string]any, error) {
+	result, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.skip("[]") {
+		result = map[string]any{
+			"type":  typeArray,
+			"items": result,
+		}
+	}
+
+	if p.skip("?") {
+		result = setNullable(result)
+	}
+
+	return result, nil
+}
+
+func (p *typeExpressionParser) parsePrimary() (map[string]any, error) {
+	p.skipSpaces()
+
+	if p.skip("(") {
+		result, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.skip(")") {
+			return nil, fmt.Errorf("expected )")
+		}
+
+		return result, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && isTypeNameChar(p.input[p.pos]) {
+		p.pos++
+	}
+
+	if start == p.pos {
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("expected type name")
+		}
+
+		return nil, fmt.Errorf("unexpected character %q", p.input[p.pos])
+	}
+
+	return p.converter.convertNamedType(p.input[start:p.pos]), nil
+}
+
+func (p *typeExpressionParser) skip(token string) bool {
+	p.skipSpaces()
+
+	if !strings.HasPrefix(p.input[p.pos:], token) {
+		return false
+	}
+
+	p.pos += len(token)
+	p.skipSpaces()
+
+	return true
+}
+
+func (p *typeExpressionParser) skipSpaces() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func isTypeNameChar(char byte) bool {
+	return char == '_' || char == '-' || char == '.' || (char >= 'a' && char <= 'z') ||
+		(char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+}
+
+func isNilSchema(schema map[string]any) bool {
+	return len(schema) == 1 && schema["nullable"] == true
+}
+
+// setNullable marks the schema nullable. References are wrapped with allOf
+// because siblings of $ref are ignored in OpenAPI 3.0.
+func setNullable(schema map[string]any) map[string]any {
+	if _, ok := schema["$ref"]; ok {
+		return map[string]any{
+			"allOf":    []any{schema},
+			"nullable": true,
+		}
+	}
+
+	schema["nullable"] = true
+
+	return schema
+}
@@ -0,0 +1,66 @@
+package raml
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"go.yaml.in/yaml/v4"
+)
+
+// RAMLToNDCSchema converts a RAML 1.0 API definition to NDC HTTP schema.
+// Resource types and traits are merged into resources and methods, and the definition is converted to
+// an OpenAPI 3 document which is built with the OpenAPI converter.
+func RAMLToNDCSchema(input []byte, options openapi.ConvertOptions) (*rest.NDCHttpSchema, []error) {
+	document, err := ParseRAML(input)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	oasDocument, err := newConverter(document, options.Logger).Build()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	rawDocument, err := json.Marshal(oasDocument)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return openapi.OpenAPIv3ToNDCSchema(rawDocument, options)
+}
+
+// ParseRAML parses the RAML 1.0 API definition. The JSON representation of the definition is also accepted.
+// Fragments, e.g. libraries and overlays, aren't supported and !include tags are not resolved.
+func ParseRAML(input []byte) (*Document, error) {
+	input = bytes.TrimPrefix(input, []byte("\xef\xbb\xbf"))
+
+	if header, ok := bytes.CutPrefix(bytes.TrimSpace(input), []byte(ramlHeader)); ok {
+		firstLine, _, _ := bytes.Cut(header, []byte("\n"))
+		fields := strings.Fields(string(firstLine))
+
+		if len(fields) == 0 || fields[0] != ramlVersion {
+			return nil, fmt.Errorf("unsupported RAML version, expected %s", ramlVersion)
+		}
+
+		if len(fields) > 1 {
+			return nil, fmt.Errorf("unsupported RAML fragment %s, expected the root API definition", fields[1])
+		}
+	}
+
+	var rawDocument any
+	if err := yaml.Unmarshal(input, &rawDocument); err != nil {
+		return nil, err
+	}
+
+	root, ok := normalizeNode(rawDocument).(map[string]any)
+	if !ok {
+		return nil, errors.New("the RAML document must be a map")
+	}
+
+	return newDocument(root), nil
+}
@@ -0,0 +1,150 @@
+package raml
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/internal/testutil"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	"gotest.tools/v3/assert"
+)
+
+func TestRAMLToNDCSchema(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Source   string
+		Expected string
+		Schema   string
+		Options  openapi.ConvertOptions
+	}{
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/raml/testdata/library/source.raml -o ./ndc-http-schema/raml/testdata/library/expected.json --spec raml --env-prefix LIBRARY
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/raml/testdata/library/source.raml -o ./ndc-http-schema/raml/testdata/library/schema.json --pure --spec raml --env-prefix LIBRARY
+		{
+			Name:     "library",
+			Source:   "testdata/library/source.raml",
+			Expected: "testdata/library/expected.json",
+			Schema:   "testdata/library/schema.json",
+			Options: openapi.ConvertOptions{
+				EnvPrefix: "LIBRARY",
+			},
+		},
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/raml/testdata/music/source.raml -o ./ndc-http-schema/raml/testdata/music/expected.json --spec raml --prefix music --trim-prefix /api/music --method-alias post=create --method-alias put=update
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/raml/testdata/music/source.raml -o ./ndc-http-schema/raml/testdata/music/schema.json --pure --spec raml --prefix music --trim-prefix /api/music --method-alias post=create --method-alias put=update
+		{
+			Name:     "music",
+			Source:   "testdata/music/source.raml",
+			Expected: "testdata/music/expected.json",
+			Schema:   "testdata/music/schema.json",
+			Options: openapi.ConvertOptions{
+				Prefix:     "music",
+				TrimPrefix: "/api/music",
+				MethodAlias: map[string]string{
+					"post": "create",
+					"put":  "update",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sourceBytes, err := os.ReadFile(tc.Source)
+			assert.NilError(t, err)
+
+			output, errs := RAMLToNDCSchema(sourceBytes, tc.Options)
+			if output == nil {
+				t.Fatal(errors.Join(errs...))
+			}
+
+			testutil.AssertJSONFileEqual(t, tc.Expected, output)
+			testutil.AssertJSONFileEqual(t, tc.Schema, output.ToSchemaResponse())
+		})
+	}
+
+	t.Run("failure_empty", func(t *testing.T) {
+		_, errs := RAMLToNDCSchema([]byte("#%RAML 1.0\ntitle: Empty"), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "there is no API to be converted")
+	})
+
+	t.Run("failure_version", func(t *testing.T) {
+		_, errs := RAMLToNDCSchema([]byte("#%RAML 0.8\ntitle: Old\n/users:\n  get:"), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "unsupported RAML version")
+	})
+
+	t.Run("failure_fragment", func(t *testing.T) {
+		_, errs := RAMLToNDCSchema([]byte("#%RAML 1.0 Library\ntypes:\n  User: object"), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "unsupported RAML fragment Library")
+	})
+
+	t.Run("failure_trait", func(t *testing.T) {
+		_, errs := RAMLToNDCSchema([]byte("#%RAML 1.0\ntitle: Test\n/users:\n  get:\n    is: [ pageable ]"), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "the trait pageable does not exist")
+	})
+
+	t.Run("failure_resource_type", func(t *testing.T) {
+		_, errs := RAMLToNDCSchema([]byte("#%RAML 1.0\ntitle: Test\n/users:\n  type: collection\n  get:"), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "the resource type collection does not exist")
+	})
+}
+
+func TestConvertTypeExpression(t *testing.T) {
+	document := newDocument(map[string]any{
+		"types": map[string]any{
+			"Cat": "object",
+			"Dog": "object",
+		},
+	})
+	c := newConverter(document, nil)
+
+	testCases := []struct {
+		Expression string
+		Expected   map[string]any
+	}{
+		{
+			Expression: "string?",
+			Expected:   map[string]any{"type": "string", "nullable": true},
+		},
+		{
+			Expression: "Cat | nil",
+			Expected: map[string]any{
+				"allOf":    []any{map[string]any{"$ref": "#/components/schemas/Cat"}},
+				"nullable": true,
+			},
+		},
+		{
+			Expression: "(Cat | Dog)[]",
+			Expected: map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"anyOf": []any{
+						map[string]any{"$ref": "#/components/schemas/Cat"},
+						map[string]any{"$ref": "#/components/schemas/Dog"},
+					},
+				},
+			},
+		},
+		{
+			Expression: "Unknown",
+			Expected:   map[string]any{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Expression, func(t *testing.T) {
+			assert.DeepEqual(t, tc.Expected, c.convertTypeExpression(tc.Expression))
+		})
+	}
+}
+
+func TestSubstituteParams(t *testing.T) {
+	params := newResourceParams("/users/{userId}/books").with(map[string]string{
+		"item": "book_item",
+	})
+
+	assert.Equal(t, "/users/{userId}/books", substituteString("<<resourcePath>>", params))
+	assert.Equal(t, "book", substituteString("<<resourcePathName | !singularize>>", params))
+	assert.Equal(t, "BOOKS", substituteString("<<resourcePathName|!uppercase>>", params))
+	assert.Equal(t, "BookItem", substituteString("<< item | !uppercamelcase >>", params))
+	assert.Equal(t, "<<methodName>>", substituteString("<<methodName>>", params))
+}
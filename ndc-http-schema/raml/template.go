@@ -0,0 +1,307 @@
+package raml
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/utils"
+)
+
+// maximum depth of resource types which inherit other resource types.
+const maxTemplateDepth = 10
+
+var templateParamRegex = regexp.MustCompile(`<<\s*([A-Za-z0-9_-]+)((?:\s*\|\s*![A-Za-z]+)*)\s*>>`)
+
+// templateParams represents values of parameters of resource types and traits.
+// Reserved parameters are resourcePath, resourcePathName and methodName.
+type templateParams map[string]string
+
+func newResourceParams(resourcePath string) templateParams {
+	params := templateParams{
+		"resourcePath": resourcePath,
+	}
+
+	// the resource path name is the rightmost segment which doesn't contain URI parameters.
+	segments := strings.Split(strings.Trim(resourcePath, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] != "" && !strings.Contains(segments[i], "{") {
+			params["resourcePathName"] = segments[i]
+
+			break
+		}
+	}
+
+	return params
+}
+
+func (tp templateParams) with(values map[string]string) templateParams {
+	result := make(templateParams, len(tp)+len(values))
+
+	for key, value := range tp {
+		result[key] = value
+	}
+
+	for key, value := range values {
+		result[key] = value
+	}
+
+	return result
+}
+
+// resolveResourceType merges the resource type and its base types into the resource.
+// Properties of the resource take precedence. Optional methods of the resource type, e.g. post?,
+// are applied only if the resource declares them.
+func (c *converter) resolveResourceType(resource map[string]any, params templateParams) (map[string]any, error) {
+	result := cloneNode(resource).(map[string]any)
+
+	typeRef, ok := result["type"]
+	if !ok || typeRef == nil {
+		return result, nil
+	}
+
+	delete(result, "type")
+
+	resourceType, err := c.expandResourceType(typeRef, params, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range resourceType {
+		if method, ok := strings.CutSuffix(key, "?"); ok && isMethod(method) {
+			if existing, ok := result[method].(map[string]any); ok {
+				result[method] = mergeNode(existing, value)
+			} else if _, ok := result[method]; ok {
+				// methods without properties are null nodes.
+				result[method] = cloneNode(value)
+			}
+
+			continue
+		}
+
+		if existing, ok := result[key]; ok && existing != nil {
+			result[key] = mergeNode(existing, value)
+		} else {
+			result[key] = value
+		}
+	}
+
+	return result, nil
+}
+
+func (c *converter) expandResourceType(ref any, params templateParams, depth int) (map[string]any, error) {
+	if depth > maxTemplateDepth {
+		return nil, fmt.Errorf("the inheritance of resource types is too deep")
+	}
+
+	name, values := parseTemplateRef(ref)
+
+	declaration, ok := c.document.ResourceTypes[name].(map[string]any)
+	if !ok {
+		if _, exists := c.document.ResourceTypes[name]; !exists {
+			return nil, fmt.Errorf("the resource type %s does not exist", name)
+		}
+
+		declaration = map[string]any{}
+	}
+
+	result := substituteParams(declaration, params.with(values)).(map[string]any)
+	delete(result, "usage")
+
+	baseRef, ok := result["type"]
+	if !ok || baseRef == nil {
+		return result, nil
+	}
+
+	delete(result, "type")
+
+	base, err := c.expandResourceType(baseRef, params, depth+1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return mergeNode(result, base).(map[string]any), nil
+}
+
+// applyTraits merges traits of the method and the resource into the method.
+// Properties of the method take precedence over traits, and traits of the method take precedence over traits of the resource.
+func (c *converter) applyTraits(method map[string]any, resourceTraits []any, params templateParams) (map[string]any, error) {
+	refs := append(getList(method, "is"), resourceTraits...)
+	result := cloneNode(method).(map[string]any)
+	delete(result, "is")
+
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+
+		name, values := parseTemplateRef(ref)
+
+		declaration, ok := c.document.Traits[name]
+		if !ok {
+			return nil, fmt.Errorf("the trait %s does not exist", name)
+		}
+
+		trait, ok := substituteParams(declaration, params.with(values)).(map[string]any)
+		if !ok {
+			continue
+		}
+
+		delete(trait, "usage")
+		result = mergeNode(result, trait).(map[string]any)
+	}
+
+	return result, nil
+}
+
+// mergeNode merges the source node into the target node. Values of the target take precedence.
+// Maps are merged recursively and lists of trait references are combined.
+func mergeNode(target any, source any) any {
+	targetMap, isTargetMap := target.(map[string]any)
+	sourceMap, isSourceMap := source.(map[string]any)
+
+	if !isTargetMap || !isSourceMap {
+		if target == nil {
+			return cloneNode(source)
+		}
+
+		return target
+	}
+
+	for key, value := range sourceMap {
+		existing, ok := targetMap[key]
+
+		switch {
+		case !ok || existing == nil:
+			targetMap[key] = cloneNode(value)
+		case key == "is":
+			refs := getList(targetMap, key)
+			for _, ref := range getList(sourceMap, key) {
+				if !slices.ContainsFunc(refs, func(item any) bool {
+					return fmt.Sprint(item) == fmt.Sprint(ref)
+				}) {
+					refs = append(refs, ref)
+				}
+			}
+
+			targetMap[key] = refs
+		default:
+			targetMap[key] = mergeNode(existing, value)
+		}
+	}
+
+	return targetMap
+}
+
+// parseTemplateRef parses the reference to the resource type or trait,
+// which is the name or a map of the name and parameters, e.g. { searchable: { queryParamName: title } }.
+func parseTemplateRef(ref any) (string, map[string]string) {
+	node, ok := ref.(map[string]any)
+	if !ok || len(node) != 1 {
+		return fmt.Sprint(ref), nil
+	}
+
+	for name, rawValues := range node {
+		values := map[string]string{}
+
+		if params, ok := rawValues.(map[string]any); ok {
+			for key, value := range params {
+				values[key] = fmt.Sprint(value)
+			}
+		}
+
+		return name, values
+	}
+
+	return "", nil
+}
+
+// substituteParams replaces parameters in keys and values of the node, e.g. <<resourcePathName | !singularize>>.
+// Unknown parameters are kept to be replaced later, e.g. methodName of resource types.
+func substituteParams(value any, params templateParams) any {
+	switch node := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(node))
+		for key, item := range node {
+			result[substituteString(key, params)] = substituteParams(item, params)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(node))
+		for i, item := range node {
+			result[i] = substituteParams(item, params)
+		}
+
+		return result
+	case string:
+		return substituteString(node, params)
+	default:
+		return value
+	}
+}
+
+func substituteString(input string, params templateParams) string {
+	if !strings.Contains(input, "<<") {
+		return input
+	}
+
+	return templateParamRegex.ReplaceAllStringFunc(input, func(match string) string {
+		groups := templateParamRegex.FindStringSubmatch(match)
+
+		value, ok := params[groups[1]]
+		if !ok {
+			return match
+		}
+
+		for _, fn := range strings.Split(groups[2], "|") {
+			value = applyTransformFunction(value, strings.TrimPrefix(strings.TrimSpace(fn), "!"))
+		}
+
+		return value
+	})
+}
+
+// applyTransformFunction applies the function to the parameter value.
+// Inflections are naive English rules.
+func applyTransformFunction(value string, fn string) string {
+	switch fn {
+	case "singularize":
+		switch {
+		case strings.HasSuffix(value, "ies"):
+			return strings.TrimSuffix(value, "ies") + "y"
+		case strings.HasSuffix(value, "ses"), strings.HasSuffix(value, "xes"):
+			return strings.TrimSuffix(value, "es")
+		case strings.HasSuffix(value, "s") && !strings.HasSuffix(value, "ss"):
+			return strings.TrimSuffix(value, "s")
+		}
+	case "pluralize":
+		switch {
+		case strings.HasSuffix(value, "y") && !strings.ContainsAny(value[max(len(value)-2, 0):len(value)-1], "aeiou"):
+			return strings.TrimSuffix(value, "y") + "ies"
+		case strings.HasSuffix(value, "s"), strings.HasSuffix(value, "x"):
+			return value + "es"
+		default:
+			return value + "s"
+		}
+	case "uppercase":
+		return strings.ToUpper(value)
+	case "lowercase":
+		return strings.ToLower(value)
+	case "lowercamelcase":
+		return utils.ToCamelCase(value)
+	case "uppercamelcase":
+		return utils.ToPascalCase(value)
+	case "lowerunderscorecase":
+		return utils.ToSnakeCase(value)
+	case "upperunderscorecase":
+		return utils.ToConstantCase(value)
+	case "lowerhyphencase":
+		return strings.ReplaceAll(utils.ToSnakeCase(value), "_", "-")
+	case "upperhyphencase":
+		return strings.ReplaceAll(utils.ToConstantCase(value), "_", "-")
+	}
+
+	return value
+}
@@ -0,0 +1,196 @@
+package raml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	ramlHeader         = "#%RAML"
+	ramlVersion        = "1.0"
+	defaultContentType = "application/json"
+)
+
+// methods of resources in the order of conversion.
+var httpMethods = []string{"get", "put", "post", "delete", "patch", "options", "head"}
+
+// Document represents the root of a RAML 1.0 API definition.
+// Resources, resource types and traits are kept as raw nodes because they are resolved by merging.
+type Document struct {
+	Title             string
+	Description       string
+	Version           string
+	BaseURI           string
+	BaseURIParameters map[string]any
+	Protocols         []string
+	MediaTypes        []string
+	SecuredBy         []any
+	Types             map[string]any
+	Traits            map[string]any
+	ResourceTypes     map[string]any
+	SecuritySchemes   map[string]any
+	Uses              map[string]any
+	// resources indexed by relative URIs, e.g. /users.
+	Resources map[string]any
+}
+
+// newDocument reads properties of the root node.
+func newDocument(root map[string]any) *Document {
+	document := &Document{
+		Title:             getString(root, "title"),
+		Description:       getString(root, "description"),
+		Version:           getString(root, "version"),
+		BaseURI:           getString(root, "baseUri"),
+		BaseURIParameters: getMap(root, "baseUriParameters"),
+		Protocols:         getStrings(root, "protocols"),
+		MediaTypes:        getStrings(root, "mediaType"),
+		SecuredBy:         getList(root, "securedBy"),
+		Types:             map[string]any{},
+		Traits:            getMap(root, "traits"),
+		ResourceTypes:     getMap(root, "resourceTypes"),
+		SecuritySchemes:   getMap(root, "securitySchemes"),
+		Uses:              getMap(root, "uses"),
+		Resources:         getResources(root),
+	}
+
+	// schemas is the deprecated alias of types.
+	for _, key := range []string{"schemas", "types"} {
+		for name, value := range getMap(root, key) {
+			document.Types[name] = value
+		}
+	}
+
+	if len(document.MediaTypes) == 0 {
+		document.MediaTypes = []string{defaultContentType}
+	}
+
+	return document
+}
+
+// getResources returns nested resources of the node whose keys are relative URIs.
+func getResources(node map[string]any) map[string]any {
+	results := map[string]any{}
+
+	for key, value := range node {
+		if strings.HasPrefix(key, "/") {
+			results[key] = value
+		}
+	}
+
+	return results
+}
+
+// normalizeNode converts maps with non-string keys, e.g. status codes of responses, to string maps.
+func normalizeNode(value any) any {
+	switch node := value.(type) {
+	case map[string]any:
+		for key, item := range node {
+			node[key] = normalizeNode(item)
+		}
+
+		return node
+	case map[any]any:
+		result := make(map[string]any, len(node))
+		for key, item := range node {
+			result[fmt.Sprint(key)] = normalizeNode(item)
+		}
+
+		return result
+	case []any:
+		for i, item := range node {
+			node[i] = normalizeNode(item)
+		}
+
+		return node
+	default:
+		return value
+	}
+}
+
+// cloneNode deep copies the node.
+func cloneNode(value any) any {
+	switch node := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(node))
+		for key, item := range node {
+			result[key] = cloneNode(item)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(node))
+		for i, item := range node {
+			result[i] = cloneNode(item)
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+func getString(node map[string]any, key string) string {
+	switch value := node[key].(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func getMap(node map[string]any, key string) map[string]any {
+	value, ok := node[key].(map[string]any)
+	if !ok {
+		return map[string]any{}
+	}
+
+	return value
+}
+
+// getList returns the value as a list. A single value is converted to a list of one item.
+func getList(node map[string]any, key string) []any {
+	switch value := node[key].(type) {
+	case []any:
+		return value
+	case nil:
+		if _, ok := node[key]; ok {
+			return []any{nil}
+		}
+
+		return nil
+	default:
+		return []any{value}
+	}
+}
+
+func getStrings(node map[string]any, key string) []string {
+	var results []string
+
+	for _, item := range getList(node, key) {
+		if value, ok := item.(string); ok && value != "" {
+			results = append(results, value)
+		}
+	}
+
+	return results
+}
+
+// sortedKeys returns keys of the node in order for the deterministic output.
+func sortedKeys(node map[string]any) []string {
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// isMethod checks if the key of the resource node is a method.
+func isMethod(key string) bool {
+	return slices.Contains(httpMethods, key)
+}
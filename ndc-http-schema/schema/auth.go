@@ -4,16 +4,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/hasura/goenvconf"
 	"github.com/invopop/jsonschema"
+	"github.com/theory/jsonpath"
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 )
 
 const (
 	HTTPAuthSchemeBearer = "bearer"
 	AuthorizationHeader  = "Authorization"
+	CookieHeader         = "Cookie"
 )
 
 var errSecuritySchemerRequired = errors.New("SecuritySchemer is required")
@@ -29,6 +33,9 @@ const (
 	OAuth2Scheme        SecuritySchemeType = "oauth2"
 	OpenIDConnectScheme SecuritySchemeType = "openIdConnect"
 	MutualTLSScheme     SecuritySchemeType = "mutualTLS"
+	TokenEndpointScheme SecuritySchemeType = "tokenEndpoint"
+	DigestAuthScheme    SecuritySchemeType = "digest"
+	NTLMAuthScheme      SecuritySchemeType = "ntlm"
 )
 
 var securityScheme_enums = []SecuritySchemeType{
@@ -39,6 +46,9 @@ var securityScheme_enums = []SecuritySchemeType{
 	OAuth2Scheme,
 	OpenIDConnectScheme,
 	MutualTLSScheme,
+	TokenEndpointScheme,
+	DigestAuthScheme,
+	NTLMAuthScheme,
 }
 
 // JSONSchema is used to generate a custom jsonschema.
@@ -149,12 +159,15 @@ func (j SecurityScheme) JSONSchema() *jsonschema.Schema {
 	envStringRef := &jsonschema.Schema{
 		Ref: "#/$defs/EnvString",
 	}
+	secretStringRef := &jsonschema.Schema{
+		Ref: "#/$defs/SecretString",
+	}
 	apiKeySchema := orderedmap.New[string, *jsonschema.Schema]()
 	apiKeySchema.Set("type", &jsonschema.Schema{
 		Type: "string",
 		Enum: []any{APIKeyScheme},
 	})
-	apiKeySchema.Set("value", envStringRef)
+	apiKeySchema.Set("value", secretStringRef)
 	apiKeySchema.Set("in", (APIKeyLocation("")).JSONSchema())
 	apiKeySchema.Set("name", &jsonschema.Schema{
 		Type: "string",
@@ -165,7 +178,7 @@ func (j SecurityScheme) JSONSchema() *jsonschema.Schema {
 		Type: "string",
 		Enum: []any{HTTPAuthScheme},
 	})
-	httpAuthSchema.Set("value", envStringRef)
+	httpAuthSchema.Set("value", secretStringRef)
 	httpAuthSchema.Set("header", &jsonschema.Schema{
 		Type: "string",
 	})
@@ -178,8 +191,8 @@ func (j SecurityScheme) JSONSchema() *jsonschema.Schema {
 		Type: "string",
 		Enum: []any{BasicAuthScheme},
 	})
-	basicAuthSchema.Set("username", envStringRef)
-	basicAuthSchema.Set("password", envStringRef)
+	basicAuthSchema.Set("username", secretStringRef)
+	basicAuthSchema.Set("password", secretStringRef)
 	httpAuthSchema.Set("header", &jsonschema.Schema{
 		Description: "Request contains a header field in the form of Authorization: Basic <credentials>",
 		OneOf: []*jsonschema.Schema{
@@ -209,6 +222,55 @@ func (j SecurityScheme) JSONSchema() *jsonschema.Schema {
 		Enum: []any{MutualTLSScheme},
 	})
 
+	tokenEndpointSchema := orderedmap.New[string, *jsonschema.Schema]()
+	tokenEndpointSchema.Set("type", &jsonschema.Schema{
+		Type: "string",
+		Enum: []any{TokenEndpointScheme},
+	})
+	tokenEndpointSchema.Set("request", &jsonschema.Schema{
+		Ref: "#/$defs/TokenEndpointRequest",
+	})
+	tokenEndpointSchema.Set("tokenPath", &jsonschema.Schema{
+		Description: "JSONPath of the access token in the login response body",
+		Type:        "string",
+	})
+	tokenEndpointSchema.Set("expiresInPath", &jsonschema.Schema{
+		Description: "JSONPath of the token expiry in the login response body. The value is either the number of seconds until the token expires or an RFC 3339 timestamp",
+		Type:        "string",
+	})
+	tokenEndpointSchema.Set("in", &jsonschema.Schema{
+		Type: "string",
+		Enum: []any{APIKeyInHeader, APIKeyInQuery},
+	})
+	tokenEndpointSchema.Set("name", &jsonschema.Schema{
+		Description: "Name of the header or query parameter that the token is injected into",
+		Type:        "string",
+	})
+	tokenEndpointSchema.Set("scheme", &jsonschema.Schema{
+		Description: "Optional scheme prefix of the token value, e.g. Bearer",
+		Type:        "string",
+	})
+
+	digestAuthSchema := orderedmap.New[string, *jsonschema.Schema]()
+	digestAuthSchema.Set("type", &jsonschema.Schema{
+		Type: "string",
+		Enum: []any{DigestAuthScheme},
+	})
+	digestAuthSchema.Set("username", secretStringRef)
+	digestAuthSchema.Set("password", secretStringRef)
+
+	ntlmAuthSchema := orderedmap.New[string, *jsonschema.Schema]()
+	ntlmAuthSchema.Set("type", &jsonschema.Schema{
+		Type: "string",
+		Enum: []any{NTLMAuthScheme},
+	})
+	ntlmAuthSchema.Set("domain", &jsonschema.Schema{
+		Description: "Optional domain of the user. The username can also contain the domain in the DOMAIN\\user format",
+		Ref:         envStringRef.Ref,
+	})
+	ntlmAuthSchema.Set("username", secretStringRef)
+	ntlmAuthSchema.Set("password", secretStringRef)
+
 	return &jsonschema.Schema{
 		OneOf: []*jsonschema.Schema{
 			{
@@ -242,6 +304,21 @@ func (j SecurityScheme) JSONSchema() *jsonschema.Schema {
 				Properties: mutualTLSSchema,
 				Required:   []string{"type"},
 			},
+			{
+				Type:       "object",
+				Properties: tokenEndpointSchema,
+				Required:   []string{"type", "request", "tokenPath", "in", "name"},
+			},
+			{
+				Type:       "object",
+				Properties: digestAuthSchema,
+				Required:   []string{"type", "username", "password"},
+			},
+			{
+				Type:       "object",
+				Properties: ntlmAuthSchema,
+				Required:   []string{"type", "username", "password"},
+			},
 		},
 	}
 }
@@ -306,6 +383,30 @@ func (j *SecurityScheme) UnmarshalJSON(b []byte) error {
 		j.SecuritySchemer = &MutualTLSAuthConfig{
 			Type: rawScheme.Type,
 		}
+	case TokenEndpointScheme:
+		var config TokenEndpointAuthConfig
+		if err := json.Unmarshal(b, &config); err != nil {
+			return err
+		}
+
+		_ = config.Validate()
+		j.SecuritySchemer = &config
+	case DigestAuthScheme:
+		var config DigestAuthConfig
+		if err := json.Unmarshal(b, &config); err != nil {
+			return err
+		}
+
+		_ = config.Validate()
+		j.SecuritySchemer = &config
+	case NTLMAuthScheme:
+		var config NTLMAuthConfig
+		if err := json.Unmarshal(b, &config); err != nil {
+			return err
+		}
+
+		_ = config.Validate()
+		j.SecuritySchemer = &config
 	}
 
 	return nil
@@ -329,10 +430,10 @@ func (ss *SecurityScheme) Validate() error {
 //
 // [apiKey authentication]: https://swagger.io/docs/specification/authentication/api-keys/
 type APIKeyAuthConfig struct {
-	Type  SecuritySchemeType  `json:"type"  mapstructure:"type"  yaml:"type"`
-	In    APIKeyLocation      `json:"in"    mapstructure:"in"    yaml:"in"`
-	Name  string              `json:"name"  mapstructure:"name"  yaml:"name"`
-	Value goenvconf.EnvString `json:"value" mapstructure:"value" yaml:"value"`
+	Type  SecuritySchemeType `json:"type"  mapstructure:"type"  yaml:"type"`
+	In    APIKeyLocation     `json:"in"    mapstructure:"in"    yaml:"in"`
+	Name  string             `json:"name"  mapstructure:"name"  yaml:"name"`
+	Value SecretString       `json:"value" mapstructure:"value" yaml:"value"`
 }
 
 var _ SecuritySchemer = (*APIKeyAuthConfig)(nil)
@@ -347,7 +448,7 @@ func NewAPIKeyAuthConfig(
 		Type:  APIKeyScheme,
 		Name:  name,
 		In:    in,
-		Value: value,
+		Value: NewSecretString(value),
 	}
 }
 
@@ -390,10 +491,10 @@ func (ss APIKeyAuthConfig) GetType() SecuritySchemeType {
 //
 // [bearer]: https://swagger.io/docs/specification/authentication/bearer-authentication
 type HTTPAuthConfig struct {
-	Type   SecuritySchemeType  `json:"type"   mapstructure:"type"   yaml:"type"`
-	Header string              `json:"header" mapstructure:"header" yaml:"header"`
-	Scheme string              `json:"scheme" mapstructure:"scheme" yaml:"scheme"`
-	Value  goenvconf.EnvString `json:"value"  mapstructure:"value"  yaml:"value"`
+	Type   SecuritySchemeType `json:"type"   mapstructure:"type"   yaml:"type"`
+	Header string             `json:"header" mapstructure:"header" yaml:"header"`
+	Scheme string             `json:"scheme" mapstructure:"scheme" yaml:"scheme"`
+	Value  SecretString       `json:"value"  mapstructure:"value"  yaml:"value"`
 }
 
 var _ SecuritySchemer = (*HTTPAuthConfig)(nil)
@@ -404,7 +505,7 @@ func NewHTTPAuthConfig(scheme string, header string, value goenvconf.EnvString)
 		Type:   HTTPAuthScheme,
 		Header: header,
 		Scheme: scheme,
-		Value:  value,
+		Value:  NewSecretString(value),
 	}
 }
 
@@ -426,18 +527,18 @@ func (ss HTTPAuthConfig) GetType() SecuritySchemeType {
 //
 // [basic]: https://swagger.io/docs/specification/authentication/basic-authentication
 type BasicAuthConfig struct {
-	Type     SecuritySchemeType  `json:"type"     mapstructure:"type"     yaml:"type"`
-	Header   string              `json:"header"   mapstructure:"header"   yaml:"header"`
-	Username goenvconf.EnvString `json:"username" mapstructure:"username" yaml:"username"`
-	Password goenvconf.EnvString `json:"password" mapstructure:"password" yaml:"password"`
+	Type     SecuritySchemeType `json:"type"     mapstructure:"type"     yaml:"type"`
+	Header   string             `json:"header"   mapstructure:"header"   yaml:"header"`
+	Username SecretString       `json:"username" mapstructure:"username" yaml:"username"`
+	Password SecretString       `json:"password" mapstructure:"password" yaml:"password"`
 }
 
 // NewBasicAuthConfig creates a new BasicAuthConfig instance.
 func NewBasicAuthConfig(username, password goenvconf.EnvString) *BasicAuthConfig {
 	return &BasicAuthConfig{
 		Type:     BasicAuthScheme,
-		Username: username,
-		Password: password,
+		Username: NewSecretString(username),
+		Password: NewSecretString(password),
 	}
 }
 
@@ -451,6 +552,66 @@ func (ss BasicAuthConfig) GetType() SecuritySchemeType {
 	return ss.Type
 }
 
+// DigestAuthConfig contains configurations for the [HTTP Digest] authentication.
+// The client answers the 401 challenge of the server and sends the request again with the digest credential.
+//
+// [HTTP Digest]: https://datatracker.ietf.org/doc/html/rfc7616
+type DigestAuthConfig struct {
+	Type     SecuritySchemeType `json:"type"     mapstructure:"type"     yaml:"type"`
+	Username SecretString       `json:"username" mapstructure:"username" yaml:"username"`
+	Password SecretString       `json:"password" mapstructure:"password" yaml:"password"`
+}
+
+// NewDigestAuthConfig creates a new DigestAuthConfig instance.
+func NewDigestAuthConfig(username, password goenvconf.EnvString) *DigestAuthConfig {
+	return &DigestAuthConfig{
+		Type:     DigestAuthScheme,
+		Username: NewSecretString(username),
+		Password: NewSecretString(password),
+	}
+}
+
+// Validate if the current instance is valid.
+func (ss *DigestAuthConfig) Validate() error {
+	return nil
+}
+
+// GetType get the type of security scheme.
+func (ss DigestAuthConfig) GetType() SecuritySchemeType {
+	return ss.Type
+}
+
+// NTLMAuthConfig contains configurations for the [NTLM] authentication.
+// NTLM authenticates the connection with a challenge-response handshake instead of every request.
+//
+// [NTLM]: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-nlmp
+type NTLMAuthConfig struct {
+	Type SecuritySchemeType `json:"type" mapstructure:"type" yaml:"type"`
+	// Optional domain of the user. The username can also contain the domain in the DOMAIN\user format.
+	Domain   *goenvconf.EnvString `json:"domain,omitempty" mapstructure:"domain"   yaml:"domain,omitempty"`
+	Username SecretString         `json:"username"         mapstructure:"username" yaml:"username"`
+	Password SecretString         `json:"password"         mapstructure:"password" yaml:"password"`
+}
+
+// NewNTLMAuthConfig creates a new NTLMAuthConfig instance.
+func NewNTLMAuthConfig(username, password goenvconf.EnvString) *NTLMAuthConfig {
+	return &NTLMAuthConfig{
+		Type:     NTLMAuthScheme,
+		Username: NewSecretString(username),
+		Password: NewSecretString(password),
+	}
+}
+
+// Validate if the current instance is valid.
+func (ss *NTLMAuthConfig) Validate() error {
+	return nil
+}
+
+// GetType get the type of security scheme.
+func (ss NTLMAuthConfig) GetType() SecuritySchemeType {
+	return ss.Type
+}
+
 // OAuthFlowType represents the OAuth flow type enum.
 type OAuthFlowType string
 
@@ -507,9 +668,12 @@ type OAuthFlow struct {
 	TokenURL         *goenvconf.EnvString           `json:"tokenUrl,omitempty"         mapstructure:"tokenUrl"         yaml:"tokenUrl,omitempty"`
 	RefreshURL       string                         `json:"refreshUrl,omitempty"       mapstructure:"refreshUrl"       yaml:"refreshUrl,omitempty"`
 	Scopes           map[string]string              `json:"scopes,omitempty"           mapstructure:"scopes"           yaml:"scopes,omitempty"`
-	ClientID         *goenvconf.EnvString           `json:"clientId,omitempty"         mapstructure:"clientId"         yaml:"clientId,omitempty"`
-	ClientSecret     *goenvconf.EnvString           `json:"clientSecret,omitempty"     mapstructure:"clientSecret"     yaml:"clientSecret,omitempty"`
+	ClientID         *SecretString                  `json:"clientId,omitempty"         mapstructure:"clientId"         yaml:"clientId,omitempty"`
+	ClientSecret     *SecretString                  `json:"clientSecret,omitempty"     mapstructure:"clientSecret"     yaml:"clientSecret,omitempty"`
 	EndpointParams   map[string]goenvconf.EnvString `json:"endpointParams,omitempty"   mapstructure:"endpointParams"   yaml:"endpointParams,omitempty"`
+	// Request a token with all scopes of the flow if the operation doesn't require any scope.
+	// By default, tokens are requested with the scopes that each operation requires only.
+	RequestAllScopes bool `json:"requestAllScopes,omitempty" mapstructure:"requestAllScopes" yaml:"requestAllScopes,omitempty"`
 }
 
 // Validate if the current instance is valid.
@@ -721,6 +885,159 @@ func (ss MutualTLSAuthConfig) Validate() error {
 	return nil
 }
 
+// TokenEndpointRequest represents the login request to fetch an access token from a custom token endpoint.
+type TokenEndpointRequest struct {
+	// URL of the login endpoint. A relative path is joined with the base server URL.
+	URL goenvconf.EnvString `json:"url" mapstructure:"url" yaml:"url"`
+	// HTTP method of the login request. Defaults to POST.
+	Method string `json:"method,omitempty" mapstructure:"method" yaml:"method,omitempty" jsonschema:"enum=get,enum=post,enum=put,enum=patch"`
+	// Content type of the request body, is one of application/json or application/x-www-form-urlencoded. Defaults to application/json.
+	ContentType string `json:"contentType,omitempty" mapstructure:"contentType" yaml:"contentType,omitempty" jsonschema:"enum=application/json,enum=application/x-www-form-urlencoded"`
+	// Additional headers of the login request.
+	Headers map[string]goenvconf.EnvString `json:"headers,omitempty" mapstructure:"headers" yaml:"headers,omitempty"`
+	// Fields of the request body. Values can be loaded from environment variables.
+	Body map[string]SecretString `json:"body,omitempty" mapstructure:"body" yaml:"body,omitempty"`
+}
+
+// GetMethod returns the HTTP method of the login request.
+func (ter TokenEndpointRequest) GetMethod() string {
+	if ter.Method == "" {
+		return http.MethodPost
+	}
+
+	return strings.ToUpper(ter.Method)
+}
+
+// GetContentType returns the content type of the login request body.
+func (ter TokenEndpointRequest) GetContentType() string {
+	if ter.ContentType == "" {
+		return ContentTypeJSON
+	}
+
+	return ter.ContentType
+}
+
+// Validate if the current instance is valid.
+func (ter TokenEndpointRequest) Validate() error {
+	if ter.URL.Value == nil && ter.URL.Variable == nil {
+		return errors.New("url: value and env are empty")
+	}
+
+	switch ter.GetContentType() {
+	case ContentTypeJSON, ContentTypeFormURLEncoded:
+	default:
+		return fmt.Errorf(
+			"unsupported contentType %s. Expected %s or %s",
+			ter.ContentType,
+			ContentTypeJSON,
+			ContentTypeFormURLEncoded,
+		)
+	}
+
+	return nil
+}
+
+// TokenEndpointAuthConfig contains configurations for the authentication via a custom login endpoint,
+// e.g. POST /login with a JSON body that returns {"token": "..."}.
+// The token is extracted from the response body and injected into requests as a header or query parameter.
+type TokenEndpointAuthConfig struct {
+	Type SecuritySchemeType `json:"type" mapstructure:"type" yaml:"type"`
+	// The login request to fetch the access token.
+	Request TokenEndpointRequest `json:"request" mapstructure:"request" yaml:"request"`
+	// JSONPath of the access token in the login response body, e.g. $.token
+	TokenPath string `json:"tokenPath" mapstructure:"tokenPath" yaml:"tokenPath"`
+	// JSONPath of the token expiry in the login response body.
+	// The value is either the number of seconds until the token expires or an RFC 3339 timestamp.
+	ExpiresInPath string `json:"expiresInPath,omitempty" mapstructure:"expiresInPath" yaml:"expiresInPath,omitempty"`
+	// Location where the token is injected, is one of header or query.
+	In APIKeyLocation `json:"in" mapstructure:"in" yaml:"in"`
+	// Name of the header or query parameter that the token is injected into.
+	Name string `json:"name" mapstructure:"name" yaml:"name"`
+	// Optional scheme prefix of the token value, e.g. Bearer.
+	Scheme string `json:"scheme,omitempty" mapstructure:"scheme" yaml:"scheme,omitempty"`
+}
+
+var _ SecuritySchemer = (*TokenEndpointAuthConfig)(nil)
+
+// NewTokenEndpointAuthConfig creates a new TokenEndpointAuthConfig instance.
+func NewTokenEndpointAuthConfig(
+	request TokenEndpointRequest,
+	tokenPath string,
+	in APIKeyLocation,
+	name string,
+) *TokenEndpointAuthConfig {
+	return &TokenEndpointAuthConfig{
+		Type:      TokenEndpointScheme,
+		Request:   request,
+		TokenPath: tokenPath,
+		In:        in,
+		Name:      name,
+	}
+}
+
+// GetType get the type of security scheme.
+func (ss TokenEndpointAuthConfig) GetType() SecuritySchemeType {
+	return ss.Type
+}
+
+// Validate if the current instance is valid.
+func (ss TokenEndpointAuthConfig) Validate() error {
+	if err := ss.Request.Validate(); err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+
+	if ss.Name == "" {
+		return errors.New("name is required for tokenEndpoint security")
+	}
+
+	if ss.In != APIKeyInHeader && ss.In != APIKeyInQuery {
+		return fmt.Errorf(
+			"invalid location of tokenEndpoint security. Expected %s or %s, got <%s>",
+			APIKeyInHeader,
+			APIKeyInQuery,
+			ss.In,
+		)
+	}
+
+	if _, err := ss.GetTokenPath(); err != nil {
+		return err
+	}
+
+	if _, err := ss.GetExpiresInPath(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetTokenPath parses the JSONPath of the access token.
+func (ss TokenEndpointAuthConfig) GetTokenPath() (*jsonpath.Path, error) {
+	if ss.TokenPath == "" {
+		return nil, errors.New("tokenPath is required for tokenEndpoint security")
+	}
+
+	result, err := parseJSONPath(ss.TokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("tokenPath: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetExpiresInPath parses the JSONPath of the token expiry if exists.
+func (ss TokenEndpointAuthConfig) GetExpiresInPath() (*jsonpath.Path, error) {
+	if ss.ExpiresInPath == "" {
+		return nil, nil
+	}
+
+	result, err := parseJSONPath(ss.ExpiresInPath)
+	if err != nil {
+		return nil, fmt.Errorf("expiresInPath: %w", err)
+	}
+
+	return result, nil
+}
+
 // AuthSecurity wraps the raw security requirement with helpers.
 type AuthSecurity map[string][]string
 
@@ -742,6 +1059,18 @@ func (as AuthSecurity) Name() string {
 	return ""
 }
 
+// Names returns sorted names of security schemes that must all be satisfied in the security requirement.
+func (as AuthSecurity) Names() []string {
+	names := make([]string, 0, len(as))
+	for name := range as {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
 // Scopes returns scopes of security requirement.
 func (as AuthSecurity) Scopes() []string {
 	if len(as) > 0 {
@@ -19,6 +19,14 @@ const (
 	OAS3Spec      SchemaSpecType = "oas3"
 	OAS2Spec      SchemaSpecType = "oas2"
 	NDCSpec       SchemaSpecType = "ndc"
+	PostmanSpec   SchemaSpecType = "postman"
+	HARSpec       SchemaSpecType = "har"
+	GraphQLSpec   SchemaSpecType = "graphql"
+	OpenRPCSpec   SchemaSpecType = "openrpc"
+	WSDLSpec      SchemaSpecType = "wsdl"
+	ODataSpec     SchemaSpecType = "odata"
+	ProtoSpec     SchemaSpecType = "proto"
+	RAMLSpec      SchemaSpecType = "raml"
 )
 
 var schemaSpecType_enums = []SchemaSpecType{
@@ -27,6 +35,14 @@ var schemaSpecType_enums = []SchemaSpecType{
 	OpenAPIv3Spec,
 	OpenAPIv2Spec,
 	NDCSpec,
+	PostmanSpec,
+	HARSpec,
+	GraphQLSpec,
+	OpenRPCSpec,
+	WSDLSpec,
+	ODataSpec,
+	ProtoSpec,
+	RAMLSpec,
 }
 
 // JSONSchema is used to generate a custom jsonschema.
@@ -205,6 +221,7 @@ const (
 	ScalarFloat32     ScalarName = "Float32"
 	ScalarFloat64     ScalarName = "Float64"
 	ScalarBigDecimal  ScalarName = "BigDecimal"
+	ScalarBigInteger  ScalarName = "BigInteger"
 	ScalarUUID        ScalarName = "UUID"
 	ScalarDate        ScalarName = "Date"
 	ScalarTimestampTZ ScalarName = "TimestampTZ"
@@ -226,6 +243,7 @@ var scalarName_enums = []ScalarName{
 	ScalarFloat32,
 	ScalarFloat64,
 	ScalarBigDecimal,
+	ScalarBigInteger,
 	ScalarUUID,
 	ScalarDate,
 	ScalarTimestampTZ,
@@ -250,6 +268,8 @@ const (
 	ContentTypeJSON              = "application/json"
 	ContentTypeNdJSON            = "application/x-ndjson"
 	ContentTypeXML               = "application/xml"
+	ContentTypeTextXML           = "text/xml"
+	ContentTypeSOAPXML           = "application/soap+xml"
 	ContentTypeFormURLEncoded    = "application/x-www-form-urlencoded"
 	ContentTypeMultipartFormData = "multipart/form-data"
 	ContentTypeTextPlain         = "text/plain"
@@ -334,3 +354,165 @@ func ParseParameterEncodingStyle(input string) (ParameterEncodingStyle, error) {
 
 	return result, nil
 }
+
+// GraphQLOperationType represents the root operation type of a GraphQL field.
+type GraphQLOperationType string
+
+const (
+	GraphQLQuery    GraphQLOperationType = "query"
+	GraphQLMutation GraphQLOperationType = "mutation"
+)
+
+var graphQLOperationType_enums = []GraphQLOperationType{GraphQLQuery, GraphQLMutation}
+
+// JSONSchema is used to generate a custom jsonschema.
+func (j GraphQLOperationType) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "string",
+		Enum: toAnySlice(graphQLOperationType_enums),
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *GraphQLOperationType) UnmarshalJSON(b []byte) error {
+	var rawResult string
+	if err := json.Unmarshal(b, &rawResult); err != nil {
+		return err
+	}
+
+	result, err := ParseGraphQLOperationType(rawResult)
+	if err != nil {
+		return err
+	}
+
+	*j = result
+
+	return nil
+}
+
+// IsValid checks if the operation type enum is valid.
+func (j GraphQLOperationType) IsValid() bool {
+	return slices.Contains(graphQLOperationType_enums, j)
+}
+
+// ParseGraphQLOperationType parses GraphQLOperationType from string.
+func ParseGraphQLOperationType(input string) (GraphQLOperationType, error) {
+	result := GraphQLOperationType(input)
+	if !result.IsValid() {
+		return result, fmt.Errorf(
+			"invalid GraphQLOperationType. Expected %+v, got <%s>",
+			graphQLOperationType_enums,
+			input,
+		)
+	}
+
+	return result, nil
+}
+
+// JSONRPCParamStructure represents the structure of params in a JSON-RPC request object.
+type JSONRPCParamStructure string
+
+const (
+	JSONRPCParamsByPosition JSONRPCParamStructure = "by-position"
+	JSONRPCParamsByName     JSONRPCParamStructure = "by-name"
+)
+
+var jsonRPCParamStructure_enums = []JSONRPCParamStructure{JSONRPCParamsByPosition, JSONRPCParamsByName}
+
+// JSONSchema is used to generate a custom jsonschema.
+func (j JSONRPCParamStructure) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "string",
+		Enum: toAnySlice(jsonRPCParamStructure_enums),
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *JSONRPCParamStructure) UnmarshalJSON(b []byte) error {
+	var rawResult string
+	if err := json.Unmarshal(b, &rawResult); err != nil {
+		return err
+	}
+
+	result, err := ParseJSONRPCParamStructure(rawResult)
+	if err != nil {
+		return err
+	}
+
+	*j = result
+
+	return nil
+}
+
+// IsValid checks if the param structure enum is valid.
+func (j JSONRPCParamStructure) IsValid() bool {
+	return slices.Contains(jsonRPCParamStructure_enums, j)
+}
+
+// ParseJSONRPCParamStructure parses JSONRPCParamStructure from string.
+func ParseJSONRPCParamStructure(input string) (JSONRPCParamStructure, error) {
+	result := JSONRPCParamStructure(input)
+	if !result.IsValid() {
+		return result, fmt.Errorf(
+			"invalid JSONRPCParamStructure. Expected %+v, got <%s>",
+			jsonRPCParamStructure_enums,
+			input,
+		)
+	}
+
+	return result, nil
+}
+
+// SOAPVersion represents the version of the SOAP envelope.
+type SOAPVersion string
+
+const (
+	SOAPVersion11 SOAPVersion = "1.1"
+	SOAPVersion12 SOAPVersion = "1.2"
+)
+
+var soapVersion_enums = []SOAPVersion{SOAPVersion11, SOAPVersion12}
+
+// JSONSchema is used to generate a custom jsonschema.
+func (j SOAPVersion) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "string",
+		Enum: toAnySlice(soapVersion_enums),
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *SOAPVersion) UnmarshalJSON(b []byte) error {
+	var rawResult string
+	if err := json.Unmarshal(b, &rawResult); err != nil {
+		return err
+	}
+
+	result, err := ParseSOAPVersion(rawResult)
+	if err != nil {
+		return err
+	}
+
+	*j = result
+
+	return nil
+}
+
+// IsValid checks if the SOAP version enum is valid.
+func (j SOAPVersion) IsValid() bool {
+	return slices.Contains(soapVersion_enums, j)
+}
+
+// ParseSOAPVersion parses SOAPVersion from string.
+func ParseSOAPVersion(input string) (SOAPVersion, error) {
+	result := SOAPVersion(input)
+	if !result.IsValid() {
+		return result, fmt.Errorf(
+			"invalid SOAPVersion. Expected %+v, got <%s>",
+			soapVersion_enums,
+			input,
+		)
+	}
+
+	return result, nil
+}
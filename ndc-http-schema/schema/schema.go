@@ -109,6 +109,10 @@ func (rm *NDCHttpSchema) AddScalar(name string, scalar schema.ScalarType) {
 type RuntimeSettings struct { // configure the request timeout in seconds, default 30s
 	Timeout uint               `json:"timeout,omitempty" mapstructure:"timeout" yaml:"timeout,omitempty"`
 	Retry   exhttp.RetryPolicy `json:"retry,omitempty"   mapstructure:"retry"   yaml:"retry,omitempty"`
+	// The maximum size of response bodies in bytes before decompression.
+	MaxResponseBytes int64 `json:"maxResponseBytes,omitempty" mapstructure:"maxResponseBytes" yaml:"maxResponseBytes,omitempty" jsonschema:"min=0"`
+	// The maximum size of response bodies in bytes after decompression. Defaults to the limit before decompression.
+	MaxDecompressedBytes int64 `json:"maxDecompressedBytes,omitempty" mapstructure:"maxDecompressedBytes" yaml:"maxDecompressedBytes,omitempty" jsonschema:"min=0"`
 }
 
 type Response struct {
@@ -126,6 +130,14 @@ type Request struct {
 	Servers     []ServerConfig                 `json:"servers,omitempty"     mapstructure:"servers"     yaml:"servers,omitempty"`
 	RequestBody *RequestBody                   `json:"requestBody,omitempty" mapstructure:"requestBody" yaml:"requestBody,omitempty"`
 	Response    Response                       `json:"response"              mapstructure:"response"    yaml:"response"`
+	// The GraphQL operation of the request. The request body is generated from the field selection if set.
+	GraphQL *GraphQLRequest `json:"graphql,omitempty" mapstructure:"graphql" yaml:"graphql,omitempty"`
+	// The JSON-RPC method of the request. Arguments are wrapped into the JSON-RPC request object if set.
+	JSONRPC *JSONRPCRequest `json:"jsonrpc,omitempty" mapstructure:"jsonrpc" yaml:"jsonrpc,omitempty"`
+	// The SOAP operation of the request. The XML body is wrapped into the SOAP envelope if set.
+	SOAP *SOAPRequest `json:"soap,omitempty" mapstructure:"soap" yaml:"soap,omitempty"`
+	// The OData resource or operation of the request. The value envelope of the response is unwrapped if required.
+	OData *ODataRequest `json:"odata,omitempty" mapstructure:"odata" yaml:"odata,omitempty"`
 }
 
 // Clone copies this instance to a new one.
@@ -139,7 +151,47 @@ func (r Request) Clone() *Request {
 		RequestBody:     r.RequestBody,
 		Response:        r.Response,
 		RuntimeSettings: r.RuntimeSettings,
-	}
+		GraphQL:         r.GraphQL,
+		JSONRPC:         r.JSONRPC,
+		SOAP:            r.SOAP,
+		OData:           r.OData,
+	}
+}
+
+// GraphQLRequest represents the root field of a remote GraphQL API.
+type GraphQLRequest struct {
+	// The root operation type of the field
+	OperationType GraphQLOperationType `json:"operationType" mapstructure:"operationType" yaml:"operationType"`
+	// The name of the root field
+	Field string `json:"field" mapstructure:"field" yaml:"field"`
+	// GraphQL input types of field arguments which are used to declare variables, e.g. ID!
+	Variables map[string]string `json:"variables,omitempty" mapstructure:"variables" yaml:"variables,omitempty"`
+}
+
+// JSONRPCRequest represents a method of a remote JSON-RPC 2.0 API.
+type JSONRPCRequest struct {
+	// The name of the remote method
+	Method string `json:"method" mapstructure:"method" yaml:"method"`
+	// The structure of params in the request object. Defaults to by-position
+	ParamStructure JSONRPCParamStructure `json:"paramStructure,omitempty" mapstructure:"paramStructure" yaml:"paramStructure,omitempty"`
+	// Ordered argument names which are sent as params
+	Params []string `json:"params,omitempty" mapstructure:"params" yaml:"params,omitempty"`
+}
+
+// SOAPRequest represents an operation of a remote SOAP service.
+type SOAPRequest struct {
+	// The SOAP version of the envelope
+	Version SOAPVersion `json:"version" mapstructure:"version" yaml:"version"`
+	// The SOAP action of the operation which is sent in the SOAPAction header or the action parameter of the content type
+	Action string `json:"action,omitempty" mapstructure:"action" yaml:"action,omitempty"`
+	// The empty element of the body content which is sent if the operation doesn't have the body argument
+	Element *XMLSchema `json:"element,omitempty" mapstructure:"element" yaml:"element,omitempty"`
+}
+
+// ODataRequest represents a resource or an operation of a remote OData v4 service.
+type ODataRequest struct {
+	// The result is wrapped in the value property of the response, e.g. collections and primitive values
+	Value bool `json:"value,omitempty" mapstructure:"value" yaml:"value,omitempty"`
 }
 
 // RequestParameter represents an HTTP request parameter.
@@ -0,0 +1,137 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+	"github.com/invopop/jsonschema"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+// SecretString represents a secret from a literal value, an environment variable or a file.
+// Secret files can be rotated without restarting the connector, e.g. Kubernetes secret volumes.
+type SecretString struct {
+	goenvconf.EnvString `mapstructure:",squash" yaml:",inline"`
+
+	// Path to the file that contains the secret. Leading and trailing whitespaces of the content are trimmed.
+	File *goenvconf.EnvString `json:"file,omitempty" mapstructure:"file" yaml:"file,omitempty"`
+}
+
+// NewSecretString creates a SecretString from an environment string.
+func NewSecretString(value goenvconf.EnvString) SecretString {
+	return SecretString{
+		EnvString: value,
+	}
+}
+
+// NewSecretStringValue creates a SecretString with a literal value.
+func NewSecretStringValue(value string) SecretString {
+	return NewSecretString(goenvconf.NewEnvStringValue(value))
+}
+
+// NewSecretStringVariable creates a SecretString with a variable name.
+func NewSecretStringVariable(name string) SecretString {
+	return NewSecretString(goenvconf.NewEnvStringVariable(name))
+}
+
+// NewSecretStringFile creates a SecretString that is read from a file.
+func NewSecretStringFile(path goenvconf.EnvString) SecretString {
+	return SecretString{
+		File: &path,
+	}
+}
+
+// JSONSchema is used to generate a custom jsonschema.
+func (ss SecretString) JSONSchema() *jsonschema.Schema {
+	properties := orderedmap.New[string, *jsonschema.Schema]()
+	properties.Set("value", &jsonschema.Schema{
+		Description: "Default literal value if the env is empty",
+		Type:        "string",
+	})
+	properties.Set("env", &jsonschema.Schema{
+		Description: "Environment variable to be evaluated",
+		Type:        "string",
+	})
+	properties.Set("file", &jsonschema.Schema{
+		Description: "Path to the file that contains the secret. The secret is reloaded when the file changes",
+		Ref:         "#/$defs/EnvString",
+	})
+
+	return &jsonschema.Schema{
+		Type:       "object",
+		Properties: properties,
+		AnyOf: []*jsonschema.Schema{
+			{Required: []string{"value"}},
+			{Required: []string{"env"}},
+			{Required: []string{"file"}},
+		},
+	}
+}
+
+// IsZero checks if the instance is empty.
+func (ss SecretString) IsZero() bool {
+	return ss.File == nil && ss.EnvString.IsZero()
+}
+
+// Equal checks if the target value is equal, including the secret file.
+func (ss SecretString) Equal(target SecretString) bool {
+	if !ss.EnvString.Equal(target.EnvString) {
+		return false
+	}
+
+	return (ss.File == nil && target.File == nil) ||
+		(ss.File != nil && target.File != nil && ss.File.Equal(*target.File))
+}
+
+// GetFilePath returns the path of the secret file if exists.
+func (ss SecretString) GetFilePath() (string, error) {
+	if ss.File == nil {
+		return "", nil
+	}
+
+	return ss.File.GetOrDefault("")
+}
+
+// Get gets the secret from the file, the literal value or system environment.
+func (ss SecretString) Get() (string, error) {
+	filePath, err := ss.GetFilePath()
+	if err != nil {
+		return "", fmt.Errorf("file: %w", err)
+	}
+
+	if filePath == "" {
+		return ss.EnvString.Get()
+	}
+
+	rawValue, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the secret file: %w", err)
+	}
+
+	return strings.TrimSpace(string(rawValue)), nil
+}
+
+// GetOrDefault returns the default value if the secret is empty.
+func (ss SecretString) GetOrDefault(defaultValue string) (string, error) {
+	filePath, err := ss.GetFilePath()
+	if err != nil {
+		return "", fmt.Errorf("file: %w", err)
+	}
+
+	if filePath == "" {
+		return ss.EnvString.GetOrDefault(defaultValue)
+	}
+
+	result, err := ss.Get()
+	if err != nil {
+		return "", err
+	}
+
+	if result == "" {
+		return defaultValue, nil
+	}
+
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hasura/goenvconf"
+	"gotest.tools/v3/assert"
+)
+
+func TestSecretString(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "secret")
+	assert.NilError(t, os.WriteFile(secretPath, []byte("s3cret\n"), 0o600))
+	t.Setenv("SECRET_FILE", secretPath)
+	t.Setenv("SECRET_VALUE", "from-env")
+
+	testCases := []struct {
+		name     string
+		input    SecretString
+		expected string
+		errorMsg string
+	}{
+		{
+			name:     "value",
+			input:    NewSecretStringValue("foo"),
+			expected: "foo",
+		},
+		{
+			name:     "env",
+			input:    NewSecretStringVariable("SECRET_VALUE"),
+			expected: "from-env",
+		},
+		{
+			name:     "file",
+			input:    NewSecretStringFile(goenvconf.NewEnvStringVariable("SECRET_FILE")),
+			expected: "s3cret",
+		},
+		{
+			name: "file_not_found",
+			input: NewSecretStringFile(
+				goenvconf.NewEnvStringValue(filepath.Join(t.TempDir(), "not-found")),
+			),
+			errorMsg: "failed to read the secret file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.input.Get()
+			if tc.errorMsg != "" {
+				assert.ErrorContains(t, err, tc.errorMsg)
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tc.expected, result)
+			assert.Assert(t, !tc.input.IsZero())
+		})
+	}
+
+	assert.Assert(t, SecretString{}.IsZero())
+}
+
+func TestSecretStringEqual(t *testing.T) {
+	fileA := NewSecretStringFile(goenvconf.NewEnvStringValue("/secrets/a"))
+
+	assert.Assert(t, NewSecretStringValue("foo").Equal(NewSecretStringValue("foo")))
+	assert.Assert(t, !NewSecretStringValue("foo").Equal(NewSecretStringValue("bar")))
+	assert.Assert(t, fileA.Equal(NewSecretStringFile(goenvconf.NewEnvStringValue("/secrets/a"))))
+	assert.Assert(t, !fileA.Equal(NewSecretStringFile(goenvconf.NewEnvStringValue("/secrets/b"))))
+	assert.Assert(t, !fileA.Equal(SecretString{}))
+}
@@ -26,6 +26,8 @@ type NDCHttpSettings struct {
 	Security           AuthSecurities                 `json:"security,omitempty"           mapstructure:"security"           yaml:"security,omitempty"`
 	Version            string                         `json:"version,omitempty"            mapstructure:"version"            yaml:"version,omitempty"`
 	TLS                *exhttp.TLSConfig              `json:"tls,omitempty"                mapstructure:"tls"                yaml:"tls,omitempty"`
+	Proxy              *exhttp.ProxyConfig            `json:"proxy,omitempty"              mapstructure:"proxy"              yaml:"proxy,omitempty"`
+	Transport          *exhttp.HTTPTransportConfig    `json:"transport,omitempty"          mapstructure:"transport"          yaml:"transport,omitempty"`
 	ResponseTransforms []ResponseTransformSetting     `json:"responseTransforms,omitempty" mapstructure:"responseTransforms" yaml:"responseTransforms,omitempty"`
 }
 
@@ -55,6 +57,12 @@ func (rs *NDCHttpSettings) Validate() error {
 		}
 	}
 
+	if rs.Transport != nil {
+		if err := rs.Transport.Validate(); err != nil {
+			return fmt.Errorf("transport: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -67,6 +75,8 @@ type ServerConfig struct {
 	SecuritySchemes map[string]SecurityScheme      `json:"securitySchemes,omitempty" mapstructure:"securitySchemes" yaml:"securitySchemes,omitempty"`
 	Security        AuthSecurities                 `json:"security,omitempty"        mapstructure:"security"        yaml:"security,omitempty"`
 	TLS             *exhttp.TLSConfig              `json:"tls,omitempty"             mapstructure:"tls"             yaml:"tls,omitempty"`
+	Proxy           *exhttp.ProxyConfig            `json:"proxy,omitempty"           mapstructure:"proxy"           yaml:"proxy,omitempty"`
+	Transport       *exhttp.HTTPTransportConfig    `json:"transport,omitempty"       mapstructure:"transport"       yaml:"transport,omitempty"`
 }
 
 // Validate if the current instance is valid.
@@ -80,7 +90,7 @@ func (ss *ServerConfig) Validate() error {
 		return errors.New("url is required for server")
 	}
 
-	_, err = exhttp.ParseHttpURL(rawURL)
+	_, err = exhttp.ParseServerURL(rawURL)
 	if err != nil {
 		return fmt.Errorf("server url: %w", err)
 	}
@@ -91,6 +101,12 @@ func (ss *ServerConfig) Validate() error {
 		}
 	}
 
+	if ss.Transport != nil {
+		if err := ss.Transport.Validate(); err != nil {
+			return fmt.Errorf("transport: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -101,7 +117,7 @@ func (ss ServerConfig) GetURL() (*url.URL, error) {
 		return nil, err
 	}
 
-	urlValue, err := exhttp.ParseHttpURL(rawURL)
+	urlValue, err := exhttp.ParseServerURL(rawURL)
 	if err != nil {
 		return nil, fmt.Errorf("server url: %w", err)
 	}
@@ -129,18 +145,9 @@ func (apc ArgumentPresetConfig) Validate() (*jsonpath.Path, []regexp.Regexp, err
 		return nil, nil, errors.New("require value in ArgumentPresetConfig")
 	}
 
-	rawPath := apc.Path
-	switch rawPath[0] {
-	case '$':
-	case '.':
-		rawPath = "$" + rawPath
-	default:
-		rawPath = "$." + rawPath
-	}
-
-	jsonPath, err := jsonpath.Parse(rawPath)
+	jsonPath, err := parseJSONPath(apc.Path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse the json path: %w", err)
+		return nil, nil, err
 	}
 
 	if len(jsonPath.Query().Segments()) == 0 {
@@ -415,6 +422,24 @@ func ParseRelativeOrHttpURL(input string) (*url.URL, error) {
 	return exhttp.ParseHttpURL(input)
 }
 
+// parseJSONPath parses a JSONPath expression. The root selector ($) is optional.
+func parseJSONPath(rawPath string) (*jsonpath.Path, error) {
+	switch rawPath[0] {
+	case '$':
+	case '.':
+		rawPath = "$" + rawPath
+	default:
+		rawPath = "$." + rawPath
+	}
+
+	jsonPath, err := jsonpath.Parse(rawPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the json path: %w", err)
+	}
+
+	return jsonPath, nil
+}
+
 func getStringFromAnyMap(input map[string]any, key string) (string, error) {
 	rawValue, ok := input[key]
 	if !ok {
@@ -95,6 +95,17 @@ func TestNDCHttpSettings(t *testing.T) {
 							"value": "password"
 						}
 					},
+					"digest": {
+						"type": "digest",
+						"username": {
+							"value": "user"
+						},
+						"password": {
+							"file": {
+								"value": "/run/secrets/password"
+							}
+						}
+					},
 					"cookie": {
 						"type": "cookie"
 					},
@@ -105,6 +116,24 @@ func TestNDCHttpSettings(t *testing.T) {
 						"type": "openIdConnect",
 						"openIdConnectUrl": "http://localhost:8080/oauth/token"
 					},
+					"login": {
+						"type": "tokenEndpoint",
+						"request": {
+							"url": {
+								"value": "/login"
+							},
+							"body": {
+								"username": {
+									"env": "PET_STORE_USERNAME"
+								}
+							}
+						},
+						"tokenPath": "$.token",
+						"expiresInPath": "expires_in",
+						"in": "header",
+						"name": "Authorization",
+						"scheme": "Bearer"
+					},
 					"petstore_auth": {
 						"type": "oauth2",
 						"flows": {
@@ -242,14 +271,23 @@ func TestNDCHttpSettings(t *testing.T) {
 							Type:  APIKeyScheme,
 							In:    APIKeyInHeader,
 							Name:  "api_key",
-							Value: goenvconf.NewEnvStringVariable("PET_STORE_API_KEY"),
+							Value: NewSecretStringVariable("PET_STORE_API_KEY"),
 						},
 					},
 					"basic": {
 						SecuritySchemer: &BasicAuthConfig{
 							Type:     BasicAuthScheme,
-							Username: goenvconf.NewEnvStringValue("user"),
-							Password: goenvconf.NewEnvStringValue("password"),
+							Username: NewSecretStringValue("user"),
+							Password: NewSecretStringValue("password"),
+						},
+					},
+					"digest": {
+						SecuritySchemer: &DigestAuthConfig{
+							Type:     DigestAuthScheme,
+							Username: NewSecretStringValue("user"),
+							Password: NewSecretStringFile(
+								goenvconf.NewEnvStringValue("/run/secrets/password"),
+							),
 						},
 					},
 					"http": {
@@ -257,7 +295,7 @@ func TestNDCHttpSettings(t *testing.T) {
 							Type:   HTTPAuthScheme,
 							Header: "Authorization",
 							Scheme: "bearer",
-							Value:  goenvconf.NewEnvStringVariable("PET_STORE_API_KEY"),
+							Value:  NewSecretStringVariable("PET_STORE_API_KEY"),
 						},
 					},
 					"cookie": {
@@ -271,6 +309,22 @@ func TestNDCHttpSettings(t *testing.T) {
 							"http://localhost:8080/oauth/token",
 						),
 					},
+					"login": {
+						SecuritySchemer: &TokenEndpointAuthConfig{
+							Type: TokenEndpointScheme,
+							Request: TokenEndpointRequest{
+								URL: goenvconf.NewEnvStringValue("/login"),
+								Body: map[string]SecretString{
+									"username": NewSecretStringVariable("PET_STORE_USERNAME"),
+								},
+							},
+							TokenPath:     "$.token",
+							ExpiresInPath: "expires_in",
+							In:            APIKeyInHeader,
+							Name:          "Authorization",
+							Scheme:        "Bearer",
+						},
+					},
 					"petstore_auth": {
 						SecuritySchemer: &OAuth2Config{
 							Type: OAuth2Scheme,
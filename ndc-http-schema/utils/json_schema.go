@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	dateRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+// InferJSONSchema infers an OpenAPI schema object from an example JSON value.
+// Numbers should be decoded as json.Number to distinguish integers from floats.
+func InferJSONSchema(value any) map[string]any {
+	return inferJSONSchema(value, false)
+}
+
+// InferRequiredJSONSchema infers an OpenAPI schema object from an example JSON value
+// and marks non-null properties of objects as required.
+// Merging samples with MergeJSONSchemas keeps only properties that are required in all samples.
+func InferRequiredJSONSchema(value any) map[string]any {
+	return inferJSONSchema(value, true)
+}
+
+// InferStringScalarSchema infers an OpenAPI schema object from a string value,
+// e.g. a query or path parameter.
+func InferStringScalarSchema(value string) map[string]any {
+	if value == "" {
+		return map[string]any{"type": "string"}
+	}
+
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil && (value == "0" || value[0] != '0') {
+		return inferIntegerSchema(i)
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil && strings.Contains(value, ".") {
+		return map[string]any{"type": "number"}
+	}
+
+	if value == "true" || value == "false" {
+		return map[string]any{"type": "boolean"}
+	}
+
+	return inferStringSchema(value)
+}
+
+func inferJSONSchema(value any, required bool) map[string]any {
+	switch v := value.(type) {
+	case nil:
+		return map[string]any{"nullable": true}
+	case bool:
+		return map[string]any{"type": "boolean"}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return inferIntegerSchema(i)
+		}
+
+		return map[string]any{"type": "number"}
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < math.MaxInt64 {
+			return inferIntegerSchema(int64(v))
+		}
+
+		return map[string]any{"type": "number"}
+	case string:
+		return inferStringSchema(v)
+	case []any:
+		var items map[string]any
+
+		for i, item := range v {
+			if i == 0 {
+				items = inferJSONSchema(item, required)
+			} else {
+				items = MergeJSONSchemas(items, inferJSONSchema(item, required))
+			}
+		}
+
+		if items == nil {
+			items = map[string]any{}
+		}
+
+		return map[string]any{"type": "array", "items": items}
+	case map[string]any:
+		properties := make(map[string]any, len(v))
+		requiredKeys := []string{}
+
+		for key, item := range v {
+			properties[key] = inferJSONSchema(item, required)
+
+			if item != nil {
+				requiredKeys = append(requiredKeys, key)
+			}
+		}
+
+		result := map[string]any{"type": "object", "properties": properties}
+		if required {
+			slices.Sort(requiredKeys)
+			result["required"] = requiredKeys
+		}
+
+		return result
+	default:
+		return map[string]any{}
+	}
+}
+
+// MergeJSONSchemas merges two inferred schemas of examples of the same value.
+// Incompatible types are merged to an arbitrary JSON schema.
+func MergeJSONSchemas(a, b map[string]any) map[string]any {
+	typeA, _ := a["type"].(string)
+	typeB, _ := b["type"].(string)
+	nullable := a["nullable"] == true || b["nullable"] == true
+
+	var result map[string]any
+
+	switch {
+	case typeA == "" && nullable && len(a) == 1:
+		result = maps.Clone(b)
+	case typeB == "" && nullable && len(b) == 1:
+		result = maps.Clone(a)
+	case typeA != typeB:
+		if (typeA == "integer" || typeA == "number") && (typeB == "integer" || typeB == "number") {
+			result = map[string]any{"type": "number"}
+		} else {
+			result = map[string]any{}
+		}
+	case typeA == "object":
+		propsA, _ := a["properties"].(map[string]any)
+		propsB, _ := b["properties"].(map[string]any)
+		properties := maps.Clone(propsA)
+		if properties == nil {
+			properties = map[string]any{}
+		}
+
+		for key, prop := range propsB {
+			if existing, ok := properties[key].(map[string]any); ok {
+				properties[key] = MergeJSONSchemas(existing, prop.(map[string]any))
+			} else {
+				properties[key] = prop
+			}
+		}
+
+		result = map[string]any{"type": "object", "properties": properties}
+
+		requiredA, okA := a["required"].([]string)
+		requiredB, okB := b["required"].([]string)
+
+		if okA && okB {
+			requiredKeys := []string{}
+
+			for _, key := range requiredA {
+				if slices.Contains(requiredB, key) {
+					requiredKeys = append(requiredKeys, key)
+				}
+			}
+
+			result["required"] = requiredKeys
+		}
+	case typeA == "array":
+		itemsA, _ := a["items"].(map[string]any)
+		itemsB, _ := b["items"].(map[string]any)
+
+		var items map[string]any
+
+		switch {
+		case len(itemsA) == 0:
+			items = itemsB
+		case len(itemsB) == 0:
+			items = itemsA
+		default:
+			items = MergeJSONSchemas(itemsA, itemsB)
+		}
+
+		result = map[string]any{"type": "array", "items": items}
+	default:
+		result = maps.Clone(a)
+		if a["format"] != b["format"] {
+			if typeA == "integer" && (a["format"] == "int64" || b["format"] == "int64") {
+				result["format"] = "int64"
+			} else {
+				delete(result, "format")
+			}
+		}
+	}
+
+	if nullable && len(result) > 0 {
+		result["nullable"] = true
+	}
+
+	return result
+}
+
+func inferStringSchema(value string) map[string]any {
+	switch {
+	case uuidRegex.MatchString(value):
+		return map[string]any{"type": "string", "format": "uuid"}
+	case dateRegex.MatchString(value):
+		if _, err := time.Parse(time.DateOnly, value); err == nil {
+			return map[string]any{"type": "string", "format": "date"}
+		}
+	default:
+		if _, err := time.Parse(time.RFC3339, value); err == nil {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+	}
+
+	return map[string]any{"type": "string"}
+}
+
+func inferIntegerSchema(value int64) map[string]any {
+	if value > math.MaxInt32 || value < math.MinInt32 {
+		return map[string]any{"type": "integer", "format": "int64"}
+	}
+
+	return map[string]any{"type": "integer", "format": "int32"}
+}
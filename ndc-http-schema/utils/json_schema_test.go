@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestInferJSONSchema(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`[
+		{"id": 1, "name": "a", "price": 1, "tags": [], "createdAt": "2024-01-02T03:04:05Z"},
+		{"id": 5000000000, "price": 1.5, "tags": ["x"], "owner": null, "extra": {"ok": true}},
+		{"id": 2, "name": null}
+	]`))
+	decoder.UseNumber()
+
+	var value any
+	assert.NilError(t, decoder.Decode(&value))
+
+	assert.DeepEqual(t, map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":        map[string]any{"type": "integer", "format": "int64"},
+				"name":      map[string]any{"type": "string", "nullable": true},
+				"price":     map[string]any{"type": "number"},
+				"tags":      map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+				"createdAt": map[string]any{"type": "string", "format": "date-time"},
+				"owner":     map[string]any{"nullable": true},
+				"extra": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"ok": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}, InferJSONSchema(value))
+
+	assert.DeepEqual(
+		t,
+		map[string]any{},
+		MergeJSONSchemas(InferJSONSchema("a"), InferJSONSchema(true)),
+	)
+}
+
+func TestInferRequiredJSONSchema(t *testing.T) {
+	first := InferRequiredJSONSchema(map[string]any{"id": json.Number("1"), "name": "a", "owner": nil})
+	second := InferRequiredJSONSchema(map[string]any{"id": json.Number("2"), "owner": map[string]any{"id": "x"}})
+
+	assert.DeepEqual(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":   map[string]any{"type": "integer", "format": "int32"},
+			"name": map[string]any{"type": "string"},
+			"owner": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"id": map[string]any{"type": "string"}},
+				"required":   []string{"id"},
+				"nullable":   true,
+			},
+		},
+		"required": []string{"id"},
+	}, MergeJSONSchemas(first, second))
+}
+
+func TestInferStringScalarSchema(t *testing.T) {
+	for input, expected := range map[string]map[string]any{
+		"":                                     {"type": "string"},
+		"10":                                   {"type": "integer", "format": "int32"},
+		"5000000000":                           {"type": "integer", "format": "int64"},
+		"007":                                  {"type": "string"},
+		"1.5":                                  {"type": "number"},
+		"true":                                 {"type": "boolean"},
+		"2024-01-31":                           {"type": "string", "format": "date"},
+		"2024-01-31T10:00:00Z":                 {"type": "string", "format": "date-time"},
+		"6b0a4bd3-8f0e-4c49-9b2c-0e3d5d1a3c11": {"type": "string", "format": "uuid"},
+	} {
+		t.Run(input, func(t *testing.T) {
+			assert.DeepEqual(t, expected, InferStringScalarSchema(input))
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/theory/jsonpath"
+	"github.com/theory/jsonpath/spec"
+)
+
+var errOverlayRemoveRoot = errors.New("unable to remove the root document")
+
+// OverlayDocument represents an [OpenAPI Overlay] document.
+//
+// [OpenAPI Overlay]: https://spec.openapis.org/overlay/v1.0.0.html
+type OverlayDocument struct {
+	Overlay string          `json:"overlay"`
+	Info    OverlayInfo     `json:"info"`
+	Extends string          `json:"extends,omitempty"`
+	Actions []OverlayAction `json:"actions"`
+}
+
+// OverlayInfo represents metadata of the overlay document.
+type OverlayInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OverlayAction represents an action which updates or removes nodes that the target JSONPath selects.
+type OverlayAction struct {
+	Target      string          `json:"target"`
+	Description string          `json:"description,omitempty"`
+	Update      json.RawMessage `json:"update,omitempty"`
+	Remove      bool            `json:"remove,omitempty"`
+}
+
+// ApplyOverlay applies the overlay document to the raw JSON input.
+// Objects of the update value are merged recursively into selected objects, the update value is appended to
+// selected arrays and replaces other values. Targets which don't select any node are ignored.
+func ApplyOverlay(input []byte, rawOverlay []byte) ([]byte, error) {
+	var overlay OverlayDocument
+	if err := json.Unmarshal(rawOverlay, &overlay); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(overlay.Overlay, "1.") {
+		return nil, fmt.Errorf("unsupported overlay version %q, expected 1.x", overlay.Overlay)
+	}
+
+	document, err := decodeJSONValue(input)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, action := range overlay.Actions {
+		document, err = applyOverlayAction(document, action)
+		if err != nil {
+			return nil, fmt.Errorf("actions[%d]: %w", i, err)
+		}
+	}
+
+	return json.Marshal(document)
+}
+
+func applyOverlayAction(document any, action OverlayAction) (any, error) {
+	if action.Target == "" {
+		return nil, errors.New("target is required")
+	}
+
+	path, err := jsonpath.Parse(action.Target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target %s: %w", action.Target, err)
+	}
+
+	nodes := path.SelectLocated(document).Deduplicate()
+
+	if action.Remove {
+		// remove nodes in reverse order so that indexes of remaining array items are still valid.
+		nodes.Sort()
+
+		for _, node := range slices.Backward(nodes) {
+			document, err = removeJSONPathValue(document, node.Path)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return document, nil
+	}
+
+	if len(action.Update) == 0 || string(action.Update) == "null" {
+		return document, nil
+	}
+
+	for node := range nodes.All() {
+		// decode the update value for each node to avoid sharing references between nodes.
+		update, err := decodeJSONValue(action.Update)
+		if err != nil {
+			return nil, fmt.Errorf("invalid update value: %w", err)
+		}
+
+		document = setJSONPathValue(document, node.Path, mergeOverlayValue(node.Node, update))
+	}
+
+	return document, nil
+}
+
+// mergeOverlayValue merges the update value into the target node.
+func mergeOverlayValue(target any, update any) any {
+	switch targetValue := target.(type) {
+	case map[string]any:
+		updateObject, ok := update.(map[string]any)
+		if !ok {
+			return update
+		}
+
+		for key, value := range updateObject {
+			if existing, ok := targetValue[key]; ok {
+				if _, isObject := existing.(map[string]any); isObject {
+					targetValue[key] = mergeOverlayValue(existing, value)
+
+					continue
+				}
+			}
+
+			targetValue[key] = value
+		}
+
+		return targetValue
+	case []any:
+		return append(targetValue, update)
+	default:
+		return update
+	}
+}
+
+func setJSONPathValue(root any, path spec.NormalizedPath, value any) any {
+	if len(path) == 0 {
+		return value
+	}
+
+	parent := getJSONPathValue(root, path[:len(path)-1])
+
+	switch sel := path[len(path)-1].(type) {
+	case spec.Name:
+		if obj, ok := parent.(map[string]any); ok {
+			obj[string(sel)] = value
+		}
+	case spec.Index:
+		if arr, ok := parent.([]any); ok && int(sel) < len(arr) {
+			arr[sel] = value
+		}
+	}
+
+	return root
+}
+
+func removeJSONPathValue(root any, path spec.NormalizedPath) (any, error) {
+	if len(path) == 0 {
+		return nil, errOverlayRemoveRoot
+	}
+
+	parentPath := path[:len(path)-1]
+	parent := getJSONPathValue(root, parentPath)
+
+	switch sel := path[len(path)-1].(type) {
+	case spec.Name:
+		if obj, ok := parent.(map[string]any); ok {
+			delete(obj, string(sel))
+		}
+	case spec.Index:
+		if arr, ok := parent.([]any); ok && int(sel) < len(arr) {
+			return setJSONPathValue(root, parentPath, slices.Delete(arr, int(sel), int(sel)+1)), nil
+		}
+	}
+
+	return root, nil
+}
+
+func getJSONPathValue(root any, path spec.NormalizedPath) any {
+	current := root
+
+	for _, selector := range path {
+		switch sel := selector.(type) {
+		case spec.Name:
+			obj, ok := current.(map[string]any)
+			if !ok {
+				return nil
+			}
+
+			current = obj[string(sel)]
+		case spec.Index:
+			arr, ok := current.([]any)
+			if !ok || int(sel) >= len(arr) {
+				return nil
+			}
+
+			current = arr[sel]
+		default:
+			return nil
+		}
+	}
+
+	return current
+}
+
+// decodeJSONValue decodes the JSON value and keeps numbers as they are.
+func decodeJSONValue(input []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+
+	var result any
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// isOverlayDocument checks if the JSON object is an overlay document, which has the overlay version and actions.
+func isOverlayDocument(input []byte) bool {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(input, &document); err != nil {
+		return false
+	}
+
+	_, hasOverlay := document["overlay"]
+	_, hasActions := document["actions"]
+
+	return hasOverlay && hasActions
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestApplyOverlay(t *testing.T) {
+	input := `{
+	"functions": {
+		"getPets": {
+			"request": { "url": "/pets", "method": "get" },
+			"arguments": { "limit": { "type": "Int32" } },
+			"tags": ["pet"]
+		},
+		"getUsers": {
+			"request": { "url": "/users", "method": "get" },
+			"arguments": { "id": { "type": "Int64" } },
+			"tags": ["user"]
+		}
+	},
+	"servers": [
+		{ "url": "https://a.example.com" },
+		{ "url": "https://b.example.com" },
+		{ "url": "https://c.example.com" }
+	]
+}`
+
+	testCases := []struct {
+		Name     string
+		Overlay  string
+		Expected string
+		Error    string
+	}{
+		{
+			Name: "update",
+			Overlay: `{
+				"overlay": "1.0.0",
+				"info": { "title": "update", "version": "1.0.0" },
+				"actions": [
+					{ "target": "$.functions.*.request", "update": { "timeout": 30 } },
+					{ "target": "$.functions.getPets.tags", "update": "animal" },
+					{ "target": "$.functions.getUsers.arguments.id", "update": { "type": "BigInteger" } }
+				]
+			}`,
+			Expected: `{
+				"functions": {
+					"getPets": {
+						"request": { "url": "/pets", "method": "get", "timeout": 30 },
+						"arguments": { "limit": { "type": "Int32" } },
+						"tags": ["pet", "animal"]
+					},
+					"getUsers": {
+						"request": { "url": "/users", "method": "get", "timeout": 30 },
+						"arguments": { "id": { "type": "BigInteger" } },
+						"tags": ["user"]
+					}
+				},
+				"servers": [
+					{ "url": "https://a.example.com" },
+					{ "url": "https://b.example.com" },
+					{ "url": "https://c.example.com" }
+				]
+			}`,
+		},
+		{
+			Name: "remove",
+			Overlay: `{
+				"overlay": "1.0.0",
+				"info": { "title": "remove", "version": "1.0.0" },
+				"actions": [
+					{ "target": "$.servers[?@.url != 'https://b.example.com']", "remove": true },
+					{ "target": "$.functions[?@.request.url == '/users']", "remove": true },
+					{ "target": "$.functions.getPets.tags", "remove": true }
+				]
+			}`,
+			Expected: `{
+				"functions": {
+					"getPets": {
+						"request": { "url": "/pets", "method": "get" },
+						"arguments": { "limit": { "type": "Int32" } }
+					}
+				},
+				"servers": [
+					{ "url": "https://b.example.com" }
+				]
+			}`,
+		},
+		{
+			Name:    "invalid_version",
+			Overlay: `{ "overlay": "2.0.0", "actions": [] }`,
+			Error:   `unsupported overlay version "2.0.0"`,
+		},
+		{
+			Name:    "invalid_target",
+			Overlay: `{ "overlay": "1.0.0", "actions": [{ "target": "functions[", "remove": true }] }`,
+			Error:   "actions[0]: invalid target functions[",
+		},
+		{
+			Name:    "remove_root",
+			Overlay: `{ "overlay": "1.0.0", "actions": [{ "target": "$", "remove": true }] }`,
+			Error:   "actions[0]: unable to remove the root document",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := ApplyOverlay([]byte(input), []byte(tc.Overlay))
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+
+				return
+			}
+
+			assert.NilError(t, err)
+
+			var expected, output any
+			assert.NilError(t, json.Unmarshal([]byte(tc.Expected), &expected))
+			assert.NilError(t, json.Unmarshal(result, &output))
+			assert.DeepEqual(t, expected, output)
+		})
+	}
+}
+
+func TestGuessPatchStrategyOverlay(t *testing.T) {
+	strategy, err := guessPatchStrategy([]byte(`{"overlay": "1.0.0", "actions": []}`))
+	assert.NilError(t, err)
+	assert.Equal(t, PatchStrategyOverlay, strategy)
+
+	strategy, err = guessPatchStrategy([]byte(`{"overlay": "1.0.0"}`))
+	assert.NilError(t, err)
+	assert.Equal(t, PatchStrategyMerge, strategy)
+}
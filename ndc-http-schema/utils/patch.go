@@ -28,12 +28,16 @@ const (
 	//
 	// [RFC 6902]: https://datatracker.ietf.org/doc/html/rfc6902
 	PatchStrategyJSON6902 PatchStrategy = "json6902"
+	// PatchStrategyOverlay the patch strategy enum for [OpenAPI Overlay] specification
+	//
+	// [OpenAPI Overlay]: https://spec.openapis.org/overlay/v1.0.0.html
+	PatchStrategyOverlay PatchStrategy = "overlay"
 )
 
 // PatchConfig the configuration for JSON patch.
 type PatchConfig struct {
 	Path     string        `json:"path"     yaml:"path"`
-	Strategy PatchStrategy `json:"strategy" yaml:"strategy" jsonschema:"enum=merge,enum=json6902,default=merge"`
+	Strategy PatchStrategy `json:"strategy" yaml:"strategy" jsonschema:"enum=merge,enum=json6902,enum=overlay,default=merge"`
 }
 
 // ApplyPatchToHTTPSchema applies JSON patches to NDC HTTP schema and validate the output.
@@ -106,6 +110,11 @@ func ApplyPatchFromRawJSON(input []byte, patchFiles []PatchConfig) ([]byte, erro
 				if err != nil {
 					return fmt.Errorf("failed to merge JSON patch from file %s: %w", patchFile, err)
 				}
+			case PatchStrategyOverlay:
+				input, err = ApplyOverlay(input, jsonPatch)
+				if err != nil {
+					return fmt.Errorf("failed to apply overlay from file %s: %w", patchFile, err)
+				}
 			default:
 				return fmt.Errorf("invalid JSON path strategy: %s", patchFile.Strategy)
 			}
@@ -152,6 +161,10 @@ func guessPatchStrategy(runes []byte) (PatchStrategy, error) {
 	}
 
 	if runes[0] == '{' && runes[len(runes)-1] == '}' {
+		if isOverlayDocument(runes) {
+			return PatchStrategyOverlay, nil
+		}
+
 		return PatchStrategyMerge, nil
 	}
 
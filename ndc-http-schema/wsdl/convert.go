@@ -0,0 +1,1110 @@
+package wsdl
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/hasura/goenvconf"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+	"github.com/hasura/ndc-http/ndc-http-schema/utils"
+	"github.com/hasura/ndc-sdk-go/v2/schema"
+)
+
+var errUnsupportedOperation = errors.New("unsupported operation")
+
+// builtin XML schema types and their equivalent NDC scalars.
+var builtinScalars = map[string]rest.ScalarName{
+	"anySimpleType":      rest.ScalarJSON,
+	"anyType":            rest.ScalarJSON,
+	"anyURI":             rest.ScalarString,
+	"base64Binary":       rest.ScalarBytes,
+	"boolean":            rest.ScalarBoolean,
+	"byte":               rest.ScalarInt32,
+	"date":               rest.ScalarDate,
+	"dateTime":           rest.ScalarTimestampTZ,
+	"decimal":            rest.ScalarBigDecimal,
+	"double":             rest.ScalarFloat64,
+	"duration":           rest.ScalarString,
+	"float":              rest.ScalarFloat32,
+	"hexBinary":          rest.ScalarString,
+	"ID":                 rest.ScalarString,
+	"IDREF":              rest.ScalarString,
+	"int":                rest.ScalarInt32,
+	"integer":            rest.ScalarInt64,
+	"language":           rest.ScalarString,
+	"long":               rest.ScalarInt64,
+	"Name":               rest.ScalarString,
+	"NCName":             rest.ScalarString,
+	"negativeInteger":    rest.ScalarInt64,
+	"NMTOKEN":            rest.ScalarString,
+	"nonNegativeInteger": rest.ScalarInt64,
+	"nonPositiveInteger": rest.ScalarInt64,
+	"normalizedString":   rest.ScalarString,
+	"positiveInteger":    rest.ScalarInt64,
+	"QName":              rest.ScalarString,
+	"short":              rest.ScalarInt32,
+	"string":             rest.ScalarString,
+	"time":               rest.ScalarString,
+	"token":              rest.ScalarString,
+	"unsignedByte":       rest.ScalarInt32,
+	"unsignedInt":        rest.ScalarInt64,
+	"unsignedLong":       rest.ScalarInt64,
+	"unsignedShort":      rest.ScalarInt32,
+}
+
+var builtinScalarRepresentations = map[rest.ScalarName]schema.TypeRepresentation{
+	rest.ScalarBigDecimal:  schema.NewTypeRepresentationBigDecimal().Encode(),
+	rest.ScalarBoolean:     schema.NewTypeRepresentationBoolean().Encode(),
+	rest.ScalarBytes:       schema.NewTypeRepresentationBytes().Encode(),
+	rest.ScalarDate:        schema.NewTypeRepresentationDate().Encode(),
+	rest.ScalarFloat32:     schema.NewTypeRepresentationFloat32().Encode(),
+	rest.ScalarFloat64:     schema.NewTypeRepresentationFloat64().Encode(),
+	rest.ScalarInt32:       schema.NewTypeRepresentationInt32().Encode(),
+	rest.ScalarInt64:       schema.NewTypeRepresentationInt64().Encode(),
+	rest.ScalarJSON:        schema.NewTypeRepresentationJSON().Encode(),
+	rest.ScalarString:      schema.NewTypeRepresentationString().Encode(),
+	rest.ScalarTimestampTZ: schema.NewTypeRepresentationTimestampTZ().Encode(),
+}
+
+// known verbs of read-only operations which are converted to functions.
+var functionVerbs = map[string]bool{
+	"check":    true,
+	"count":    true,
+	"describe": true,
+	"fetch":    true,
+	"find":     true,
+	"get":      true,
+	"has":      true,
+	"is":       true,
+	"list":     true,
+	"lookup":   true,
+	"query":    true,
+	"read":     true,
+	"retrieve": true,
+	"search":   true,
+}
+
+// schemaScope holds the XML schema of a component with namespace prefixes in its scope.
+type schemaScope struct {
+	schema     *Schema
+	namespaces map[string]string
+}
+
+type schemaComponent[T any] struct {
+	value *T
+	scope *schemaScope
+}
+
+type converter struct {
+	definitions *Definitions
+	options     openapi.ConvertOptions
+	logger      *slog.Logger
+	schema      *rest.NDCHttpSchema
+	namespaces  map[string]string
+	messages    map[string]*Message
+	portTypes   map[string]*PortType
+	bindings    map[string]*Binding
+	// top-level schema components indexed by local names
+	elements     map[string]schemaComponent[Element]
+	complexTypes map[string]schemaComponent[ComplexType]
+	simpleTypes  map[string]schemaComponent[SimpleType]
+	// converted NDC type names of schema components
+	typeNames map[string]string
+}
+
+func newConverter(definitions *Definitions, options openapi.ConvertOptions) *converter {
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	c := &converter{
+		definitions:  definitions,
+		options:      options,
+		logger:       logger,
+		schema:       rest.NewNDCHttpSchema(),
+		namespaces:   getNamespaces(definitions.Attrs),
+		messages:     map[string]*Message{},
+		portTypes:    map[string]*PortType{},
+		bindings:     map[string]*Binding{},
+		elements:     map[string]schemaComponent[Element]{},
+		complexTypes: map[string]schemaComponent[ComplexType]{},
+		simpleTypes:  map[string]schemaComponent[SimpleType]{},
+		typeNames:    map[string]string{},
+	}
+
+	for i := range definitions.Messages {
+		c.messages[definitions.Messages[i].Name] = &definitions.Messages[i]
+	}
+
+	for i := range definitions.PortTypes {
+		c.portTypes[definitions.PortTypes[i].Name] = &definitions.PortTypes[i]
+	}
+
+	for i := range definitions.Bindings {
+		c.bindings[definitions.Bindings[i].Name] = &definitions.Bindings[i]
+	}
+
+	for i := range definitions.Types.Schemas {
+		xmlSchema := &definitions.Types.Schemas[i]
+		scope := &schemaScope{
+			schema:     xmlSchema,
+			namespaces: map[string]string{},
+		}
+
+		for prefix, namespace := range c.namespaces {
+			scope.namespaces[prefix] = namespace
+		}
+
+		for prefix, namespace := range getNamespaces(xmlSchema.Attrs) {
+			scope.namespaces[prefix] = namespace
+		}
+
+		for j := range xmlSchema.Elements {
+			c.elements[xmlSchema.Elements[j].Name] = schemaComponent[Element]{&xmlSchema.Elements[j], scope}
+		}
+
+		for j := range xmlSchema.ComplexTypes {
+			c.complexTypes[xmlSchema.ComplexTypes[j].Name] = schemaComponent[ComplexType]{&xmlSchema.ComplexTypes[j], scope}
+		}
+
+		for j := range xmlSchema.SimpleTypes {
+			c.simpleTypes[xmlSchema.SimpleTypes[j].Name] = schemaComponent[SimpleType]{&xmlSchema.SimpleTypes[j], scope}
+		}
+	}
+
+	return c
+}
+
+// Build converts operations of SOAP ports to NDC operations.
+// Ports of the same port type are converted once, e.g. SOAP 1.1 and 1.2 ports of a service.
+func (c *converter) Build() (*rest.NDCHttpSchema, error) {
+	var location string
+
+	convertedPortTypes := map[string]bool{}
+
+	for _, service := range c.definitions.Services {
+		for _, port := range service.Ports {
+			version := rest.SOAPVersion11
+			address := port.SOAP11Address
+
+			if address == nil {
+				version = rest.SOAPVersion12
+				address = port.SOAP12Address
+			}
+
+			// skip non-SOAP ports, e.g. HTTP bindings.
+			if address == nil {
+				continue
+			}
+
+			_, bindingName := splitQName(port.Binding)
+
+			binding, ok := c.bindings[bindingName]
+			if !ok {
+				return nil, fmt.Errorf("%s.%s: the binding %s does not exist", service.Name, port.Name, port.Binding)
+			}
+
+			_, portTypeName := splitQName(binding.Type)
+			if convertedPortTypes[portTypeName] {
+				continue
+			}
+
+			// operations share the server URL, so ports of other locations can't be served.
+			if location != "" && address.Location != location {
+				c.logger.Warn(
+					"skipped the port which has a different location",
+					slog.String("service", service.Name),
+					slog.String("port", port.Name),
+					slog.String("location", address.Location),
+				)
+
+				continue
+			}
+
+			location = address.Location
+			convertedPortTypes[portTypeName] = true
+
+			if err := c.convertBinding(binding, portTypeName, version); err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", service.Name, port.Name, err)
+			}
+		}
+	}
+
+	if len(c.schema.Functions) == 0 && len(c.schema.Procedures) == 0 {
+		return nil, errors.New("there is no API to be converted")
+	}
+
+	envName := utils.StringSliceToConstantCase([]string{c.options.EnvPrefix, "SERVER_URL"})
+	serverURL := goenvconf.NewEnvStringVariable(envName)
+
+	if location != "" {
+		serverURL = goenvconf.NewEnvString(envName, location)
+	}
+
+	c.schema.Settings.Servers = []rest.ServerConfig{
+		{
+			URL: serverURL,
+		},
+	}
+
+	return c.schema, nil
+}
+
+func (c *converter) convertBinding(binding *Binding, portTypeName string, version rest.SOAPVersion) error {
+	portType, ok := c.portTypes[portTypeName]
+	if !ok {
+		return fmt.Errorf("the port type %s of the binding %s does not exist", portTypeName, binding.Name)
+	}
+
+	style := "document"
+
+	switch {
+	case binding.SOAP11 != nil && binding.SOAP11.Style != "":
+		style = binding.SOAP11.Style
+	case binding.SOAP12 != nil && binding.SOAP12.Style != "":
+		style = binding.SOAP12.Style
+	}
+
+	bindingOperations := map[string]*BindingOperation{}
+	for i := range binding.Operations {
+		bindingOperations[binding.Operations[i].Name] = &binding.Operations[i]
+	}
+
+	for _, operation := range portType.Operations {
+		bindingOperation, ok := bindingOperations[operation.Name]
+		if !ok {
+			continue
+		}
+
+		operationInfo, err := c.convertOperation(operation, bindingOperation, style, version)
+		if errors.Is(err, errUnsupportedOperation) {
+			c.logger.Warn(
+				"skipped the unsupported operation",
+				slog.String("operation", operation.Name),
+				slog.String("reason", err.Error()),
+			)
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", operation.Name, err)
+		}
+
+		name := c.formatOperationName(operation.Name)
+		if isFunctionOperation(operation.Name) {
+			c.schema.Functions[name] = *operationInfo
+		} else {
+			c.schema.Procedures[name] = *operationInfo
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) convertOperation(
+	operation Operation,
+	bindingOperation *BindingOperation,
+	style string,
+	version rest.SOAPVersion,
+) (*rest.OperationInfo, error) {
+	soapOperation := bindingOperation.SOAP11
+	if version == rest.SOAPVersion12 {
+		soapOperation = bindingOperation.SOAP12
+	}
+
+	var action string
+
+	if soapOperation != nil {
+		action = soapOperation.SOAPAction
+
+		if soapOperation.Style != "" {
+			style = soapOperation.Style
+		}
+	}
+
+	inputBody := getSOAPBody(bindingOperation.Input, version)
+	outputBody := getSOAPBody(bindingOperation.Output, version)
+
+	for _, body := range []*SOAPBody{inputBody, outputBody} {
+		if body != nil && body.Use == "encoded" {
+			return nil, fmt.Errorf("%w: the encoded use of SOAP body", errUnsupportedOperation)
+		}
+	}
+
+	contentType := rest.ContentTypeTextXML
+	if version == rest.SOAPVersion12 {
+		contentType = rest.ContentTypeSOAPXML
+	}
+
+	result := &rest.OperationInfo{
+		Request: &rest.Request{
+			// the server URL is the SOAP endpoint.
+			URL:    "/",
+			Method: "post",
+			RequestBody: &rest.RequestBody{
+				ContentType: contentType,
+			},
+			Response: rest.Response{
+				ContentType: contentType,
+			},
+			SOAP: &rest.SOAPRequest{
+				Version: version,
+				Action:  action,
+			},
+		},
+		Arguments:   map[string]rest.ArgumentInfo{},
+		Description: toDescription(operation.Documentation),
+	}
+
+	if operation.Input != nil {
+		bodyType, bodySchema, err := c.convertMessage(operation.Input.Message, operation.Name, style, inputBody, false)
+		if err != nil {
+			return nil, fmt.Errorf("input: %w", err)
+		}
+
+		switch {
+		case bodyType != nil:
+			result.Arguments[rest.BodyKey] = rest.ArgumentInfo{
+				ArgumentInfo: schema.ArgumentInfo{
+					Type: bodyType.Encode(),
+				},
+				HTTP: &rest.RequestParameter{
+					In:     rest.InBody,
+					Schema: bodySchema,
+				},
+			}
+		case bodySchema != nil:
+			// the element without content can't be an argument.
+			result.Request.SOAP.Element = bodySchema.XML
+		}
+	}
+
+	var resultType schema.TypeEncoder
+
+	if operation.Output != nil {
+		outputType, _, err := c.convertMessage(operation.Output.Message, operation.Name, style, outputBody, true)
+		if err != nil {
+			return nil, fmt.Errorf("output: %w", err)
+		}
+
+		resultType = outputType
+	}
+
+	if resultType == nil {
+		// one-way operations don't return any content.
+		resultType = schema.NewNullableType(schema.NewNamedType(c.addScalar(rest.ScalarJSON)))
+	}
+
+	result.ResultType = resultType.Encode()
+
+	return result, nil
+}
+
+// convertMessage converts the message to the type of the SOAP body content.
+// The element of the document style message is the content.
+// The RPC style message is wrapped by the element of the operation name.
+// The type is nil if the content is an empty element.
+func (c *converter) convertMessage(
+	messageName string,
+	operationName string,
+	style string,
+	soapBody *SOAPBody,
+	output bool,
+) (schema.TypeEncoder, *rest.TypeSchema, error) {
+	_, name := splitQName(messageName)
+
+	message, ok := c.messages[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("the message %s does not exist", messageName)
+	}
+
+	if style == "rpc" {
+		return c.convertRPCMessage(message, operationName, soapBody, output)
+	}
+
+	switch len(message.Parts) {
+	case 0:
+		return nil, nil, nil
+	case 1:
+	default:
+		return nil, nil, fmt.Errorf("%w: the document style message %s has many parts", errUnsupportedOperation, message.Name)
+	}
+
+	part := message.Parts[0]
+	if part.Element == "" {
+		return nil, nil, fmt.Errorf("%w: the part %s of the document style message %s is not an element", errUnsupportedOperation, part.Name, message.Name)
+	}
+
+	_, elementName := splitQName(part.Element)
+
+	element, ok := c.elements[elementName]
+	if !ok {
+		return nil, nil, fmt.Errorf("the element %s does not exist", part.Element)
+	}
+
+	typeName, typeSchema, err := c.convertRootElement(element)
+	if err != nil || typeName == "" {
+		return nil, typeSchema, err
+	}
+
+	return schema.NewNamedType(typeName), typeSchema, nil
+}
+
+// convertRPCMessage converts parts of the RPC style message to fields of the wrapper element.
+// The wrapper element is qualified by the namespace of the SOAP body while parts are unqualified.
+func (c *converter) convertRPCMessage(
+	message *Message,
+	operationName string,
+	soapBody *SOAPBody,
+	output bool,
+) (schema.TypeEncoder, *rest.TypeSchema, error) {
+	xmlName := operationName
+	typeName := c.formatTypeName(utils.ToPascalCase(operationName) + "Request")
+
+	if output {
+		xmlName += "Response"
+		typeName = c.formatTypeName(utils.ToPascalCase(operationName) + "Response")
+	}
+
+	namespace := c.definitions.TargetNamespace
+	if soapBody != nil && soapBody.Namespace != "" {
+		namespace = soapBody.Namespace
+	}
+
+	objectType := rest.ObjectType{
+		Fields: map[string]rest.ObjectField{},
+		XML: &rest.XMLSchema{
+			Name:      xmlName,
+			Prefix:    "ns",
+			Namespace: namespace,
+		},
+	}
+
+	scope := &schemaScope{
+		schema:     &Schema{TargetNamespace: c.definitions.TargetNamespace},
+		namespaces: c.namespaces,
+	}
+
+	for _, part := range message.Parts {
+		var (
+			fieldType   schema.TypeEncoder
+			fieldSchema *rest.TypeSchema
+			err         error
+		)
+
+		switch {
+		case part.Element != "":
+			fieldType, fieldSchema, err = c.convertElement(Element{Ref: part.Element}, scope, typeName)
+		case part.Type != "":
+			fieldType, fieldSchema, err = c.convertElement(Element{Name: part.Name, Type: part.Type}, scope, typeName)
+		default:
+			err = errors.New("the element or type of the part is required")
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s.%s: %w", message.Name, part.Name, err)
+		}
+
+		objectType.Fields[part.Name] = rest.ObjectField{
+			ObjectField: schema.ObjectField{
+				Type: fieldType.Encode(),
+			},
+			HTTP: fieldSchema,
+		}
+	}
+
+	if len(objectType.Fields) == 0 {
+		return nil, &rest.TypeSchema{
+			XML: objectType.XML,
+		}, nil
+	}
+
+	c.schema.ObjectTypes[typeName] = objectType
+
+	return schema.NewNamedType(typeName), &rest.TypeSchema{
+		Type: []string{"object"},
+	}, nil
+}
+
+// convertRootElement converts the top-level element to the type of the SOAP body content.
+// The object type has the XML name and namespace of the element.
+// The type name is empty if the element doesn't have any content.
+func (c *converter) convertRootElement(element schemaComponent[Element]) (string, *rest.TypeSchema, error) {
+	cacheKey := "element:" + element.value.Name
+	xmlSchema := &rest.XMLSchema{
+		Name:      element.value.Name,
+		Namespace: element.scope.schema.TargetNamespace,
+	}
+
+	// child elements are unqualified by default, so the root element uses the prefix
+	// instead of the default namespace.
+	if element.scope.schema.ElementFormDefault != "qualified" {
+		xmlSchema.Prefix = "tns"
+	}
+
+	if typeName, ok := c.typeNames[cacheKey]; ok {
+		if typeName == "" {
+			return "", &rest.TypeSchema{XML: xmlSchema}, nil
+		}
+
+		return typeName, c.getNamedTypeSchema(typeName, xmlSchema), nil
+	}
+
+	typeName := c.formatTypeName(utils.ToPascalCase(element.value.Name))
+
+	complexType, scope, err := c.getElementComplexType(element)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if complexType == nil {
+		// the content of the element is a simple value.
+		fieldType, _, err := c.convertElement(*element.value, element.scope, "")
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: %w", element.value.Name, err)
+		}
+
+		scalarName := schema.GetUnderlyingNamedType(fieldType.Encode()).Name
+		c.typeNames[cacheKey] = scalarName
+
+		return scalarName, c.getNamedTypeSchema(scalarName, xmlSchema), nil
+	}
+
+	// register the name before converting fields to support recursive types.
+	c.typeNames[cacheKey] = typeName
+
+	objectType, err := c.convertComplexType(complexType, scope, typeName)
+	if err != nil {
+		delete(c.typeNames, cacheKey)
+
+		return "", nil, fmt.Errorf("%s: %w", element.value.Name, err)
+	}
+
+	if len(objectType.Fields) == 0 {
+		c.typeNames[cacheKey] = ""
+
+		return "", &rest.TypeSchema{XML: xmlSchema}, nil
+	}
+
+	objectType.XML = xmlSchema
+
+	if typeName != element.value.Name {
+		objectType.Alias = element.value.Name
+	}
+
+	c.schema.ObjectTypes[typeName] = *objectType
+
+	return typeName, c.getNamedTypeSchema(typeName, xmlSchema), nil
+}
+
+// getElementComplexType returns the anonymous or named complex type of the element.
+func (c *converter) getElementComplexType(element schemaComponent[Element]) (*ComplexType, *schemaScope, error) {
+	if element.value.ComplexType != nil {
+		return element.value.ComplexType, element.scope, nil
+	}
+
+	if element.value.Type == "" || c.isXSDType(element.value.Type, element.scope) {
+		return nil, nil, nil
+	}
+
+	_, name := splitQName(element.value.Type)
+	if complexType, ok := c.complexTypes[name]; ok {
+		return complexType.value, complexType.scope, nil
+	}
+
+	if _, ok := c.simpleTypes[name]; ok {
+		return nil, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("%s: the type %s does not exist", element.value.Name, element.value.Type)
+}
+
+// convertElement converts the element to the NDC type and the HTTP schema of the field.
+// Elements which can occur many times are arrays and optional elements are nullable.
+func (c *converter) convertElement(
+	element Element,
+	scope *schemaScope,
+	parentName string,
+) (schema.TypeEncoder, *rest.TypeSchema, error) {
+	occurrence := element
+
+	if element.Ref != "" {
+		_, name := splitQName(element.Ref)
+
+		refElement, ok := c.elements[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("the element %s does not exist", element.Ref)
+		}
+
+		element = *refElement.value
+		scope = refElement.scope
+	}
+
+	var (
+		typeName string
+		err      error
+	)
+
+	// anonymous types are named after the parent type which is formatted already.
+	anonymousName := parentName + utils.ToPascalCase(element.Name)
+	if parentName == "" {
+		anonymousName = c.formatTypeName(anonymousName)
+	}
+
+	switch {
+	case element.ComplexType != nil:
+		typeName, err = c.convertAnonymousComplexType(element.ComplexType, scope, anonymousName)
+	case element.SimpleType != nil:
+		typeName, err = c.convertSimpleType(element.SimpleType, scope, anonymousName)
+	case element.Type != "":
+		typeName, err = c.convertTypeName(element.Type, scope)
+	default:
+		typeName = c.addScalar(rest.ScalarJSON)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result schema.TypeEncoder = schema.NewNamedType(typeName)
+
+	typeSchema := c.getNamedTypeSchema(typeName, nil)
+
+	if occurrence.IsArray() {
+		result = schema.NewArrayType(result)
+		typeSchema = &rest.TypeSchema{
+			Type:  []string{"array"},
+			Items: typeSchema,
+		}
+	}
+
+	if occurrence.IsOptional() || element.Nillable {
+		result = schema.NewNullableType(result)
+	}
+
+	return result, typeSchema, nil
+}
+
+// convertTypeName converts the qualified name of the builtin or named type.
+func (c *converter) convertTypeName(qname string, scope *schemaScope) (string, error) {
+	_, name := splitQName(qname)
+
+	if c.isXSDType(qname, scope) {
+		scalarName, ok := builtinScalars[name]
+		if !ok {
+			scalarName = rest.ScalarString
+		}
+
+		return c.addScalar(scalarName), nil
+	}
+
+	cacheKey := "type:" + name
+	if typeName, ok := c.typeNames[cacheKey]; ok {
+		return typeName, nil
+	}
+
+	if simpleType, ok := c.simpleTypes[name]; ok {
+		typeName, err := c.convertSimpleType(simpleType.value, simpleType.scope, c.formatComplexTypeName(name))
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+
+		c.typeNames[cacheKey] = typeName
+
+		return typeName, nil
+	}
+
+	complexType, ok := c.complexTypes[name]
+	if !ok {
+		return "", fmt.Errorf("the type %s does not exist", qname)
+	}
+
+	typeName := c.formatComplexTypeName(name)
+	// register the name before converting fields to support recursive types.
+	c.typeNames[cacheKey] = typeName
+
+	objectType, err := c.convertComplexType(complexType.value, complexType.scope, typeName)
+	if err != nil {
+		delete(c.typeNames, cacheKey)
+
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+
+	// object types must have fields, so the element without content is arbitrary.
+	if len(objectType.Fields) == 0 {
+		typeName = c.addScalar(rest.ScalarJSON)
+		c.typeNames[cacheKey] = typeName
+
+		return typeName, nil
+	}
+
+	if typeName != name {
+		objectType.Alias = name
+	}
+
+	c.schema.ObjectTypes[typeName] = *objectType
+
+	return typeName, nil
+}
+
+func (c *converter) convertAnonymousComplexType(complexType *ComplexType, scope *schemaScope, typeName string) (string, error) {
+	objectType, err := c.convertComplexType(complexType, scope, typeName)
+	if err != nil {
+		return "", err
+	}
+
+	if len(objectType.Fields) == 0 {
+		return c.addScalar(rest.ScalarJSON), nil
+	}
+
+	c.schema.ObjectTypes[typeName] = *objectType
+
+	return typeName, nil
+}
+
+// convertComplexType converts elements, attributes and the simple content of the complex type to fields.
+func (c *converter) convertComplexType(complexType *ComplexType, scope *schemaScope, typeName string) (*rest.ObjectType, error) {
+	objectType := &rest.ObjectType{
+		Description: toDescription(complexType.Annotation.GetDescription()),
+		Fields:      map[string]rest.ObjectField{},
+	}
+
+	groups := []*Group{complexType.Sequence, complexType.All, complexType.Choice}
+	choices := map[*Group]bool{
+		complexType.Choice: true,
+	}
+	attributes := complexType.Attributes
+
+	for _, content := range []*Content{complexType.ComplexContent, complexType.SimpleContent} {
+		if content == nil {
+			continue
+		}
+
+		extension := content.Extension
+		if extension == nil {
+			extension = content.Restriction
+		}
+
+		if extension == nil {
+			continue
+		}
+
+		groups = append(groups, extension.Sequence, extension.All, extension.Choice)
+		choices[extension.Choice] = true
+		attributes = append(attributes, extension.Attributes...)
+
+		if extension.Base == "" {
+			continue
+		}
+
+		if content == complexType.SimpleContent {
+			// the text content of the element is stored in the value field.
+			baseName, err := c.convertTypeName(extension.Base, scope)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := c.schema.ScalarTypes[baseName]; ok {
+				typeSchema := c.getNamedTypeSchema(baseName, &rest.XMLSchema{Text: true})
+				objectType.Fields["value"] = rest.ObjectField{
+					ObjectField: schema.ObjectField{
+						Type: schema.NewNullableType(schema.NewNamedType(baseName)).Encode(),
+					},
+					HTTP: typeSchema,
+				}
+
+				continue
+			}
+		}
+
+		// inherit fields of the base type.
+		if err := c.inheritBaseType(objectType, extension.Base, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, group := range groups {
+		if err := c.convertGroup(objectType, group, scope, typeName, choices[group]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, attr := range attributes {
+		if err := c.convertAttribute(objectType, attr, scope, typeName); err != nil {
+			return nil, err
+		}
+	}
+
+	return objectType, nil
+}
+
+func (c *converter) inheritBaseType(objectType *rest.ObjectType, base string, scope *schemaScope) error {
+	if c.isXSDType(base, scope) {
+		return nil
+	}
+
+	baseName, err := c.convertTypeName(base, scope)
+	if err != nil {
+		return err
+	}
+
+	baseType, ok := c.schema.ObjectTypes[baseName]
+	if !ok {
+		return nil
+	}
+
+	for key, field := range baseType.Fields {
+		objectType.Fields[key] = field
+	}
+
+	return nil
+}
+
+// convertGroup converts elements of the model group to fields.
+// Elements of a choice group are nullable because only one of them occurs.
+func (c *converter) convertGroup(
+	objectType *rest.ObjectType,
+	group *Group,
+	scope *schemaScope,
+	typeName string,
+	choice bool,
+) error {
+	if group == nil {
+		return nil
+	}
+
+	for _, element := range group.Elements {
+		fieldType, fieldSchema, err := c.convertElement(element, scope, typeName)
+		if err != nil {
+			return fmt.Errorf("%s: %w", getElementName(element), err)
+		}
+
+		if choice {
+			fieldType = utils.WrapNullableTypeEncoder(fieldType)
+		}
+
+		var description *string
+
+		if element.Annotation != nil {
+			description = toDescription(element.Annotation.GetDescription())
+		}
+
+		objectType.Fields[getElementName(element)] = rest.ObjectField{
+			ObjectField: schema.ObjectField{
+				Description: description,
+				Type:        fieldType.Encode(),
+			},
+			HTTP: fieldSchema,
+		}
+	}
+
+	for i := range group.Sequences {
+		if err := c.convertGroup(objectType, &group.Sequences[i], scope, typeName, choice); err != nil {
+			return err
+		}
+	}
+
+	for i := range group.Choices {
+		if err := c.convertGroup(objectType, &group.Choices[i], scope, typeName, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) convertAttribute(objectType *rest.ObjectType, attr Attribute, scope *schemaScope, typeName string) error {
+	var (
+		attrTypeName string
+		err          error
+	)
+
+	switch {
+	case attr.SimpleType != nil:
+		attrTypeName, err = c.convertSimpleType(attr.SimpleType, scope, typeName+utils.ToPascalCase(attr.Name))
+	case attr.Type != "":
+		attrTypeName, err = c.convertTypeName(attr.Type, scope)
+	default:
+		attrTypeName = c.addScalar(rest.ScalarString)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", attr.Name, err)
+	}
+
+	var attrType schema.TypeEncoder = schema.NewNamedType(attrTypeName)
+	if attr.Use != "required" {
+		attrType = schema.NewNullableType(attrType)
+	}
+
+	objectType.Fields[attr.Name] = rest.ObjectField{
+		ObjectField: schema.ObjectField{
+			Description: toDescription(attr.Annotation.GetDescription()),
+			Type:        attrType.Encode(),
+		},
+		HTTP: c.getNamedTypeSchema(attrTypeName, &rest.XMLSchema{Attribute: true}),
+	}
+
+	return nil
+}
+
+// convertSimpleType converts the simple type to a scalar.
+// Restrictions with enumerations are converted to enum scalars. Lists and unions are strings.
+func (c *converter) convertSimpleType(simpleType *SimpleType, scope *schemaScope, typeName string) (string, error) {
+	restriction := simpleType.Restriction
+	if restriction == nil {
+		return c.addScalar(rest.ScalarString), nil
+	}
+
+	if len(restriction.Enumerations) == 0 {
+		if restriction.Base == "" {
+			return c.addScalar(rest.ScalarString), nil
+		}
+
+		return c.convertTypeName(restriction.Base, scope)
+	}
+
+	values := make([]string, len(restriction.Enumerations))
+
+	for i, enum := range restriction.Enumerations {
+		values[i] = enum.Value
+	}
+
+	scalar := schema.NewScalarType()
+	scalar.Representation = schema.NewTypeRepresentationEnum(values).Encode()
+	c.schema.AddScalar(typeName, *scalar)
+
+	return typeName, nil
+}
+
+// isXSDType checks if the qualified name belongs to the XML schema namespace.
+func (c *converter) isXSDType(qname string, scope *schemaScope) bool {
+	prefix, _ := splitQName(qname)
+
+	return scope.namespaces[prefix] == xsdNamespace
+}
+
+func (c *converter) addScalar(scalarName rest.ScalarName) string {
+	scalar := schema.NewScalarType()
+	scalar.Representation = builtinScalarRepresentations[scalarName]
+	c.schema.AddScalar(string(scalarName), *scalar)
+
+	return string(scalarName)
+}
+
+// getNamedTypeSchema returns the HTTP schema of the named type which is used to encode and decode XML values.
+func (c *converter) getNamedTypeSchema(typeName string, xmlSchema *rest.XMLSchema) *rest.TypeSchema {
+	result := &rest.TypeSchema{
+		XML: xmlSchema,
+	}
+
+	if _, ok := c.schema.ObjectTypes[typeName]; ok {
+		result.Type = []string{"object"}
+
+		return result
+	}
+
+	switch rest.ScalarName(typeName) {
+	case rest.ScalarBoolean:
+		result.Type = []string{"boolean"}
+	case rest.ScalarInt32, rest.ScalarInt64:
+		result.Type = []string{"integer"}
+	case rest.ScalarFloat32, rest.ScalarFloat64:
+		result.Type = []string{"number"}
+	case rest.ScalarJSON:
+	default:
+		if _, ok := c.schema.ScalarTypes[typeName]; ok {
+			result.Type = []string{"string"}
+		} else {
+			// the object type is being converted.
+			result.Type = []string{"object"}
+		}
+	}
+
+	return result
+}
+
+func (c *converter) formatOperationName(name string) string {
+	if c.options.Prefix == "" {
+		return utils.ToCamelCase(name)
+	}
+
+	return utils.StringSliceToCamelCase([]string{c.options.Prefix, name})
+}
+
+func (c *converter) formatTypeName(name string) string {
+	if c.options.Prefix == "" {
+		return name
+	}
+
+	return utils.ToPascalCase(c.options.Prefix) + name
+}
+
+// formatComplexTypeName formats the name of the named type.
+// Elements and types are in different symbol spaces,
+// so the type name is suffixed if an element of the same name exists.
+func (c *converter) formatComplexTypeName(name string) string {
+	typeName := utils.ToPascalCase(name)
+
+	for elementName := range c.elements {
+		if utils.ToPascalCase(elementName) == typeName {
+			typeName += "Type"
+
+			break
+		}
+	}
+
+	return c.formatTypeName(typeName)
+}
+
+func getSOAPBody(message *BindingMessage, version rest.SOAPVersion) *SOAPBody {
+	if message == nil {
+		return nil
+	}
+
+	if version == rest.SOAPVersion12 {
+		return message.SOAP12Body
+	}
+
+	return message.SOAP11Body
+}
+
+func getElementName(element Element) string {
+	if element.Name != "" {
+		return element.Name
+	}
+
+	_, name := splitQName(element.Ref)
+
+	return name
+}
+
+// isFunctionOperation checks if the operation is read-only by the leading verb of the name,
+// e.g. GetWeather or listOrders.
+func isFunctionOperation(name string) bool {
+	end := len(name)
+
+	for i, r := range name {
+		if i > 0 && (r >= 'A' && r <= 'Z' || r == '_') {
+			end = i
+
+			break
+		}
+	}
+
+	return functionVerbs[strings.ToLower(name[:end])]
+}
+
+func toDescription(description string) *string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil
+	}
+
+	return &description
+}
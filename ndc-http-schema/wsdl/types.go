@@ -0,0 +1,257 @@
+package wsdl
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+const xsdNamespace = "http://www.w3.org/2001/XMLSchema"
+
+// Definitions represents the root element of a WSDL 1.1 document.
+type Definitions struct {
+	Name            string     `xml:"name,attr"`
+	TargetNamespace string     `xml:"targetNamespace,attr"`
+	Attrs           []xml.Attr `xml:",any,attr"`
+	Documentation   string     `xml:"documentation"`
+	Types           Types      `xml:"types"`
+	Messages        []Message  `xml:"message"`
+	PortTypes       []PortType `xml:"portType"`
+	Bindings        []Binding  `xml:"binding"`
+	Services        []Service  `xml:"service"`
+}
+
+// Types contains XML schemas of messages.
+type Types struct {
+	Schemas []Schema `xml:"schema"`
+}
+
+// Message represents an abstract message of operations.
+type Message struct {
+	Name  string `xml:"name,attr"`
+	Parts []Part `xml:"part"`
+}
+
+// Part represents a part of the message which refers to either an element or a type.
+type Part struct {
+	Name    string `xml:"name,attr"`
+	Element string `xml:"element,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+// PortType represents a set of abstract operations.
+type PortType struct {
+	Name       string      `xml:"name,attr"`
+	Operations []Operation `xml:"operation"`
+}
+
+// Operation represents an abstract operation of the port type.
+type Operation struct {
+	Name          string            `xml:"name,attr"`
+	Documentation string            `xml:"documentation"`
+	Input         *OperationMessage `xml:"input"`
+	Output        *OperationMessage `xml:"output"`
+}
+
+// OperationMessage refers to the message of the operation input or output.
+type OperationMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// Binding represents the protocol details of operations of a port type.
+type Binding struct {
+	Name       string             `xml:"name,attr"`
+	Type       string             `xml:"type,attr"`
+	SOAP11     *SOAPBinding       `xml:"http://schemas.xmlsoap.org/wsdl/soap/ binding"`
+	SOAP12     *SOAPBinding       `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ binding"`
+	Operations []BindingOperation `xml:"operation"`
+}
+
+// SOAPBinding represents the SOAP binding extension.
+type SOAPBinding struct {
+	Style     string `xml:"style,attr"`
+	Transport string `xml:"transport,attr"`
+}
+
+// BindingOperation represents the protocol details of an operation.
+type BindingOperation struct {
+	Name   string          `xml:"name,attr"`
+	SOAP11 *SOAPOperation  `xml:"http://schemas.xmlsoap.org/wsdl/soap/ operation"`
+	SOAP12 *SOAPOperation  `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ operation"`
+	Input  *BindingMessage `xml:"input"`
+	Output *BindingMessage `xml:"output"`
+}
+
+// SOAPOperation represents the SOAP operation extension.
+type SOAPOperation struct {
+	SOAPAction string `xml:"soapAction,attr"`
+	Style      string `xml:"style,attr"`
+}
+
+// BindingMessage represents the protocol details of the operation input or output.
+type BindingMessage struct {
+	SOAP11Body *SOAPBody `xml:"http://schemas.xmlsoap.org/wsdl/soap/ body"`
+	SOAP12Body *SOAPBody `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ body"`
+}
+
+// SOAPBody represents the SOAP body extension.
+type SOAPBody struct {
+	Use       string `xml:"use,attr"`
+	Namespace string `xml:"namespace,attr"`
+}
+
+// Service represents a set of ports.
+type Service struct {
+	Name          string `xml:"name,attr"`
+	Documentation string `xml:"documentation"`
+	Ports         []Port `xml:"port"`
+}
+
+// Port represents an endpoint of a binding.
+type Port struct {
+	Name          string       `xml:"name,attr"`
+	Binding       string       `xml:"binding,attr"`
+	SOAP11Address *SOAPAddress `xml:"http://schemas.xmlsoap.org/wsdl/soap/ address"`
+	SOAP12Address *SOAPAddress `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ address"`
+}
+
+// SOAPAddress represents the SOAP address extension.
+type SOAPAddress struct {
+	Location string `xml:"location,attr"`
+}
+
+// Schema represents an embedded XML schema.
+type Schema struct {
+	TargetNamespace    string        `xml:"targetNamespace,attr"`
+	ElementFormDefault string        `xml:"elementFormDefault,attr"`
+	Attrs              []xml.Attr    `xml:",any,attr"`
+	Elements           []Element     `xml:"element"`
+	ComplexTypes       []ComplexType `xml:"complexType"`
+	SimpleTypes        []SimpleType  `xml:"simpleType"`
+}
+
+// Element represents an XML schema element.
+type Element struct {
+	Name        string       `xml:"name,attr"`
+	Type        string       `xml:"type,attr"`
+	Ref         string       `xml:"ref,attr"`
+	MinOccurs   string       `xml:"minOccurs,attr"`
+	MaxOccurs   string       `xml:"maxOccurs,attr"`
+	Nillable    bool         `xml:"nillable,attr"`
+	Annotation  *Annotation  `xml:"annotation"`
+	ComplexType *ComplexType `xml:"complexType"`
+	SimpleType  *SimpleType  `xml:"simpleType"`
+}
+
+// IsArray checks if the element can occur many times.
+func (e Element) IsArray() bool {
+	return e.MaxOccurs != "" && e.MaxOccurs != "0" && e.MaxOccurs != "1"
+}
+
+// IsOptional checks if the element can be omitted or nil.
+func (e Element) IsOptional() bool {
+	return e.MinOccurs == "0" || e.Nillable
+}
+
+// Annotation represents the documentation of a schema component.
+type Annotation struct {
+	Documentation string `xml:"documentation"`
+}
+
+// GetDescription returns the documentation of the annotation.
+func (a *Annotation) GetDescription() string {
+	if a == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(a.Documentation)
+}
+
+// ComplexType represents an XML schema complex type.
+type ComplexType struct {
+	Name           string      `xml:"name,attr"`
+	Annotation     *Annotation `xml:"annotation"`
+	Sequence       *Group      `xml:"sequence"`
+	All            *Group      `xml:"all"`
+	Choice         *Group      `xml:"choice"`
+	Attributes     []Attribute `xml:"attribute"`
+	ComplexContent *Content    `xml:"complexContent"`
+	SimpleContent  *Content    `xml:"simpleContent"`
+}
+
+// Group represents a sequence, all or choice model group.
+type Group struct {
+	Elements  []Element `xml:"element"`
+	Sequences []Group   `xml:"sequence"`
+	Choices   []Group   `xml:"choice"`
+}
+
+// Content represents the complex or simple content of a complex type.
+type Content struct {
+	Extension   *Extension `xml:"extension"`
+	Restriction *Extension `xml:"restriction"`
+}
+
+// Extension represents the extension or restriction of a base type.
+type Extension struct {
+	Base       string      `xml:"base,attr"`
+	Sequence   *Group      `xml:"sequence"`
+	All        *Group      `xml:"all"`
+	Choice     *Group      `xml:"choice"`
+	Attributes []Attribute `xml:"attribute"`
+}
+
+// Attribute represents an XML schema attribute.
+type Attribute struct {
+	Name       string      `xml:"name,attr"`
+	Type       string      `xml:"type,attr"`
+	Use        string      `xml:"use,attr"`
+	Annotation *Annotation `xml:"annotation"`
+	SimpleType *SimpleType `xml:"simpleType"`
+}
+
+// SimpleType represents an XML schema simple type.
+type SimpleType struct {
+	Name        string       `xml:"name,attr"`
+	Annotation  *Annotation  `xml:"annotation"`
+	Restriction *Restriction `xml:"restriction"`
+	List        *struct{}    `xml:"list"`
+	Union       *struct{}    `xml:"union"`
+}
+
+// Restriction represents the restriction of a simple type.
+type Restriction struct {
+	Base         string        `xml:"base,attr"`
+	Enumerations []Enumeration `xml:"enumeration"`
+}
+
+// Enumeration represents an allowed value of a simple type.
+type Enumeration struct {
+	Value string `xml:"value,attr"`
+}
+
+// splitQName splits the qualified name to the prefix and the local name.
+func splitQName(name string) (string, string) {
+	prefix, local, ok := strings.Cut(name, ":")
+	if !ok {
+		return "", name
+	}
+
+	return prefix, local
+}
+
+// getNamespaces gets namespace declarations of the element, indexed by prefix.
+// The default namespace has the empty prefix.
+func getNamespaces(attrs []xml.Attr) map[string]string {
+	results := map[string]string{}
+
+	for _, attr := range attrs {
+		switch {
+		case attr.Name.Space == "xmlns":
+			results[attr.Name.Local] = attr.Value
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			results[""] = attr.Value
+		}
+	}
+
+	return results
+}
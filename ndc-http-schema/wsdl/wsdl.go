@@ -0,0 +1,32 @@
+package wsdl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	rest "github.com/hasura/ndc-http/ndc-http-schema/schema"
+)
+
+// WSDLToNDCSchema converts a WSDL 1.1 document with embedded XML schemas to NDC HTTP schema.
+// Operations of SOAP bindings are converted to functions and procedures
+// which send SOAP envelopes to the address of the port.
+func WSDLToNDCSchema(input []byte, options openapi.ConvertOptions) (*rest.NDCHttpSchema, []error) {
+	var definitions Definitions
+
+	if err := xml.Unmarshal(bytes.TrimPrefix(input, []byte("\xef\xbb\xbf")), &definitions); err != nil {
+		return nil, []error{err}
+	}
+
+	if len(definitions.PortTypes) == 0 {
+		return nil, []error{errors.New("the WSDL document has no port type")}
+	}
+
+	result, err := newConverter(&definitions, options).Build()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return result, nil
+}
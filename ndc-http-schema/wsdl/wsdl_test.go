@@ -0,0 +1,96 @@
+package wsdl
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hasura/ndc-http/ndc-http-schema/internal/testutil"
+	"github.com/hasura/ndc-http/ndc-http-schema/openapi"
+	"gotest.tools/v3/assert"
+)
+
+func TestWSDLToNDCSchema(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Source   string
+		Expected string
+		Schema   string
+		Options  openapi.ConvertOptions
+	}{
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/wsdl/testdata/weather/source.wsdl -o ./ndc-http-schema/wsdl/testdata/weather/expected.json --spec wsdl --env-prefix WEATHER
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/wsdl/testdata/weather/source.wsdl -o ./ndc-http-schema/wsdl/testdata/weather/schema.json --pure --spec wsdl --env-prefix WEATHER
+		{
+			Name:     "weather",
+			Source:   "testdata/weather/source.wsdl",
+			Expected: "testdata/weather/expected.json",
+			Schema:   "testdata/weather/schema.json",
+			Options: openapi.ConvertOptions{
+				EnvPrefix: "WEATHER",
+			},
+		},
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/wsdl/testdata/calculator/source.wsdl -o ./ndc-http-schema/wsdl/testdata/calculator/expected.json --spec wsdl --prefix calc
+		// go run ./ndc-http-schema convert -f ./ndc-http-schema/wsdl/testdata/calculator/source.wsdl -o ./ndc-http-schema/wsdl/testdata/calculator/schema.json --pure --spec wsdl --prefix calc
+		{
+			Name:     "calculator",
+			Source:   "testdata/calculator/source.wsdl",
+			Expected: "testdata/calculator/expected.json",
+			Schema:   "testdata/calculator/schema.json",
+			Options: openapi.ConvertOptions{
+				Prefix: "calc",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sourceBytes, err := os.ReadFile(tc.Source)
+			assert.NilError(t, err)
+
+			output, errs := WSDLToNDCSchema(sourceBytes, tc.Options)
+			if output == nil {
+				t.Fatal(errors.Join(errs...))
+			}
+
+			testutil.AssertJSONFileEqual(t, tc.Expected, output)
+			testutil.AssertJSONFileEqual(t, tc.Schema, output.ToSchemaResponse())
+		})
+	}
+
+	t.Run("failure_invalid_xml", func(t *testing.T) {
+		_, errs := WSDLToNDCSchema([]byte(`{"openapi": "3.0.0"}`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "EOF")
+	})
+
+	t.Run("failure_empty", func(t *testing.T) {
+		_, errs := WSDLToNDCSchema([]byte(`<definitions xmlns="http://schemas.xmlsoap.org/wsdl/">
+			<portType name="Empty" />
+		</definitions>`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "there is no API to be converted")
+	})
+
+	t.Run("failure_unknown_binding", func(t *testing.T) {
+		_, errs := WSDLToNDCSchema([]byte(`<definitions xmlns="http://schemas.xmlsoap.org/wsdl/" xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/">
+			<portType name="Empty" />
+			<service name="Service">
+				<port name="Port" binding="tns:Binding">
+					<soap:address location="http://localhost" />
+				</port>
+			</service>
+		</definitions>`), openapi.ConvertOptions{})
+		assert.ErrorContains(t, errors.Join(errs...), "Service.Port: the binding tns:Binding does not exist")
+	})
+}
+
+func TestIsFunctionOperation(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"GetForecast":    true,
+		"listCities":     true,
+		"Is_Valid":       true,
+		"SubmitReport":   false,
+		"Add":            false,
+		"GetterSettings": false,
+	} {
+		assert.Equal(t, expected, isFunctionOperation(name), name)
+	}
+}